@@ -0,0 +1,226 @@
+package kalgo
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrBloomFilterMismatch = errors.New("bloom filter parameters mismatch")
+	ErrInvalidBloomData    = errors.New("invalid bloom filter data")
+)
+
+// bloomHeaderSize 序列化头部长度: m(8字节) + k(8字节) + count(8字节)
+const bloomHeaderSize = 24
+
+// BloomFilter 布隆过滤器,用于判断元素是否可能存在
+// 判断不存在时一定不存在,判断存在时有一定概率误判
+type BloomFilter struct {
+	mu    sync.RWMutex
+	bits  []uint64
+	m     uint64 // 位数组长度
+	k     uint64 // 哈希函数个数
+	count uint64 // 已添加的元素个数(近似,重复添加会重复计数)
+}
+
+// NewBloomFilter 根据预期元素数量和误判率创建布隆过滤器
+//
+// 参数说明:
+//   - n: 预期添加的元素数量
+//   - fpRate: 期望的误判率,范围为(0, 1)
+//
+// 返回值说明:
+//   - *BloomFilter: 新创建的布隆过滤器
+//
+// 注意事项:
+//   - n为0或fpRate不在(0, 1)范围内会panic
+//   - 位数组长度 m = -n*ln(p)/(ln2)^2,哈希函数个数 k = m/n*ln2
+//   - 实际添加数量超过n后误判率会上升
+//   - 线程安全
+//
+// 示例:
+//
+//	bf := NewBloomFilter(10000, 0.01)
+//	bf.AddString("hello")
+//	bf.MightContainString("hello") // true
+//	bf.MightContainString("world") // 大概率为false
+func NewBloomFilter(n uint64, fpRate float64) *BloomFilter {
+	if n == 0 {
+		panic("n must be greater than 0")
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		panic("fpRate must be in (0, 1)")
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Max(1, math.Round(float64(m)/float64(n)*math.Ln2)))
+	return NewBloomFilterWithSize(m, k)
+}
+
+// NewBloomFilterWithSize 使用指定的位数组长度和哈希函数个数创建布隆过滤器
+//
+// 参数说明:
+//   - m: 位数组长度,会向上取整为64的倍数
+//   - k: 哈希函数个数
+//
+// 注意事项:
+//   - m或k为0时会panic
+func NewBloomFilterWithSize(m, k uint64) *BloomFilter {
+	if m == 0 || k == 0 {
+		panic("m and k must be greater than 0")
+	}
+	words := (m + 63) / 64
+	return &BloomFilter{
+		bits: make([]uint64, words),
+		m:    words * 64,
+		k:    k,
+	}
+}
+
+// Add 添加一个元素
+func (b *BloomFilter) Add(data []byte) {
+	h1, h2 := bloomHash(data)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+	b.count++
+}
+
+// AddString 添加一个字符串元素
+func (b *BloomFilter) AddString(s string) {
+	b.Add([]byte(s))
+}
+
+// MightContain 判断元素是否可能存在
+//
+// 返回值说明:
+//   - bool: false表示一定不存在,true表示可能存在
+func (b *BloomFilter) MightContain(data []byte) bool {
+	h1, h2 := bloomHash(data)
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MightContainString 判断字符串元素是否可能存在
+func (b *BloomFilter) MightContainString(s string) bool {
+	return b.MightContain([]byte(s))
+}
+
+// Merge 将另一个布隆过滤器合并到当前过滤器,合并后包含两者的所有元素
+//
+// 返回值说明:
+//   - error: 两个过滤器的m或k不同时返回 ErrBloomFilterMismatch
+func (b *BloomFilter) Merge(other *BloomFilter) error {
+	if b == other {
+		return nil
+	}
+	// 先复制other的数据再加锁当前过滤器,避免交叉合并时死锁
+	other.mu.RLock()
+	m, k, count := other.m, other.k, other.count
+	bits := slices.Clone(other.bits)
+	other.mu.RUnlock()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.m != m || b.k != k {
+		return errors.Wrapf(ErrBloomFilterMismatch, "m: %d/%d, k: %d/%d", b.m, m, b.k, k)
+	}
+	for i := range b.bits {
+		b.bits[i] |= bits[i]
+	}
+	b.count += count
+	return nil
+}
+
+// Count 返回已添加的元素个数,重复添加的元素会被重复计数
+func (b *BloomFilter) Count() uint64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.count
+}
+
+// Cap 返回位数组长度m和哈希函数个数k
+func (b *BloomFilter) Cap() (m, k uint64) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.m, b.k
+}
+
+// Reset 清空所有元素
+func (b *BloomFilter) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clear(b.bits)
+	b.count = 0
+}
+
+// MarshalBinary 将布隆过滤器序列化为二进制,实现 encoding.BinaryMarshaler
+//
+// 注意事项:
+//   - 格式为大端序的 m | k | count | 位数组
+func (b *BloomFilter) MarshalBinary() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	data := make([]byte, bloomHeaderSize+len(b.bits)*8)
+	binary.BigEndian.PutUint64(data[0:], b.m)
+	binary.BigEndian.PutUint64(data[8:], b.k)
+	binary.BigEndian.PutUint64(data[16:], b.count)
+	for i, w := range b.bits {
+		binary.BigEndian.PutUint64(data[bloomHeaderSize+i*8:], w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从二进制数据恢复布隆过滤器,实现 encoding.BinaryUnmarshaler
+//
+// 返回值说明:
+//   - error: 数据格式不正确时返回 ErrInvalidBloomData
+//
+// 示例:
+//
+//	data, _ := bf.MarshalBinary()
+//	restored := &BloomFilter{}
+//	err := restored.UnmarshalBinary(data)
+func (b *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < bloomHeaderSize {
+		return errors.Wrap(ErrInvalidBloomData, "data too short")
+	}
+	m := binary.BigEndian.Uint64(data[0:])
+	k := binary.BigEndian.Uint64(data[8:])
+	count := binary.BigEndian.Uint64(data[16:])
+	if m == 0 || k == 0 || m%64 != 0 || uint64(len(data)-bloomHeaderSize) != m/8 {
+		return errors.Wrapf(ErrInvalidBloomData, "m: %d, k: %d, length: %d", m, k, len(data))
+	}
+	bits := make([]uint64, m/64)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(data[bloomHeaderSize+i*8:])
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.m, b.k, b.count, b.bits = m, k, count, bits
+	return nil
+}
+
+// bloomHash 计算两个独立的哈希值,通过 h1+i*h2 模拟k个哈希函数
+func bloomHash(data []byte) (h1, h2 uint64) {
+	h := fnv.New64a()
+	h.Write(data)
+	h1 = h.Sum64()
+	h.Write([]byte{0})
+	h2 = h.Sum64() | 1 // 保证h2为奇数,避免多个位置重合
+	return h1, h2
+}
@@ -0,0 +1,71 @@
+package kalgo
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBloomFilter(t *testing.T) {
+	t.Run("添加和判断", func(t *testing.T) {
+		bf := NewBloomFilter(1000, 0.01)
+		for i := 0; i < 1000; i++ {
+			bf.AddString(strconv.Itoa(i))
+		}
+		for i := 0; i < 1000; i++ {
+			assert.True(t, bf.MightContainString(strconv.Itoa(i)))
+		}
+		falsePositive := 0
+		for i := 1000; i < 11000; i++ {
+			if bf.MightContainString(strconv.Itoa(i)) {
+				falsePositive++
+			}
+		}
+		assert.Less(t, float64(falsePositive)/10000, 0.03)
+		assert.Equal(t, uint64(1000), bf.Count())
+
+		bf.Reset()
+		assert.False(t, bf.MightContainString("1"))
+		assert.Equal(t, uint64(0), bf.Count())
+	})
+
+	t.Run("合并", func(t *testing.T) {
+		a := NewBloomFilter(100, 0.01)
+		b := NewBloomFilter(100, 0.01)
+		a.AddString("a")
+		b.AddString("b")
+		assert.NoError(t, a.Merge(b))
+		assert.True(t, a.MightContainString("a"))
+		assert.True(t, a.MightContainString("b"))
+
+		c := NewBloomFilter(1000, 0.01)
+		assert.True(t, errors.Is(a.Merge(c), ErrBloomFilterMismatch))
+	})
+
+	t.Run("序列化", func(t *testing.T) {
+		bf := NewBloomFilter(100, 0.01)
+		bf.Add([]byte("hello"))
+		data, err := bf.MarshalBinary()
+		assert.NoError(t, err)
+
+		restored := &BloomFilter{}
+		assert.NoError(t, restored.UnmarshalBinary(data))
+		assert.True(t, restored.MightContain([]byte("hello")))
+		assert.Equal(t, uint64(1), restored.Count())
+		m1, k1 := bf.Cap()
+		m2, k2 := restored.Cap()
+		assert.Equal(t, m1, m2)
+		assert.Equal(t, k1, k2)
+
+		assert.True(t, errors.Is(restored.UnmarshalBinary(data[:10]), ErrInvalidBloomData))
+		assert.True(t, errors.Is(restored.UnmarshalBinary(data[:len(data)-1]), ErrInvalidBloomData))
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		assert.Panics(t, func() { NewBloomFilter(0, 0.01) })
+		assert.Panics(t, func() { NewBloomFilter(100, 1) })
+		assert.Panics(t, func() { NewBloomFilterWithSize(0, 1) })
+	})
+}
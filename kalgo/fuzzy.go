@@ -0,0 +1,112 @@
+package kalgo
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// FuzzyMatch 模糊匹配结果
+type FuzzyMatch struct {
+	Value string  // 匹配到的候选字符串
+	Index int     // 候选字符串在原切片中的位置
+	Score float64 // 相似度,范围为[0, 1]
+}
+
+// Levenshtein 计算两个字符串的编辑距离
+//
+// 参数说明:
+//   - a: 第一个字符串
+//   - b: 第二个字符串
+//
+// 返回值说明:
+//   - int: 将a变为b所需的最少单字符插入、删除、替换次数
+//
+// 注意事项:
+//   - 按rune计算,中文等多字节字符算作一个字符
+//   - 时间复杂度为O(m*n),空间复杂度为O(min(m,n))
+//
+// 示例:
+//
+//	d := Levenshtein("kitten", "sitting")
+//	// d = 3
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+	// 只保留一行,row[j]表示ra[:i]与rb[:j]的编辑距离
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0] // ra[:i-1]与rb[:j-1]的编辑距离
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cur := row[j]
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			row[j] = min(row[j]+1, row[j-1]+1, prev+cost)
+			prev = cur
+		}
+	}
+	return row[len(rb)]
+}
+
+// Similarity 根据编辑距离计算两个字符串的相似度
+//
+// 返回值说明:
+//   - float64: 1 - 编辑距离/较长字符串的长度,范围为[0, 1],两个空字符串相似度为1
+//
+// 示例:
+//
+//	s := Similarity("kitten", "sitting")
+//	// s ≈ 0.571
+func Similarity(a, b string) float64 {
+	maxLen := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(maxLen)
+}
+
+// FuzzyFind 在候选字符串中查找与query相似的项,适用于命令行拼写纠错、后台搜索等场景
+//
+// 参数说明:
+//   - candidates: 候选字符串
+//   - query: 查询字符串
+//   - threshold: 相似度阈值,范围为[0, 1],只返回相似度不低于该值的候选项
+//
+// 返回值说明:
+//   - []FuzzyMatch: 匹配结果,按相似度从高到低排序,相似度相同时保持原有顺序
+//
+// 注意事项:
+//   - 区分大小写,需要忽略大小写时请先统一转换
+//
+// 示例:
+//
+//	matches := FuzzyFind([]string{"status", "start", "stop"}, "stats", 0.5)
+//	// matches[0].Value = "status"
+func FuzzyFind(candidates []string, query string, threshold float64) []FuzzyMatch {
+	matches := make([]FuzzyMatch, 0)
+	for i, c := range candidates {
+		if score := Similarity(c, query); score >= threshold {
+			matches = append(matches, FuzzyMatch{Value: c, Index: i, Score: score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b FuzzyMatch) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	return matches
+}
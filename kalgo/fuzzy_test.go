@@ -0,0 +1,47 @@
+package kalgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"", "", 0},
+		{"flaw", "lawn", 2},
+		{"same", "same", 0},
+		{"中文字符", "中国字符", 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Levenshtein(tt.a, tt.b), "%s -> %s", tt.a, tt.b)
+		assert.Equal(t, tt.expected, Levenshtein(tt.b, tt.a), "%s -> %s", tt.b, tt.a)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("", ""))
+	assert.Equal(t, 1.0, Similarity("abc", "abc"))
+	assert.Equal(t, 0.0, Similarity("abc", "xyz"))
+	assert.InDelta(t, 4.0/7, Similarity("kitten", "sitting"), 1e-9)
+}
+
+func TestFuzzyFind(t *testing.T) {
+	candidates := []string{"start", "status", "stop", "restart"}
+	matches := FuzzyFind(candidates, "stats", 0.5)
+	assert.NotEmpty(t, matches)
+	assert.Equal(t, "status", matches[0].Value)
+	assert.Equal(t, 1, matches[0].Index)
+	for i := 1; i < len(matches); i++ {
+		assert.GreaterOrEqual(t, matches[i-1].Score, matches[i].Score)
+	}
+
+	assert.Empty(t, FuzzyFind(candidates, "zzzzzz", 0.5))
+	assert.Len(t, FuzzyFind(candidates, "anything", 0), len(candidates))
+}
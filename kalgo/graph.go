@@ -0,0 +1,230 @@
+package kalgo
+
+import (
+	"container/heap"
+	"slices"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Weight 边权重类型约束
+type Weight interface {
+	constraints.Integer | constraints.Float
+}
+
+// Graph 轻量级的带权图,支持有向图和无向图
+type Graph[N comparable, W Weight] struct {
+	mu       sync.RWMutex
+	directed bool
+	nodes    []N           // 节点的加入顺序
+	edges    map[N]map[N]W // 邻接表 from -> to -> weight
+}
+
+// NewGraph 创建一个新的图
+//
+// 参数说明:
+//   - directed: 是否为有向图,为false时每条边都是双向的
+//
+// 注意事项:
+//   - 线程安全
+//
+// 示例:
+//
+//	g := NewGraph[string, int](true)
+//	g.AddEdge("a", "b", 1)
+//	g.AddEdge("b", "c", 2)
+//	g.AddEdge("a", "c", 5)
+//	path, dist, ok := g.ShortestPath("a", "c")
+//	// path = [a b c], dist = 3, ok = true
+func NewGraph[N comparable, W Weight](directed bool) *Graph[N, W] {
+	return &Graph[N, W]{
+		directed: directed,
+		edges:    make(map[N]map[N]W),
+	}
+}
+
+// AddNode 加入一个节点,已存在时不做任何操作
+func (g *Graph[N, W]) AddNode(n N) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.addNode(n)
+}
+
+// AddEdge 加入一条边,边已存在时更新权重
+//
+// 参数说明:
+//   - from: 起点
+//   - to: 终点
+//   - w: 权重,不能为负数
+//
+// 注意事项:
+//   - 权重为负数时会panic,Dijkstra算法不支持负权边
+//   - 节点不存在时会自动加入
+func (g *Graph[N, W]) AddEdge(from, to N, w W) {
+	if w < 0 {
+		panic("weight must not be negative")
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.addNode(from)
+	g.addNode(to)
+	g.edges[from][to] = w
+	if !g.directed {
+		g.edges[to][from] = w
+	}
+}
+
+// RemoveEdge 删除一条边
+//
+// 返回值说明:
+//   - bool: 边是否存在
+func (g *Graph[N, W]) RemoveEdge(from, to N) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.edges[from][to]; !ok {
+		return false
+	}
+	delete(g.edges[from], to)
+	if !g.directed {
+		delete(g.edges[to], from)
+	}
+	return true
+}
+
+// Nodes 返回所有节点,按加入顺序排列
+func (g *Graph[N, W]) Nodes() []N {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Clone(g.nodes)
+}
+
+// Neighbors 返回节点的所有出边邻居及权重
+func (g *Graph[N, W]) Neighbors(n N) map[N]W {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	neighbors := make(map[N]W, len(g.edges[n]))
+	for to, w := range g.edges[n] {
+		neighbors[to] = w
+	}
+	return neighbors
+}
+
+// ShortestPath 使用Dijkstra算法计算两点间权重之和最小的路径
+//
+// 参数说明:
+//   - from: 起点
+//   - to: 终点
+//
+// 返回值说明:
+//   - []N: 从起点到终点的路径,包含起点和终点
+//   - W: 路径的总权重
+//   - bool: 是否存在路径
+//
+// 注意事项:
+//   - 时间复杂度为O((V+E)logV)
+//   - from与to相同时返回只包含该节点的路径
+func (g *Graph[N, W]) ShortestPath(from, to N) ([]N, W, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, ok := g.edges[from]; !ok {
+		return nil, 0, false
+	}
+	dist := map[N]W{from: 0}
+	prev := make(map[N]N)
+	visited := make(map[N]bool)
+	pq := &dijkstraQueue[N, W]{{node: from}}
+	for pq.Len() > 0 {
+		cur := heap.Pop(pq).(dijkstraItem[N, W])
+		if visited[cur.node] {
+			continue
+		}
+		visited[cur.node] = true
+		if cur.node == to {
+			return buildPath(prev, from, to), cur.dist, true
+		}
+		for next, w := range g.edges[cur.node] {
+			nd := cur.dist + w
+			if d, ok := dist[next]; !ok || nd < d {
+				dist[next] = nd
+				prev[next] = cur.node
+				heap.Push(pq, dijkstraItem[N, W]{node: next, dist: nd})
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// BFSPath 使用广度优先搜索计算两点间边数最少的路径,忽略权重
+//
+// 参数说明:
+//   - from: 起点
+//   - to: 终点
+//
+// 返回值说明:
+//   - []N: 从起点到终点的路径,包含起点和终点
+//   - bool: 是否存在路径
+func (g *Graph[N, W]) BFSPath(from, to N) ([]N, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, ok := g.edges[from]; !ok {
+		return nil, false
+	}
+	prev := make(map[N]N)
+	visited := map[N]bool{from: true}
+	queue := []N{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == to {
+			return buildPath(prev, from, to), true
+		}
+		for next := range g.edges[cur] {
+			if !visited[next] {
+				visited[next] = true
+				prev[next] = cur
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil, false
+}
+
+// addNode 加入节点,调用方需持有锁
+func (g *Graph[N, W]) addNode(n N) {
+	if _, ok := g.edges[n]; ok {
+		return
+	}
+	g.edges[n] = make(map[N]W)
+	g.nodes = append(g.nodes, n)
+}
+
+// buildPath 根据前驱节点回溯路径
+func buildPath[N comparable](prev map[N]N, from, to N) []N {
+	path := []N{to}
+	for cur := to; cur != from; {
+		cur = prev[cur]
+		path = append(path, cur)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+type dijkstraItem[N comparable, W Weight] struct {
+	node N
+	dist W
+}
+
+// dijkstraQueue 按距离排序的最小堆,实现 heap.Interface
+type dijkstraQueue[N comparable, W Weight] []dijkstraItem[N, W]
+
+func (q dijkstraQueue[N, W]) Len() int           { return len(q) }
+func (q dijkstraQueue[N, W]) Less(i, j int) bool { return q[i].dist < q[j].dist }
+func (q dijkstraQueue[N, W]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *dijkstraQueue[N, W]) Push(x any)        { *q = append(*q, x.(dijkstraItem[N, W])) }
+func (q *dijkstraQueue[N, W]) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
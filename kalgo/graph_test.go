@@ -0,0 +1,77 @@
+package kalgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphShortestPath(t *testing.T) {
+	t.Run("有向图", func(t *testing.T) {
+		g := NewGraph[string, int](true)
+		g.AddEdge("a", "b", 1)
+		g.AddEdge("b", "c", 2)
+		g.AddEdge("a", "c", 5)
+		g.AddEdge("c", "d", 1)
+
+		path, dist, ok := g.ShortestPath("a", "d")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, path)
+		assert.Equal(t, 4, dist)
+
+		_, _, ok = g.ShortestPath("d", "a")
+		assert.False(t, ok)
+
+		path, dist, ok = g.ShortestPath("a", "a")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"a"}, path)
+		assert.Equal(t, 0, dist)
+
+		_, _, ok = g.ShortestPath("x", "a")
+		assert.False(t, ok)
+	})
+
+	t.Run("无向图浮点权重", func(t *testing.T) {
+		g := NewGraph[int, float64](false)
+		g.AddEdge(1, 2, 0.5)
+		g.AddEdge(2, 3, 0.5)
+		g.AddEdge(1, 3, 2)
+		path, dist, ok := g.ShortestPath(3, 1)
+		assert.True(t, ok)
+		assert.Equal(t, []int{3, 2, 1}, path)
+		assert.Equal(t, 1.0, dist)
+	})
+
+	t.Run("删除边", func(t *testing.T) {
+		g := NewGraph[int, int](false)
+		g.AddEdge(1, 2, 1)
+		assert.True(t, g.RemoveEdge(2, 1))
+		assert.False(t, g.RemoveEdge(1, 2))
+		_, _, ok := g.ShortestPath(1, 2)
+		assert.False(t, ok)
+		assert.Equal(t, []int{1, 2}, g.Nodes())
+	})
+
+	t.Run("负权重", func(t *testing.T) {
+		g := NewGraph[int, int](true)
+		assert.Panics(t, func() { g.AddEdge(1, 2, -1) })
+	})
+}
+
+func TestGraphBFSPath(t *testing.T) {
+	g := NewGraph[string, int](true)
+	g.AddEdge("a", "b", 10)
+	g.AddEdge("b", "c", 10)
+	g.AddEdge("a", "x", 1)
+	g.AddEdge("x", "y", 1)
+	g.AddEdge("y", "c", 1)
+	g.AddNode("z")
+
+	path, ok := g.BFSPath("a", "c")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b", "c"}, path)
+
+	_, ok = g.BFSPath("a", "z")
+	assert.False(t, ok)
+	assert.Equal(t, map[string]int{"b": 10, "x": 1}, g.Neighbors("a"))
+}
@@ -0,0 +1,208 @@
+package kalgo
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Interval 闭区间[Start, End]
+type Interval[T constraints.Ordered] struct {
+	Start T
+	End   T
+}
+
+// Overlaps 判断两个闭区间是否有交集,端点相接也算作相交
+func (i Interval[T]) Overlaps(o Interval[T]) bool {
+	return i.Start <= o.End && o.Start <= i.End
+}
+
+// Contains 判断点是否在区间内
+func (i Interval[T]) Contains(p T) bool {
+	return i.Start <= p && p <= i.End
+}
+
+// MergeIntervals 合并所有相交的区间
+//
+// 参数说明:
+//   - intervals: 需要合并的区间,不要求有序
+//
+// 返回值说明:
+//   - []Interval[T]: 合并后互不相交的区间,按Start升序排列
+//
+// 注意事项:
+//   - 不会修改原切片
+//   - 区间为闭区间,端点相接的区间(如[1,2]和[2,3])也会被合并
+//
+// 示例:
+//
+//	merged := MergeIntervals([]Interval[int]{{1, 3}, {8, 10}, {2, 6}})
+//	// merged = [{1 6} {8 10}]
+func MergeIntervals[T constraints.Ordered](intervals []Interval[T]) []Interval[T] {
+	sorted := slices.Clone(intervals)
+	slices.SortFunc(sorted, func(a, b Interval[T]) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+	merged := make([]Interval[T], 0, len(sorted))
+	for _, iv := range sorted {
+		if n := len(merged); n > 0 && iv.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, iv.End)
+			continue
+		}
+		merged = append(merged, iv)
+	}
+	return merged
+}
+
+// IntervalEntry 区间树中的一个条目
+type IntervalEntry[T constraints.Ordered, V any] struct {
+	Interval Interval[T]
+	Value    V
+}
+
+// IntervalTree 区间树,支持查询包含某个点或与某个区间相交的所有区间
+// 内部使用以Start为key、记录子树最大End的treap实现,插入和查询的平均复杂度为O(logn + k)
+type IntervalTree[T constraints.Ordered, V any] struct {
+	mu   sync.RWMutex
+	root *intervalNode[T, V]
+	size int
+}
+
+type intervalNode[T constraints.Ordered, V any] struct {
+	entry       IntervalEntry[T, V]
+	priority    int
+	maxEnd      T // 子树中所有区间End的最大值
+	left, right *intervalNode[T, V]
+}
+
+// NewIntervalTree 创建一个新的区间树
+//
+// 注意事项:
+//   - 线程安全
+//
+// 示例:
+//
+//	tree := NewIntervalTree[int, string]()
+//	tree.Insert(Interval[int]{9, 11}, "会议A")
+//	tree.Insert(Interval[int]{10, 12}, "会议B")
+//	conflicts := tree.Overlap(Interval[int]{11, 13})
+//	// conflicts 包含会议A和会议B
+func NewIntervalTree[T constraints.Ordered, V any]() *IntervalTree[T, V] {
+	return &IntervalTree[T, V]{}
+}
+
+// Insert 插入一个区间及其关联的值,允许插入重复区间
+//
+// 注意事项:
+//   - Start大于End时会panic
+func (t *IntervalTree[T, V]) Insert(iv Interval[T], value V) {
+	if iv.Start > iv.End {
+		panic("interval start must not be greater than end")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = t.root.insert(&intervalNode[T, V]{
+		entry:    IntervalEntry[T, V]{Interval: iv, Value: value},
+		priority: rand.Int(),
+		maxEnd:   iv.End,
+	})
+	t.size++
+}
+
+// Stab 查询包含点p的所有区间
+//
+// 返回值说明:
+//   - []IntervalEntry[T, V]: 包含p的区间,按Start升序排列
+//
+// 示例:
+//
+//	tree.Stab(10) // 查询10点时正在进行的会议
+func (t *IntervalTree[T, V]) Stab(p T) []IntervalEntry[T, V] {
+	return t.Overlap(Interval[T]{Start: p, End: p})
+}
+
+// Overlap 查询与区间q相交的所有区间
+//
+// 返回值说明:
+//   - []IntervalEntry[T, V]: 与q相交的区间,按Start升序排列
+func (t *IntervalTree[T, V]) Overlap(q Interval[T]) []IntervalEntry[T, V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	result := make([]IntervalEntry[T, V], 0)
+	t.root.overlap(q, &result)
+	return result
+}
+
+// Len 返回区间数量
+func (t *IntervalTree[T, V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+func (n *intervalNode[T, V]) insert(node *intervalNode[T, V]) *intervalNode[T, V] {
+	if n == nil {
+		return node
+	}
+	if node.entry.Interval.Start < n.entry.Interval.Start {
+		n.left = n.left.insert(node)
+		if n.left.priority > n.priority {
+			n = n.rotateRight()
+		}
+	} else {
+		n.right = n.right.insert(node)
+		if n.right.priority > n.priority {
+			n = n.rotateLeft()
+		}
+	}
+	n.update()
+	return n
+}
+
+func (n *intervalNode[T, V]) overlap(q Interval[T], result *[]IntervalEntry[T, V]) {
+	// 子树中所有区间都在q之前结束,不可能相交
+	if n == nil || n.maxEnd < q.Start {
+		return
+	}
+	n.left.overlap(q, result)
+	// 右子树的Start都不小于当前节点,当前节点在q之后开始时右子树也不可能相交
+	if n.entry.Interval.Start > q.End {
+		return
+	}
+	if n.entry.Interval.Overlaps(q) {
+		*result = append(*result, n.entry)
+	}
+	n.right.overlap(q, result)
+}
+
+func (n *intervalNode[T, V]) rotateRight() *intervalNode[T, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *intervalNode[T, V]) rotateLeft() *intervalNode[T, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+// update 重新计算子树的最大End
+func (n *intervalNode[T, V]) update() {
+	n.maxEnd = n.entry.Interval.End
+	if n.left != nil {
+		n.maxEnd = max(n.maxEnd, n.left.maxEnd)
+	}
+	if n.right != nil {
+		n.maxEnd = max(n.maxEnd, n.right.maxEnd)
+	}
+}
@@ -0,0 +1,76 @@
+package kalgo
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeIntervals(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []Interval[int]
+		expected []Interval[int]
+	}{
+		{"无序且相交", []Interval[int]{{1, 3}, {8, 10}, {2, 6}}, []Interval[int]{{1, 6}, {8, 10}}},
+		{"端点相接", []Interval[int]{{1, 2}, {2, 3}}, []Interval[int]{{1, 3}}},
+		{"包含", []Interval[int]{{1, 10}, {2, 3}}, []Interval[int]{{1, 10}}},
+		{"不相交", []Interval[int]{{5, 6}, {1, 2}}, []Interval[int]{{1, 2}, {5, 6}}},
+		{"空切片", []Interval[int]{}, []Interval[int]{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MergeIntervals(tt.input))
+		})
+	}
+}
+
+func TestIntervalTree(t *testing.T) {
+	t.Run("查询", func(t *testing.T) {
+		tree := NewIntervalTree[int, string]()
+		tree.Insert(Interval[int]{9, 11}, "A")
+		tree.Insert(Interval[int]{10, 12}, "B")
+		tree.Insert(Interval[int]{14, 15}, "C")
+		assert.Equal(t, 3, tree.Len())
+
+		values := func(entries []IntervalEntry[int, string]) []string {
+			var vs []string
+			for _, e := range entries {
+				vs = append(vs, e.Value)
+			}
+			return vs
+		}
+		assert.Equal(t, []string{"A", "B"}, values(tree.Overlap(Interval[int]{11, 13})))
+		assert.Equal(t, []string{"B", "C"}, values(tree.Overlap(Interval[int]{12, 14})))
+		assert.Equal(t, []string{"A"}, values(tree.Stab(9)))
+		assert.Nil(t, values(tree.Stab(13)))
+	})
+
+	t.Run("与暴力查询一致", func(t *testing.T) {
+		tree := NewIntervalTree[int, int]()
+		var all []Interval[int]
+		for i := 0; i < 500; i++ {
+			start := rand.Intn(1000)
+			iv := Interval[int]{start, start + rand.Intn(50)}
+			all = append(all, iv)
+			tree.Insert(iv, i)
+		}
+		for i := 0; i < 100; i++ {
+			start := rand.Intn(1000)
+			q := Interval[int]{start, start + rand.Intn(20)}
+			expected := 0
+			for _, iv := range all {
+				if iv.Overlaps(q) {
+					expected++
+				}
+			}
+			assert.Len(t, tree.Overlap(q), expected)
+		}
+	})
+
+	t.Run("非法区间", func(t *testing.T) {
+		tree := NewIntervalTree[int, int]()
+		assert.Panics(t, func() { tree.Insert(Interval[int]{2, 1}, 0) })
+	})
+}
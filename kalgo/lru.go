@@ -0,0 +1,160 @@
+package kalgo
+
+import (
+	"github.com/mtgnorton/k/kcollection"
+)
+
+// LRU 最近最少使用缓存,容量满时淘汰最久未被访问的元素
+// 基于 kcollection.LRUCache 实现,需要过期时间时直接使用 kcollection.LRUCache
+type LRU[K comparable, V any] struct {
+	cache *kcollection.LRUCache[K, V]
+}
+
+// LRUStats LRU缓存的命中统计
+type LRUStats struct {
+	Hits   uint64 // 命中次数
+	Misses uint64 // 未命中次数
+}
+
+// HitRate 返回命中率,没有访问记录时返回0
+func (s LRUStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type LRUOptions[K comparable, V any] struct {
+	OnEvict func(key K, value V) // 元素因容量不足被淘汰时的回调
+}
+
+type LRUOption[K comparable, V any] func(opts *LRUOptions[K, V])
+
+func NewLRUOptions[K comparable, V any]() *LRUOptions[K, V] {
+	return &LRUOptions[K, V]{}
+}
+
+// WithOnEvict 设置元素被淘汰时的回调函数
+//
+// 参数说明:
+//   - fn: 回调函数,接收被淘汰元素的key和value
+func WithOnEvict[K comparable, V any](fn func(key K, value V)) LRUOption[K, V] {
+	return func(opts *LRUOptions[K, V]) {
+		opts.OnEvict = fn
+	}
+}
+
+// NewLRU 创建一个新的LRU缓存
+//
+// 参数说明:
+//   - capacity: 缓存容量,必须大于0
+//   - opts: 可选配置项,如 WithOnEvict
+//
+// 返回值说明:
+//   - *LRU[K, V]: 新创建的LRU缓存
+//
+// 注意事项:
+//   - capacity小于1时会panic
+//   - 线程安全
+//   - 淘汰回调在锁外执行,可以在回调中访问缓存
+//
+// 示例:
+//
+//	cache := NewLRU[string, int](2, WithOnEvict(func(k string, v int) {
+//	    fmt.Println("evict", k, v)
+//	}))
+//	cache.Put("a", 1)
+//	cache.Put("b", 2)
+//	cache.Get("a")
+//	cache.Put("c", 3) // 输出: evict b 2
+func NewLRU[K comparable, V any](capacity int, opts ...LRUOption[K, V]) *LRU[K, V] {
+	if capacity < 1 {
+		panic("capacity must be greater than 0")
+	}
+	options := NewLRUOptions[K, V]()
+	for _, opt := range opts {
+		opt(options)
+	}
+	cacheOpts := []kcollection.LRUCacheOption[K, V]{kcollection.WithMaxEntries[K, V](capacity)}
+	if options.OnEvict != nil {
+		cacheOpts = append(cacheOpts, kcollection.WithOnEvict(func(key K, value V, reason kcollection.EvictReason) {
+			if reason == kcollection.EvictReasonCapacity {
+				options.OnEvict(key, value)
+			}
+		}))
+	}
+	return &LRU[K, V]{cache: kcollection.NewLRUCache(cacheOpts...)}
+}
+
+// Get 获取key对应的值,并将其标记为最近访问
+//
+// 返回值说明:
+//   - V: key对应的值,不存在时为零值
+//   - bool: key是否存在
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	return c.cache.Get(key)
+}
+
+// Peek 获取key对应的值,不改变访问顺序,也不计入命中统计
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	return c.cache.Peek(key)
+}
+
+// Contains 判断key是否存在,不改变访问顺序
+func (c *LRU[K, V]) Contains(key K) bool {
+	return c.cache.Contains(key)
+}
+
+// Put 写入一个键值对,并将其标记为最近访问
+//
+// 返回值说明:
+//   - evicted: 是否因容量不足淘汰了元素
+//
+// 注意事项:
+//   - key已存在时更新其值,不会触发淘汰
+func (c *LRU[K, V]) Put(key K, value V) (evicted bool) {
+	return c.cache.Set(key, value)
+}
+
+// Remove 删除key,不会触发淘汰回调
+//
+// 返回值说明:
+//   - bool: key是否存在
+func (c *LRU[K, V]) Remove(key K) bool {
+	return c.cache.Delete(key)
+}
+
+// Keys 返回所有key,顺序为从最近访问到最久未访问
+func (c *LRU[K, V]) Keys() []K {
+	return c.cache.Keys()
+}
+
+// Len 返回当前缓存的元素数量
+func (c *LRU[K, V]) Len() int {
+	return c.cache.Len()
+}
+
+// Cap 返回缓存容量
+func (c *LRU[K, V]) Cap() int {
+	return c.cache.MaxEntries()
+}
+
+// Purge 清空缓存,不会触发淘汰回调,也不会重置命中统计
+func (c *LRU[K, V]) Purge() {
+	c.cache.Purge()
+}
+
+// Stats 返回命中统计
+func (c *LRU[K, V]) Stats() LRUStats {
+	stats := c.cache.Stats()
+	return LRUStats{
+		Hits:   stats.Hits,
+		Misses: stats.Misses,
+	}
+}
+
+// ResetStats 重置命中统计
+func (c *LRU[K, V]) ResetStats() {
+	c.cache.ResetStats()
+}
@@ -0,0 +1,107 @@
+package kalgo
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	t.Run("基本读写与淘汰", func(t *testing.T) {
+		var evicted []string
+		cache := NewLRU[string, int](2, WithOnEvict(func(k string, v int) {
+			evicted = append(evicted, k)
+		}))
+		assert.False(t, cache.Put("a", 1))
+		assert.False(t, cache.Put("b", 2))
+		v, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+
+		assert.True(t, cache.Put("c", 3))
+		assert.Equal(t, []string{"b"}, evicted)
+		assert.False(t, cache.Contains("b"))
+		assert.Equal(t, []string{"c", "a"}, cache.Keys())
+		assert.Equal(t, 2, cache.Len())
+		assert.Equal(t, 2, cache.Cap())
+	})
+
+	t.Run("更新已有key", func(t *testing.T) {
+		cache := NewLRU[string, int](2)
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+		assert.False(t, cache.Put("a", 10))
+		cache.Put("c", 3)
+		v, ok := cache.Peek("a")
+		assert.True(t, ok)
+		assert.Equal(t, 10, v)
+		assert.False(t, cache.Contains("b"))
+	})
+
+	t.Run("Peek不改变顺序", func(t *testing.T) {
+		cache := NewLRU[int, int](2)
+		cache.Put(1, 1)
+		cache.Put(2, 2)
+		cache.Peek(1)
+		cache.Put(3, 3)
+		assert.False(t, cache.Contains(1))
+	})
+
+	t.Run("删除和清空", func(t *testing.T) {
+		evictCount := 0
+		cache := NewLRU[int, int](3, WithOnEvict(func(k int, v int) { evictCount++ }))
+		cache.Put(1, 1)
+		cache.Put(2, 2)
+		assert.True(t, cache.Remove(1))
+		assert.False(t, cache.Remove(1))
+		cache.Purge()
+		assert.Equal(t, 0, cache.Len())
+		assert.Equal(t, 0, evictCount)
+	})
+
+	t.Run("命中统计", func(t *testing.T) {
+		cache := NewLRU[int, int](2)
+		cache.Put(1, 1)
+		cache.Get(1)
+		cache.Get(1)
+		cache.Get(2)
+		stats := cache.Stats()
+		assert.Equal(t, uint64(2), stats.Hits)
+		assert.Equal(t, uint64(1), stats.Misses)
+		assert.InDelta(t, 2.0/3, stats.HitRate(), 1e-9)
+		cache.ResetStats()
+		assert.Equal(t, LRUStats{}, cache.Stats())
+		assert.Equal(t, 0.0, cache.Stats().HitRate())
+	})
+
+	t.Run("回调中访问缓存", func(t *testing.T) {
+		var cache *LRU[int, int]
+		cache = NewLRU[int, int](1, WithOnEvict(func(k int, v int) {
+			cache.Len()
+		}))
+		cache.Put(1, 1)
+		cache.Put(2, 2)
+	})
+
+	t.Run("非法容量", func(t *testing.T) {
+		assert.Panics(t, func() { NewLRU[int, int](0) })
+	})
+
+	t.Run("并发", func(t *testing.T) {
+		cache := NewLRU[int, int](100)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					cache.Put(i*1000+j, j)
+					cache.Get(j)
+				}
+			}(i)
+		}
+		wg.Wait()
+		assert.Equal(t, 100, cache.Len())
+	})
+}
@@ -0,0 +1,70 @@
+package kalgo
+
+import (
+	"cmp"
+
+	"golang.org/x/exp/constraints"
+)
+
+// BinarySearch 在有序切片中二分查找目标值
+//
+// 参数说明:
+//   - arr: 已排序的切片
+//   - target: 需要查找的目标值
+//   - sort: 可选的切片排序方式,默认为升序(SortAsc),需与切片实际的排序方式一致
+//
+// 返回值说明:
+//   - int: 找到时为目标值第一次出现的位置,未找到时为目标值应插入的位置
+//   - bool: 是否找到目标值
+//
+// 注意事项:
+//   - 切片未按sort指定的方式排序时结果无意义
+//   - 时间复杂度为O(logn)
+//
+// 示例:
+//
+//	idx, found := BinarySearch([]int{1, 3, 5, 7}, 5)
+//	// idx = 2, found = true
+//
+//	idx, found := BinarySearch([]int{7, 5, 3, 1}, 4, SortDesc)
+//	// idx = 2, found = false
+func BinarySearch[T constraints.Ordered](arr []T, target T, sort ...Sort) (int, bool) {
+	return BinarySearchFunc(arr, target, cmp.Compare[T], sort...)
+}
+
+// BinarySearchFunc 使用自定义比较函数在有序切片中二分查找
+//
+// 参数说明:
+//   - arr: 已按compare排序的切片
+//   - target: 需要查找的目标
+//   - compare: 比较函数,元素小于目标返回负数,等于返回0,大于返回正数
+//   - sort: 可选的切片排序方式,默认为升序(SortAsc),降序时会反转compare的结果
+//
+// 返回值说明:
+//   - int: 找到时为第一个匹配元素的位置,未找到时为目标应插入的位置
+//   - bool: 是否找到目标
+//
+// 示例:
+//
+//	users := []User{{ID: 1}, {ID: 3}, {ID: 5}}
+//	idx, found := BinarySearchFunc(users, 3, func(u User, id int) int {
+//	    return cmp.Compare(u.ID, id)
+//	})
+//	// idx = 1, found = true
+func BinarySearchFunc[T, K any](arr []T, target K, compare func(T, K) int, sort ...Sort) (int, bool) {
+	desc := sortOf(sort...) == SortDesc
+	l, r := 0, len(arr)
+	for l < r {
+		mid := int(uint(l+r) >> 1)
+		c := compare(arr[mid], target)
+		if desc {
+			c = -c
+		}
+		if c < 0 {
+			l = mid + 1
+		} else {
+			r = mid
+		}
+	}
+	return l, l < len(arr) && compare(arr[l], target) == 0
+}
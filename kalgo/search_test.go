@@ -0,0 +1,53 @@
+package kalgo
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinarySearch(t *testing.T) {
+	tests := []struct {
+		name   string
+		arr    []int
+		target int
+		sort   []Sort
+		index  int
+		found  bool
+	}{
+		{"升序找到", []int{1, 3, 5, 7}, 5, nil, 2, true},
+		{"升序未找到", []int{1, 3, 5, 7}, 4, nil, 2, false},
+		{"升序重复元素返回第一个", []int{1, 3, 3, 3, 7}, 3, nil, 1, true},
+		{"升序大于所有元素", []int{1, 3, 5, 7}, 9, nil, 4, false},
+		{"降序找到", []int{7, 5, 3, 1}, 3, []Sort{SortDesc}, 2, true},
+		{"降序未找到", []int{7, 5, 3, 1}, 4, []Sort{SortDesc}, 2, false},
+		{"降序小于所有元素", []int{7, 5, 3, 1}, 0, []Sort{SortDesc}, 4, false},
+		{"空切片", []int{}, 1, nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, found := BinarySearch(tt.arr, tt.target, tt.sort...)
+			assert.Equal(t, tt.index, index)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	users := []user{{"a", 18}, {"b", 20}, {"c", 25}}
+	byAge := func(u user, age int) int { return cmp.Compare(u.Age, age) }
+
+	index, found := BinarySearchFunc(users, 20, byAge)
+	assert.Equal(t, 1, index)
+	assert.True(t, found)
+
+	index, found = BinarySearchFunc(users, 21, byAge)
+	assert.Equal(t, 2, index)
+	assert.False(t, found)
+
+	desc := []user{{"c", 25}, {"b", 20}, {"a", 18}}
+	index, found = BinarySearchFunc(desc, 18, byAge, SortDesc)
+	assert.Equal(t, 2, index)
+	assert.True(t, found)
+}
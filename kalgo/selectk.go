@@ -0,0 +1,103 @@
+package kalgo
+
+import (
+	"math/rand"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SelectK 使用快速选择算法找出排序后位于第k个位置(从0开始)的元素
+//
+// 参数说明:
+//   - arr: 待查找的切片
+//   - k: 目标位置,范围为[0, len(arr))
+//   - sort: 可选的排序方式,默认为升序(SortAsc),即第k小;SortDesc时为第k大
+//
+// 返回值说明:
+//   - T: 排序后位于第k个位置的元素
+//
+// 注意事项:
+//   - 该函数会重排原切片,调用后arr[k]即为结果,arr[:k]中的元素都不排在arr[k]之后
+//   - 平均时间复杂度为O(n),无需完整排序
+//   - k超出范围时会panic
+//
+// 示例:
+//
+//	arr := []int{7, 2, 9, 4, 1}
+//	v := SelectK(arr, 1)           // 第2小: v = 2
+//	v = SelectK(arr, 0, SortDesc)  // 最大值: v = 9
+func SelectK[T constraints.Ordered](arr []T, k int, sort ...Sort) T {
+	if k < 0 || k >= len(arr) {
+		panic("k out of range")
+	}
+	s := sortOf(sort...)
+	l, r := 0, len(arr)-1
+	for l < r {
+		lt, gt := partition3(arr, l, r, s)
+		switch {
+		case k < lt:
+			r = lt - 1
+		case k > gt:
+			l = gt + 1
+		default:
+			return arr[k]
+		}
+	}
+	return arr[k]
+}
+
+// TopK 返回按排序方式排在最前面的k个元素
+//
+// 参数说明:
+//   - arr: 待查找的切片
+//   - k: 需要返回的元素个数
+//   - sort: 可选的排序方式,默认为升序(SortAsc),即最小的k个;SortDesc时为最大的k个
+//
+// 返回值说明:
+//   - []T: 按排序方式排好序的k个元素,是新分配的切片
+//
+// 注意事项:
+//   - 该函数会重排原切片
+//   - k<=0时返回空切片,k大于切片长度时返回全部元素
+//   - 时间复杂度为O(n + klogk)
+//
+// 示例:
+//
+//	arr := []int{7, 2, 9, 4, 1}
+//	top := TopK(arr, 3, SortDesc)
+//	// top = [9 7 4]
+func TopK[T constraints.Ordered](arr []T, k int, sort ...Sort) []T {
+	if k <= 0 {
+		return []T{}
+	}
+	if k < len(arr) {
+		SelectK(arr, k-1, sort...)
+	} else {
+		k = len(arr)
+	}
+	result := slices.Clone(arr[:k])
+	SortSlice(result, sort...)
+	return result
+}
+
+// partition3 三路划分,返回与基准值相等区间的起止位置[lt, gt]
+// 相比 partition,大量重复元素时不会退化
+func partition3[T constraints.Ordered](arr []T, l, r int, sort Sort) (lt, gt int) {
+	pivot := arr[l+rand.Intn(r-l+1)]
+	lt, i, gt := l, l, r
+	for i <= gt {
+		switch {
+		case less(arr[i], pivot, sort):
+			arr[lt], arr[i] = arr[i], arr[lt]
+			lt++
+			i++
+		case less(pivot, arr[i], sort):
+			arr[i], arr[gt] = arr[gt], arr[i]
+			gt--
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
@@ -0,0 +1,47 @@
+package kalgo
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectK(t *testing.T) {
+	t.Run("第k小", func(t *testing.T) {
+		assert.Equal(t, 2, SelectK([]int{7, 2, 9, 4, 1}, 1))
+		assert.Equal(t, 1, SelectK([]int{7, 2, 9, 4, 1}, 0))
+		assert.Equal(t, 9, SelectK([]int{7, 2, 9, 4, 1}, 4))
+	})
+
+	t.Run("第k大", func(t *testing.T) {
+		assert.Equal(t, 9, SelectK([]int{7, 2, 9, 4, 1}, 0, SortDesc))
+		assert.Equal(t, 7, SelectK([]int{7, 2, 9, 4, 1}, 1, SortDesc))
+	})
+
+	t.Run("与排序结果一致", func(t *testing.T) {
+		arr := make([]int, 1000)
+		for i := range arr {
+			arr[i] = rand.Intn(100)
+		}
+		sorted := slices.Clone(arr)
+		slices.Sort(sorted)
+		for _, k := range []int{0, 10, 500, 999} {
+			assert.Equal(t, sorted[k], SelectK(slices.Clone(arr), k))
+		}
+	})
+
+	t.Run("越界", func(t *testing.T) {
+		assert.Panics(t, func() { SelectK([]int{1}, 1) })
+		assert.Panics(t, func() { SelectK([]int{}, 0) })
+	})
+}
+
+func TestTopK(t *testing.T) {
+	assert.Equal(t, []int{9, 7, 4}, TopK([]int{7, 2, 9, 4, 1}, 3, SortDesc))
+	assert.Equal(t, []int{1, 2}, TopK([]int{7, 2, 9, 4, 1}, 2))
+	assert.Equal(t, []int{1, 2, 7}, TopK([]int{7, 2, 1}, 10))
+	assert.Equal(t, []int{}, TopK([]int{7, 2, 1}, 0))
+	assert.Equal(t, []int{5, 5}, TopK([]int{5, 5, 5, 5}, 2))
+}
@@ -0,0 +1,235 @@
+package kalgo
+
+import (
+	"cmp"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SortBy 按提取的key对切片进行排序
+//
+// 参数说明:
+//   - s: 待排序的切片
+//   - key: 从元素中提取排序key的函数
+//   - sort: 可选的排序方式,默认为升序(SortAsc)
+//
+// 注意事项:
+//   - 该函数会直接修改原切片
+//   - 不保证相等元素的相对顺序,需要稳定排序请使用 SortStableBy
+//   - key函数在每次比较时都会被调用,应避免在其中进行耗时操作
+//
+// 示例:
+//
+//	users := []User{{Name: "b", Age: 20}, {Name: "a", Age: 18}}
+//	SortBy(users, func(u User) int { return u.Age }) // 按年龄升序
+//	SortBy(users, func(u User) string { return u.Name }, SortDesc) // 按名称降序
+func SortBy[T any, K constraints.Ordered](s []T, key func(T) K, sort ...Sort) {
+	slices.SortFunc(s, compareBy(key, sort...))
+}
+
+// SortStableBy 按提取的key对切片进行稳定排序,相等元素保持原有的相对顺序
+//
+// 参数说明:
+//   - s: 待排序的切片
+//   - key: 从元素中提取排序key的函数
+//   - sort: 可选的排序方式,默认为升序(SortAsc)
+//
+// 注意事项:
+//   - 该函数会直接修改原切片
+//
+// 示例:
+//
+//	SortStableBy(users, func(u User) int { return u.Age })
+func SortStableBy[T any, K constraints.Ordered](s []T, key func(T) K, sort ...Sort) {
+	slices.SortStableFunc(s, compareBy(key, sort...))
+}
+
+// compareBy 根据key函数和排序方式生成比较函数
+func compareBy[T any, K constraints.Ordered](key func(T) K, sort ...Sort) func(a, b T) int {
+	if len(sort) > 0 && sort[0] == SortDesc {
+		return func(a, b T) int {
+			return cmp.Compare(key(b), key(a))
+		}
+	}
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// insertionSortThreshold 小于等于该长度的区间使用插入排序
+const insertionSortThreshold = 12
+
+// SortSlice 对切片进行排序,根据切片规模自动选择排序算法
+//
+// 参数说明:
+//   - arr: 待排序的切片
+//   - sort: 可选的排序方式,默认为升序(SortAsc)
+//
+// 注意事项:
+//   - 该函数会直接修改原切片
+//   - 长度不超过12时使用插入排序
+//   - 更长的切片使用快速排序,当递归深度超过2*log2(n)时切换为堆排序(即内省排序),
+//     避免快速排序在大量重复元素等特殊输入下退化为O(n^2)
+//   - 不是稳定排序,需要稳定排序请使用 MergeSort
+//
+// 示例:
+//
+//	arr := []int{3, 1, 4, 1, 5}
+//	SortSlice(arr)           // 升序
+//	SortSlice(arr, SortDesc) // 降序
+func SortSlice[T constraints.Ordered](arr []T, sort ...Sort) {
+	s := sortOf(sort...)
+	depth := 0
+	for n := len(arr); n > 0; n >>= 1 {
+		depth++
+	}
+	introSort(arr, 0, len(arr)-1, depth*2, s)
+}
+
+// InsertionSort 插入排序,适合小规模或基本有序的切片
+//
+// 参数说明:
+//   - arr: 待排序的切片
+//   - sort: 可选的排序方式,默认为升序(SortAsc)
+//
+// 注意事项:
+//   - 该函数会直接修改原切片
+//   - 稳定排序,时间复杂度为O(n^2),空间复杂度为O(1)
+func InsertionSort[T constraints.Ordered](arr []T, sort ...Sort) {
+	s := sortOf(sort...)
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0 && less(arr[j], arr[j-1], s); j-- {
+			arr[j], arr[j-1] = arr[j-1], arr[j]
+		}
+	}
+}
+
+// MergeSort 归并排序
+//
+// 参数说明:
+//   - arr: 待排序的切片
+//   - sort: 可选的排序方式,默认为升序(SortAsc)
+//
+// 注意事项:
+//   - 该函数会直接修改原切片
+//   - 稳定排序,时间复杂度为O(nlogn),空间复杂度为O(n)
+//
+// 示例:
+//
+//	arr := []int{3, 1, 4, 1, 5}
+//	MergeSort(arr) // arr = [1 1 3 4 5]
+func MergeSort[T constraints.Ordered](arr []T, sort ...Sort) {
+	if len(arr) <= 1 {
+		return
+	}
+	s := sortOf(sort...)
+	buf := make([]T, len(arr))
+	mergeSort(arr, buf, s)
+}
+
+func mergeSort[T constraints.Ordered](arr, buf []T, sort Sort) {
+	if len(arr) <= 1 {
+		return
+	}
+	mid := len(arr) / 2
+	mergeSort(arr[:mid], buf[:mid], sort)
+	mergeSort(arr[mid:], buf[mid:], sort)
+	copy(buf, arr)
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(arr) {
+		// 右侧严格小于左侧时才取右侧,保证稳定
+		if less(buf[j], buf[i], sort) {
+			arr[k] = buf[j]
+			j++
+		} else {
+			arr[k] = buf[i]
+			i++
+		}
+		k++
+	}
+	k += copy(arr[k:], buf[i:mid])
+	copy(arr[k:], buf[j:])
+}
+
+// HeapSort 堆排序
+//
+// 参数说明:
+//   - arr: 待排序的切片
+//   - sort: 可选的排序方式,默认为升序(SortAsc)
+//
+// 注意事项:
+//   - 该函数会直接修改原切片
+//   - 不是稳定排序,时间复杂度稳定为O(nlogn),空间复杂度为O(1)
+//
+// 示例:
+//
+//	arr := []int{3, 1, 4, 1, 5}
+//	HeapSort(arr, SortDesc) // arr = [5 4 3 1 1]
+func HeapSort[T constraints.Ordered](arr []T, sort ...Sort) {
+	s := sortOf(sort...)
+	n := len(arr)
+	for i := n/2 - 1; i >= 0; i-- {
+		siftDown(arr, i, n, s)
+	}
+	for end := n - 1; end > 0; end-- {
+		arr[0], arr[end] = arr[end], arr[0]
+		siftDown(arr, 0, end, s)
+	}
+}
+
+// siftDown 将arr[root]下沉到合适的位置,堆顶为排序方向上的最后一个元素
+func siftDown[T constraints.Ordered](arr []T, root, n int, sort Sort) {
+	for {
+		child := 2*root + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n && less(arr[child], arr[child+1], sort) {
+			child++
+		}
+		if !less(arr[root], arr[child], sort) {
+			return
+		}
+		arr[root], arr[child] = arr[child], arr[root]
+		root = child
+	}
+}
+
+func introSort[T constraints.Ordered](arr []T, l, r, depth int, sort Sort) {
+	for r-l+1 > insertionSortThreshold {
+		if depth == 0 {
+			HeapSort(arr[l:r+1], sort)
+			return
+		}
+		depth--
+		q := partition(arr, l, r, sort)
+		// 递归较短的一侧,循环处理较长的一侧,控制栈深度
+		if q-l < r-q {
+			introSort(arr, l, q-1, depth, sort)
+			l = q + 1
+		} else {
+			introSort(arr, q+1, r, depth, sort)
+			r = q - 1
+		}
+	}
+	if l < r {
+		InsertionSort(arr[l:r+1], sort)
+	}
+}
+
+// sortOf 获取可选的排序方式,默认为升序
+func sortOf(sort ...Sort) Sort {
+	if len(sort) > 0 {
+		return sort[0]
+	}
+	return SortAsc
+}
+
+// less 判断在排序方式下a是否应排在b之前
+func less[T constraints.Ordered](a, b T, sort Sort) bool {
+	if sort == SortDesc {
+		return a > b
+	}
+	return a < b
+}
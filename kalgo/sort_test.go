@@ -0,0 +1,83 @@
+package kalgo
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func TestSortBy(t *testing.T) {
+	t.Run("按整数key升序", func(t *testing.T) {
+		users := []user{{"c", 30}, {"a", 18}, {"b", 25}}
+		SortBy(users, func(u user) int { return u.Age })
+		assert.Equal(t, []user{{"a", 18}, {"b", 25}, {"c", 30}}, users)
+	})
+
+	t.Run("按字符串key降序", func(t *testing.T) {
+		users := []user{{"a", 18}, {"c", 30}, {"b", 25}}
+		SortBy(users, func(u user) string { return u.Name }, SortDesc)
+		assert.Equal(t, []user{{"c", 30}, {"b", 25}, {"a", 18}}, users)
+	})
+
+	t.Run("空切片", func(t *testing.T) {
+		var users []user
+		SortBy(users, func(u user) int { return u.Age })
+		assert.Empty(t, users)
+	})
+}
+
+func TestSortStableBy(t *testing.T) {
+	users := []user{{"a", 20}, {"b", 18}, {"c", 20}, {"d", 18}}
+	SortStableBy(users, func(u user) int { return u.Age })
+	assert.Equal(t, []user{{"b", 18}, {"d", 18}, {"a", 20}, {"c", 20}}, users)
+
+	users = []user{{"a", 20}, {"b", 18}, {"c", 20}, {"d", 18}}
+	SortStableBy(users, func(u user) int { return u.Age }, SortDesc)
+	assert.Equal(t, []user{{"a", 20}, {"c", 20}, {"b", 18}, {"d", 18}}, users)
+}
+
+func TestSortAlgorithms(t *testing.T) {
+	algorithms := map[string]func(arr []int, sort ...Sort){
+		"SortSlice":     SortSlice[int],
+		"InsertionSort": InsertionSort[int],
+		"MergeSort":     MergeSort[int],
+		"HeapSort":      HeapSort[int],
+	}
+	inputs := map[string][]int{
+		"空切片":  {},
+		"单元素":  {1},
+		"小切片":  {3, 1, 4, 1, 5, 9, 2, 6},
+		"大切片":  rand.Perm(1000),
+		"全部相等": slices.Repeat([]int{7}, 500),
+		"已排序":  ascending(500),
+	}
+	for name, algo := range algorithms {
+		for inputName, input := range inputs {
+			t.Run(name+"-"+inputName, func(t *testing.T) {
+				asc := slices.Clone(input)
+				algo(asc)
+				assert.True(t, slices.IsSorted(asc))
+
+				desc := slices.Clone(input)
+				algo(desc, SortDesc)
+				slices.Reverse(desc)
+				assert.True(t, slices.IsSorted(desc))
+			})
+		}
+	}
+}
+
+func ascending(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
@@ -0,0 +1,231 @@
+package kalgo
+
+import (
+	"slices"
+	"sync"
+	"unicode/utf8"
+)
+
+// Trie 前缀树,key为字符串,按rune划分节点,支持中文等多字节字符
+type Trie[V any] struct {
+	mu   sync.RWMutex
+	root *trieNode[V]
+	size int
+}
+
+type trieNode[V any] struct {
+	children map[rune]*trieNode[V]
+	value    V
+	terminal bool // 是否为某个key的结尾
+}
+
+// NewTrie 创建一个新的前缀树
+//
+// 注意事项:
+//   - 线程安全
+//
+// 示例:
+//
+//	t := NewTrie[int]()
+//	t.Insert("/api/user", 1)
+//	t.Insert("/api", 2)
+//	key, v, ok := t.LongestPrefixMatch("/api/user/list")
+//	// key = "/api/user", v = 1, ok = true
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{root: newTrieNode[V]()}
+}
+
+func newTrieNode[V any]() *trieNode[V] {
+	return &trieNode[V]{children: make(map[rune]*trieNode[V])}
+}
+
+// Insert 插入key及其对应的值,key已存在时覆盖原值
+//
+// 返回值说明:
+//   - bool: 是否为新插入的key
+func (t *Trie[V]) Insert(key string, value V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			child = newTrieNode[V]()
+			node.children[r] = child
+		}
+		node = child
+	}
+	isNew := !node.terminal
+	node.value = value
+	node.terminal = true
+	if isNew {
+		t.size++
+	}
+	return isNew
+}
+
+// Get 精确查找key对应的值
+func (t *Trie[V]) Get(key string) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	node := t.find(key)
+	if node == nil || !node.terminal {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Delete 删除key,并回收不再使用的节点
+//
+// 返回值说明:
+//   - bool: key是否存在
+func (t *Trie[V]) Delete(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	runes := []rune(key)
+	path := make([]*trieNode[V], 0, len(runes)+1)
+	node := t.root
+	path = append(path, node)
+	for _, r := range runes {
+		node = node.children[r]
+		if node == nil {
+			return false
+		}
+		path = append(path, node)
+	}
+	if !node.terminal {
+		return false
+	}
+	var zero V
+	node.terminal = false
+	node.value = zero
+	t.size--
+	// 自底向上删除没有子节点且不是结尾的节点
+	for i := len(runes); i > 0; i-- {
+		n := path[i]
+		if n.terminal || len(n.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, runes[i-1])
+	}
+	return true
+}
+
+// HasPrefix 判断是否存在以prefix为前缀的key
+//
+// 注意事项:
+//   - prefix为空字符串时,只要前缀树不为空就返回true
+func (t *Trie[V]) HasPrefix(prefix string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	node := t.find(prefix)
+	return node != nil && (node.terminal || len(node.children) > 0)
+}
+
+// LongestPrefixMatch 查找是s前缀的最长key,常用于路由匹配
+//
+// 参数说明:
+//   - s: 需要匹配的字符串
+//
+// 返回值说明:
+//   - string: 匹配到的最长key
+//   - V: 该key对应的值
+//   - bool: 是否匹配到
+//
+// 示例:
+//
+//	t.Insert("/api", 1)
+//	key, v, ok := t.LongestPrefixMatch("/api/user")
+//	// key = "/api", v = 1, ok = true
+func (t *Trie[V]) LongestPrefixMatch(s string) (string, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var (
+		matchLen = -1
+		value    V
+		node     = t.root
+	)
+	if node.terminal {
+		matchLen, value = 0, node.value
+	}
+	for i := 0; i < len(s); {
+		// 无效的UTF-8字节解码为utf8.RuneError,宽度为1而不是RuneError编码后的3
+		r, width := utf8.DecodeRuneInString(s[i:])
+		i += width
+		node = node.children[r]
+		if node == nil {
+			break
+		}
+		if node.terminal {
+			matchLen = i
+			value = node.value
+		}
+	}
+	if matchLen < 0 {
+		return "", value, false
+	}
+	return s[:matchLen], value, true
+}
+
+// Walk 按字典序遍历以prefix为前缀的所有key
+//
+// 参数说明:
+//   - prefix: 前缀,为空字符串时遍历全部key
+//   - fn: 遍历回调,返回false时停止遍历
+//
+// 注意事项:
+//   - 遍历期间持有读锁,不能在fn中修改前缀树
+//
+// 示例:
+//
+//	t.Walk("/api", func(key string, v int) bool {
+//	    fmt.Println(key, v)
+//	    return true
+//	})
+func (t *Trie[V]) Walk(prefix string, fn func(key string, value V) bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	node := t.find(prefix)
+	if node == nil {
+		return
+	}
+	walkTrie(node, []rune(prefix), fn)
+}
+
+// Len 返回key的数量
+func (t *Trie[V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// find 查找key对应的节点,不存在时返回nil
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	node := t.root
+	for _, r := range key {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// walkTrie 深度优先遍历节点,返回false表示停止遍历
+func walkTrie[V any](node *trieNode[V], path []rune, fn func(key string, value V) bool) bool {
+	if node.terminal && !fn(string(path), node.value) {
+		return false
+	}
+	keys := make([]rune, 0, len(node.children))
+	for r := range node.children {
+		keys = append(keys, r)
+	}
+	slices.Sort(keys)
+	for _, r := range keys {
+		if !walkTrie(node.children[r], append(path, r), fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package kalgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrie(t *testing.T) {
+	t.Run("插入和查找", func(t *testing.T) {
+		trie := NewTrie[int]()
+		assert.True(t, trie.Insert("apple", 1))
+		assert.True(t, trie.Insert("app", 2))
+		assert.False(t, trie.Insert("app", 3))
+		assert.Equal(t, 2, trie.Len())
+
+		v, ok := trie.Get("app")
+		assert.True(t, ok)
+		assert.Equal(t, 3, v)
+		_, ok = trie.Get("ap")
+		assert.False(t, ok)
+	})
+
+	t.Run("前缀判断", func(t *testing.T) {
+		trie := NewTrie[struct{}]()
+		assert.False(t, trie.HasPrefix(""))
+		trie.Insert("敏感词", struct{}{})
+		assert.True(t, trie.HasPrefix("敏感"))
+		assert.True(t, trie.HasPrefix("敏感词"))
+		assert.False(t, trie.HasPrefix("敏感词汇"))
+		assert.True(t, trie.HasPrefix(""))
+	})
+
+	t.Run("最长前缀匹配", func(t *testing.T) {
+		trie := NewTrie[string]()
+		trie.Insert("/api", "api")
+		trie.Insert("/api/user", "user")
+		key, v, ok := trie.LongestPrefixMatch("/api/user/list")
+		assert.True(t, ok)
+		assert.Equal(t, "/api/user", key)
+		assert.Equal(t, "user", v)
+
+		key, _, ok = trie.LongestPrefixMatch("/api/order")
+		assert.True(t, ok)
+		assert.Equal(t, "/api", key)
+
+		_, _, ok = trie.LongestPrefixMatch("/static")
+		assert.False(t, ok)
+
+		trie.Insert("中文", "cn")
+		key, _, ok = trie.LongestPrefixMatch("中文路径")
+		assert.True(t, ok)
+		assert.Equal(t, "中文", key)
+	})
+
+	t.Run("无效的UTF-8", func(t *testing.T) {
+		trie := NewTrie[int]()
+		trie.Insert("\xff", 1)
+		key, v, ok := trie.LongestPrefixMatch("\xff")
+		assert.True(t, ok)
+		assert.Equal(t, "\xff", key)
+		assert.Equal(t, 1, v)
+
+		trie.Insert("a\xffb", 2)
+		key, v, ok = trie.LongestPrefixMatch("a\xffbc")
+		assert.True(t, ok)
+		assert.Equal(t, "a\xffb", key)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("遍历", func(t *testing.T) {
+		trie := NewTrie[int]()
+		for i, k := range []string{"b", "abc", "ab", "a", "ac"} {
+			trie.Insert(k, i)
+		}
+		var keys []string
+		trie.Walk("", func(key string, v int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		assert.Equal(t, []string{"a", "ab", "abc", "ac", "b"}, keys)
+
+		keys = nil
+		trie.Walk("ab", func(key string, v int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		assert.Equal(t, []string{"ab", "abc"}, keys)
+
+		keys = nil
+		trie.Walk("a", func(key string, v int) bool {
+			keys = append(keys, key)
+			return len(keys) < 2
+		})
+		assert.Equal(t, []string{"a", "ab"}, keys)
+	})
+
+	t.Run("删除", func(t *testing.T) {
+		trie := NewTrie[int]()
+		trie.Insert("abc", 1)
+		trie.Insert("ab", 2)
+		assert.True(t, trie.Delete("abc"))
+		assert.False(t, trie.Delete("abc"))
+		assert.False(t, trie.Delete("a"))
+		assert.False(t, trie.HasPrefix("abc"))
+		assert.True(t, trie.HasPrefix("ab"))
+		assert.True(t, trie.Delete("ab"))
+		assert.False(t, trie.HasPrefix("a"))
+		assert.Equal(t, 0, trie.Len())
+	})
+}
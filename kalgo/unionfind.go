@@ -0,0 +1,162 @@
+package kalgo
+
+import "sync"
+
+// UnionFind 并查集(不相交集合),用于合并元素并查询连通分量
+type UnionFind[T comparable] struct {
+	mu     sync.Mutex
+	parent map[T]T
+	size   map[T]int // 以该元素为根的集合大小,仅根节点有效
+	order  []T       // 元素的加入顺序,用于稳定输出分组
+	count  int       // 集合(连通分量)数量
+}
+
+// NewUnionFind 创建一个新的并查集
+//
+// 参数说明:
+//   - elems: 可选的初始元素,每个元素单独成为一个集合
+//
+// 注意事项:
+//   - 查找时使用路径压缩,合并时按集合大小合并,均摊复杂度接近O(1)
+//   - 未加入过的元素在 Union/Find 时会自动加入
+//   - 线程安全
+//
+// 示例:
+//
+//	uf := NewUnionFind[string]()
+//	uf.Union("a", "b")
+//	uf.Union("c", "d")
+//	uf.Connected("a", "b") // true
+//	uf.Groups()            // [[a b] [c d]]
+func NewUnionFind[T comparable](elems ...T) *UnionFind[T] {
+	uf := &UnionFind[T]{
+		parent: make(map[T]T, len(elems)),
+		size:   make(map[T]int, len(elems)),
+	}
+	for _, e := range elems {
+		uf.add(e)
+	}
+	return uf
+}
+
+// Add 加入一个元素,已存在时不做任何操作
+//
+// 返回值说明:
+//   - bool: 是否为新加入的元素
+func (uf *UnionFind[T]) Add(x T) bool {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+	return uf.add(x)
+}
+
+// Find 查找元素所在集合的根元素
+func (uf *UnionFind[T]) Find(x T) T {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+	uf.add(x)
+	return uf.find(x)
+}
+
+// Union 合并两个元素所在的集合
+//
+// 返回值说明:
+//   - bool: 两个元素原本不在同一集合时返回true
+func (uf *UnionFind[T]) Union(a, b T) bool {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+	uf.add(a)
+	uf.add(b)
+	ra, rb := uf.find(a), uf.find(b)
+	if ra == rb {
+		return false
+	}
+	if uf.size[ra] < uf.size[rb] {
+		ra, rb = rb, ra
+	}
+	uf.parent[rb] = ra
+	uf.size[ra] += uf.size[rb]
+	delete(uf.size, rb)
+	uf.count--
+	return true
+}
+
+// Connected 判断两个元素是否在同一集合中,未加入过的元素只与自身连通
+func (uf *UnionFind[T]) Connected(a, b T) bool {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+	if a == b {
+		return true
+	}
+	if _, ok := uf.parent[a]; !ok {
+		return false
+	}
+	if _, ok := uf.parent[b]; !ok {
+		return false
+	}
+	return uf.find(a) == uf.find(b)
+}
+
+// Size 返回元素所在集合的大小,未加入过的元素返回0
+func (uf *UnionFind[T]) Size(x T) int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+	if _, ok := uf.parent[x]; !ok {
+		return 0
+	}
+	return uf.size[uf.find(x)]
+}
+
+// Count 返回集合(连通分量)的数量
+func (uf *UnionFind[T]) Count() int {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+	return uf.count
+}
+
+// Groups 返回所有集合
+//
+// 返回值说明:
+//   - [][]T: 每个元素为一个集合,集合按其第一个元素的加入顺序排列,集合内元素按加入顺序排列
+func (uf *UnionFind[T]) Groups() [][]T {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+	index := make(map[T]int, uf.count)
+	groups := make([][]T, 0, uf.count)
+	for _, e := range uf.order {
+		root := uf.find(e)
+		i, ok := index[root]
+		if !ok {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, make([]T, 0, uf.size[root]))
+		}
+		groups[i] = append(groups[i], e)
+	}
+	return groups
+}
+
+// add 加入一个元素,调用方需持有锁
+func (uf *UnionFind[T]) add(x T) bool {
+	if _, ok := uf.parent[x]; ok {
+		return false
+	}
+	uf.parent[x] = x
+	uf.size[x] = 1
+	uf.order = append(uf.order, x)
+	uf.count++
+	return true
+}
+
+// find 查找根元素并进行路径压缩,调用方需持有锁且保证x已加入
+func (uf *UnionFind[T]) find(x T) T {
+	root := x
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	for x != root {
+		next := uf.parent[x]
+		uf.parent[x] = root
+		x = next
+	}
+	return root
+}
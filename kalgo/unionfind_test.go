@@ -0,0 +1,46 @@
+package kalgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnionFind(t *testing.T) {
+	t.Run("合并与查询", func(t *testing.T) {
+		uf := NewUnionFind(1, 2, 3, 4, 5)
+		assert.Equal(t, 5, uf.Count())
+		assert.True(t, uf.Union(1, 2))
+		assert.True(t, uf.Union(3, 4))
+		assert.True(t, uf.Union(2, 4))
+		assert.False(t, uf.Union(1, 3))
+		assert.Equal(t, 2, uf.Count())
+
+		assert.True(t, uf.Connected(1, 4))
+		assert.False(t, uf.Connected(1, 5))
+		assert.Equal(t, uf.Find(1), uf.Find(3))
+		assert.Equal(t, 4, uf.Size(2))
+		assert.Equal(t, 1, uf.Size(5))
+	})
+
+	t.Run("自动加入元素", func(t *testing.T) {
+		uf := NewUnionFind[string]()
+		assert.False(t, uf.Connected("a", "b"))
+		assert.True(t, uf.Connected("a", "a"))
+		assert.Equal(t, 0, uf.Size("a"))
+		uf.Union("a", "b")
+		assert.Equal(t, "c", uf.Find("c"))
+		assert.Equal(t, 2, uf.Count())
+		assert.True(t, uf.Add("d"))
+		assert.False(t, uf.Add("d"))
+	})
+
+	t.Run("分组", func(t *testing.T) {
+		uf := NewUnionFind[string]()
+		uf.Union("a", "c")
+		uf.Union("b", "d")
+		uf.Union("e", "a")
+		uf.Add("f")
+		assert.Equal(t, [][]string{{"a", "c", "e"}, {"b", "d"}, {"f"}}, uf.Groups())
+	})
+}
@@ -0,0 +1,175 @@
+package kbase
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrorFormatFunc 将多个错误格式化为字符串,errs为保存的错误,total为错误的总数,可能大于len(errs)
+type ErrorFormatFunc func(errs []error, total int) string
+
+// CompactErrorFormat 只输出第一个错误,如 "3 errors: timeout; and 2 more"
+func CompactErrorFormat(errs []error, total int) string {
+	if total == 1 {
+		return "1 error: " + errs[0].Error()
+	}
+	return fmt.Sprintf("%d errors: %s; and %d more", total, errs[0], total-1)
+}
+
+// ListErrorFormat 每行输出一个保存的错误,未保存的错误只输出数量
+//
+// 示例输出:
+//
+//	3 errors:
+//	  1. timeout
+//	  2. connection refused
+//	  ... and 1 more
+func ListErrorFormat(errs []error, total int) string {
+	var sb strings.Builder
+	if total == 1 {
+		sb.WriteString("1 error:")
+	} else {
+		fmt.Fprintf(&sb, "%d errors:", total)
+	}
+	for i, err := range errs {
+		fmt.Fprintf(&sb, "\n  %d. %s", i+1, err)
+	}
+	if total > len(errs) {
+		fmt.Fprintf(&sb, "\n  ... and %d more", total-len(errs))
+	}
+	return sb.String()
+}
+
+// ErrorListOptions 错误列表的配置项
+type ErrorListOptions struct {
+	MaxStored int             // 最多保存的错误数量,超过后只计数,小于等于0时不限制
+	Format    ErrorFormatFunc // Err 返回的错误的格式
+}
+
+type ErrorListOption func(*ErrorListOptions)
+
+func NewErrorListOptions() *ErrorListOptions {
+	return &ErrorListOptions{
+		MaxStored: 100,
+		Format:    CompactErrorFormat,
+	}
+}
+
+// WithMaxStored 设置最多保存的错误数量,默认为100,小于等于0时不限制
+func WithMaxStored(n int) ErrorListOption {
+	return func(o *ErrorListOptions) {
+		o.MaxStored = n
+	}
+}
+
+// WithErrorFormat 设置 Err 返回的错误的格式,默认为 CompactErrorFormat
+func WithErrorFormat(format ErrorFormatFunc) ErrorListOption {
+	return func(o *ErrorListOptions) {
+		o.Format = format
+	}
+}
+
+// ErrorList 并发安全地收集多个错误,相比 errors.Join 可以限制保存的数量并控制输出的格式
+//
+// 示例:
+//
+//	errs := NewErrorList(WithMaxStored(10))
+//	for result := range resultCh {
+//	    errs.Add(result.Error)
+//	}
+//	if err := errs.Err(); err != nil {
+//	    log.Println(err) // "1000 errors: id 3: timeout; and 999 more"
+//	}
+type ErrorList struct {
+	mu    sync.Mutex
+	opts  *ErrorListOptions
+	errs  []error
+	total int
+}
+
+// NewErrorList 创建错误列表
+//
+// 参数说明:
+//   - opts: 可选配置项,默认最多保存100个错误,使用 CompactErrorFormat 格式
+func NewErrorList(opts ...ErrorListOption) *ErrorList {
+	o := NewErrorListOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &ErrorList{opts: o}
+}
+
+// Add 添加错误,err为nil时忽略,超过 MaxStored 后只增加计数
+func (l *ErrorList) Add(err error) {
+	if err == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.total++
+	if l.opts.MaxStored <= 0 || len(l.errs) < l.opts.MaxStored {
+		l.errs = append(l.errs, err)
+	}
+}
+
+// Len 返回添加的错误总数,包括未保存的错误
+func (l *ErrorList) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.total
+}
+
+// Errors 按添加的顺序返回保存的错误
+func (l *ErrorList) Errors() []error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]error(nil), l.errs...)
+}
+
+// Err 返回当前所有错误合并后的错误
+//
+// 返回值说明:
+//   - error: 没有错误时返回nil,否则返回 *Multierror,之后添加的错误不影响返回的错误
+func (l *ErrorList) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.total == 0 {
+		return nil
+	}
+	return &Multierror{
+		errs:   append([]error(nil), l.errs...),
+		total:  l.total,
+		format: l.opts.Format,
+	}
+}
+
+// Multierror 多个错误合并后的错误,由 ErrorList.Err 返回
+//
+// 注意事项:
+//   - 实现了 Unwrap() []error,errors.Is 和 errors.As 会检查保存的所有错误
+type Multierror struct {
+	errs   []error
+	total  int
+	format ErrorFormatFunc
+}
+
+// Error 使用 ErrorList 配置的格式输出错误
+func (m *Multierror) Error() string {
+	return m.format(m.errs, m.total)
+}
+
+// Unwrap 返回保存的所有错误
+func (m *Multierror) Unwrap() []error {
+	return m.errs
+}
+
+// Errors 返回保存的所有错误
+func (m *Multierror) Errors() []error {
+	return append([]error(nil), m.errs...)
+}
+
+// Total 返回错误的总数,包括未保存的错误
+func (m *Multierror) Total() int {
+	return m.total
+}
@@ -0,0 +1,86 @@
+package kbase
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestErrorList(t *testing.T) {
+	t.Run("没有错误", func(t *testing.T) {
+		errs := NewErrorList()
+		errs.Add(nil)
+		assert.NoError(t, errs.Err())
+		assert.Equal(t, 0, errs.Len())
+	})
+
+	t.Run("紧凑格式", func(t *testing.T) {
+		errs := NewErrorList()
+		errs.Add(errors.New("timeout"))
+		assert.EqualError(t, errs.Err(), "1 error: timeout")
+		errs.Add(errors.New("refused"))
+		errs.Add(errors.New("reset"))
+		assert.EqualError(t, errs.Err(), "3 errors: timeout; and 2 more")
+	})
+
+	t.Run("限制保存的数量", func(t *testing.T) {
+		errs := NewErrorList(WithMaxStored(2), WithErrorFormat(ListErrorFormat))
+		for i := 0; i < 5; i++ {
+			errs.Add(fmt.Errorf("error %d", i))
+		}
+		assert.Equal(t, 5, errs.Len())
+		assert.Len(t, errs.Errors(), 2)
+		assert.EqualError(t, errs.Err(), "5 errors:\n  1. error 0\n  2. error 1\n  ... and 3 more")
+
+		var m *Multierror
+		assert.ErrorAs(t, errs.Err(), &m)
+		assert.Equal(t, 5, m.Total())
+		assert.Len(t, m.Errors(), 2)
+	})
+
+	t.Run("不限制保存的数量", func(t *testing.T) {
+		errs := NewErrorList(WithMaxStored(0))
+		for i := 0; i < 200; i++ {
+			errs.Add(fmt.Errorf("error %d", i))
+		}
+		assert.Len(t, errs.Errors(), 200)
+	})
+
+	t.Run("Is和As", func(t *testing.T) {
+		errNotFound := NewError(codes.NotFound, "not found")
+		errs := NewErrorList()
+		errs.Add(errors.New("timeout"))
+		errs.Add(fmt.Errorf("id 3: %w", errNotFound))
+		err := errs.Err()
+		assert.ErrorIs(t, err, errNotFound)
+		var kerr *KError
+		assert.ErrorAs(t, err, &kerr)
+	})
+
+	t.Run("返回的错误不受之后添加的影响", func(t *testing.T) {
+		errs := NewErrorList()
+		errs.Add(errors.New("first"))
+		err := errs.Err()
+		errs.Add(errors.New("second"))
+		assert.EqualError(t, err, "1 error: first")
+	})
+
+	t.Run("并发添加", func(t *testing.T) {
+		errs := NewErrorList(WithMaxStored(10))
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs.Add(errors.New("error"))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 100, errs.Len())
+		assert.Len(t, errs.Errors(), 10)
+	})
+}
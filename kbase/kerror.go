@@ -0,0 +1,209 @@
+package kbase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// KError 带错误码和元数据的错误,用于在重试、熔断和日志之间传递统一的错误信息
+//
+// 注意事项:
+//   - 错误码使用grpc的错误码,可以直接转换为grpc status和http状态码
+//   - 实现了 GRPCStatus,status.FromError 和 IsDeadlineError 可以直接识别
+//   - 实现了 slog.LogValuer,使用slog记录时输出错误码、消息、元数据和原因
+//   - 创建后不可修改,With 返回新的错误,可以安全地作为包级别的错误变量
+type KError struct {
+	code    codes.Code
+	message string
+	cause   error
+	meta    map[string]any
+}
+
+// NewError 创建错误
+//
+// 参数说明:
+//   - code: 错误码
+//   - message: 错误消息
+//
+// 示例:
+//
+//	var ErrUserNotFound = NewError(codes.NotFound, "user not found")
+func NewError(code codes.Code, message string) *KError {
+	return &KError{code: code, message: message}
+}
+
+// WrapError 使用错误码和消息包装err
+//
+// 参数说明:
+//   - err: 原始错误,可以通过 errors.Unwrap 获取
+//   - code: 错误码
+//   - message: 错误消息
+//
+// 返回值说明:
+//   - *KError: 包装后的错误,err为nil时等同于 NewError
+//
+// 注意事项:
+//   - err为nil时不返回nil,避免nil的*KError赋值给error后不等于nil
+//
+// 示例:
+//
+//	if err := db.QueryRow(query).Scan(&user); err != nil {
+//	    return WrapError(err, codes.NotFound, "user not found").With("user_id", id)
+//	}
+func WrapError(err error, code codes.Code, message string) *KError {
+	return &KError{code: code, message: message, cause: err}
+}
+
+// Code 返回错误码
+func (e *KError) Code() codes.Code {
+	return e.code
+}
+
+// Message 返回错误消息
+func (e *KError) Message() string {
+	return e.message
+}
+
+// Metadata 返回元数据的副本
+func (e *KError) Metadata() map[string]any {
+	return maps.Clone(e.meta)
+}
+
+// With 返回添加了元数据的新错误,key已存在时覆盖,原错误不受影响
+func (e *KError) With(key string, value any) *KError {
+	meta := make(map[string]any, len(e.meta)+1)
+	maps.Copy(meta, e.meta)
+	meta[key] = value
+	return &KError{code: e.code, message: e.message, cause: e.cause, meta: meta}
+}
+
+// Error 返回错误信息,格式为 "错误码: 消息: 原因"
+func (e *KError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.code.String())
+	if e.message != "" {
+		sb.WriteString(": ")
+		sb.WriteString(e.message)
+	}
+	if e.cause != nil {
+		sb.WriteString(": ")
+		sb.WriteString(e.cause.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap 返回被包装的原始错误
+func (e *KError) Unwrap() error {
+	return e.cause
+}
+
+// Is 错误码和消息都相同时认为是同一个错误,target的消息为空时只比较错误码
+//
+// 示例:
+//
+//	errors.Is(err, ErrUserNotFound)              // 错误码和消息都相同
+//	errors.Is(err, NewError(codes.NotFound, "")) // 任意NotFound错误
+func (e *KError) Is(target error) bool {
+	t, ok := target.(*KError)
+	if !ok {
+		return false
+	}
+	return e.code == t.code && (t.message == "" || e.message == t.message)
+}
+
+// GRPCStatus 转换为grpc status,使用 Error 作为status的消息
+func (e *KError) GRPCStatus() *status.Status {
+	return status.New(e.code, e.Error())
+}
+
+// HTTPStatus 返回错误码对应的http状态码,参见 HTTPStatusFromCode
+func (e *KError) HTTPStatus() int {
+	return HTTPStatusFromCode(e.code)
+}
+
+// LogValue 实现 slog.LogValuer
+func (e *KError) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("code", e.code.String()),
+		slog.String("message", e.message),
+	}
+	if e.cause != nil {
+		attrs = append(attrs, slog.String("cause", e.cause.Error()))
+	}
+	keys := make([]string, 0, len(e.meta))
+	for k := range e.meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attrs = append(attrs, slog.Any(k, e.meta[k]))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// CodeOf 返回错误的错误码
+//
+// 参数说明:
+//   - err: 任意错误
+//
+// 返回值说明:
+//   - codes.Code: 依次判断:nil为OK,错误链中的 KError 的错误码,context的取消和超时错误,grpc status的错误码,其他为Unknown
+func CodeOf(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	var kerr *KError
+	if errors.As(err, &kerr) {
+		return kerr.code
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	}
+	return status.Code(err)
+}
+
+// HTTPStatusOf 返回错误对应的http状态码,错误码参见 CodeOf
+func HTTPStatusOf(err error) int {
+	return HTTPStatusFromCode(CodeOf(err))
+}
+
+// HTTPStatusFromCode 返回grpc错误码对应的http状态码,与grpc-gateway的对应关系一致
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
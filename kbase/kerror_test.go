@@ -0,0 +1,102 @@
+package kbase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestKError(t *testing.T) {
+	errUserNotFound := NewError(codes.NotFound, "user not found")
+	cause := errors.New("sql: no rows")
+
+	t.Run("错误信息", func(t *testing.T) {
+		assert.Equal(t, "NotFound: user not found", errUserNotFound.Error())
+		err := WrapError(cause, codes.NotFound, "user not found")
+		assert.Equal(t, "NotFound: user not found: sql: no rows", err.Error())
+		assert.Equal(t, "Internal", NewError(codes.Internal, "").Error())
+		assert.Equal(t, NewError(codes.Internal, "nil"), WrapError(nil, codes.Internal, "nil"))
+	})
+
+	t.Run("Is和As", func(t *testing.T) {
+		err := fmt.Errorf("get user: %w", WrapError(cause, codes.NotFound, "user not found").With("user_id", 1))
+		assert.ErrorIs(t, err, errUserNotFound)
+		assert.ErrorIs(t, err, cause)
+		assert.ErrorIs(t, err, NewError(codes.NotFound, ""), "空消息只比较错误码")
+		assert.NotErrorIs(t, err, NewError(codes.NotFound, "order not found"))
+		assert.NotErrorIs(t, err, NewError(codes.Internal, ""))
+
+		var kerr *KError
+		assert.ErrorAs(t, err, &kerr)
+		assert.Equal(t, codes.NotFound, kerr.Code())
+		assert.Equal(t, "user not found", kerr.Message())
+		assert.Equal(t, map[string]any{"user_id": 1}, kerr.Metadata())
+	})
+
+	t.Run("With不修改原错误", func(t *testing.T) {
+		err1 := errUserNotFound.With("user_id", 1)
+		err2 := err1.With("user_id", 2).With("tenant", "a")
+		assert.Nil(t, errUserNotFound.Metadata())
+		assert.Equal(t, map[string]any{"user_id": 1}, err1.Metadata())
+		assert.Equal(t, map[string]any{"user_id": 2, "tenant": "a"}, err2.Metadata())
+		assert.ErrorIs(t, err2, errUserNotFound)
+	})
+
+	t.Run("grpc status", func(t *testing.T) {
+		err := fmt.Errorf("call: %w", NewError(codes.DeadlineExceeded, "query timeout"))
+		s, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.DeadlineExceeded, s.Code())
+		assert.True(t, IsDeadlineError(err))
+	})
+
+	t.Run("http状态码", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, errUserNotFound.HTTPStatus())
+		assert.Equal(t, http.StatusTooManyRequests, HTTPStatusFromCode(codes.ResourceExhausted))
+		assert.Equal(t, http.StatusInternalServerError, HTTPStatusFromCode(codes.DataLoss))
+	})
+
+	t.Run("日志", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+		logger.Error("failed", "err", WrapError(cause, codes.NotFound, "user not found").With("user_id", 1))
+		assert.Equal(t, `level=ERROR msg=failed err.code=NotFound err.message="user not found" err.cause="sql: no rows" err.user_id=1`+"\n", buf.String())
+	})
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+		http int
+	}{
+		{"nil", nil, codes.OK, http.StatusOK},
+		{"KError", fmt.Errorf("wrap: %w", NewError(codes.PermissionDenied, "denied")), codes.PermissionDenied, http.StatusForbidden},
+		{"超时", fmt.Errorf("wrap: %w", context.DeadlineExceeded), codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"取消", context.Canceled, codes.Canceled, 499},
+		{"grpc status", status.Error(codes.Unavailable, "unavailable"), codes.Unavailable, http.StatusServiceUnavailable},
+		{"其他错误", errors.New("error"), codes.Unknown, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CodeOf(tt.err))
+			assert.Equal(t, tt.http, HTTPStatusOf(tt.err))
+		})
+	}
+}
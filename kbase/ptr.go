@@ -0,0 +1,40 @@
+package kbase
+
+// Ptr 返回指向v的指针,用于给可选的指针字段赋值
+//
+// 示例:
+//
+//	req := UpdateUserRequest{
+//	    Name: Ptr("tom"),
+//	    Age:  Ptr(18),
+//	}
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Deref 返回p指向的值,p为nil时返回def
+//
+// 示例:
+//
+//	limit := Deref(req.Limit, 20)
+func Deref[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// Coalesce 返回第一个不是零值的参数,都是零值时返回零值
+//
+// 示例:
+//
+//	name := Coalesce(req.Nickname, req.Username, "anonymous")
+func Coalesce[T comparable](values ...T) T {
+	var zero T
+	for _, v := range values {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
@@ -0,0 +1,31 @@
+package kbase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPtr(t *testing.T) {
+	p := Ptr(18)
+	assert.Equal(t, 18, *p)
+	assert.NotSame(t, p, Ptr(18), "每次返回新的指针")
+	assert.Equal(t, "tom", *Ptr("tom"))
+}
+
+func TestDeref(t *testing.T) {
+	assert.Equal(t, 18, Deref(Ptr(18), 20))
+	assert.Equal(t, 20, Deref(nil, 20))
+	assert.Equal(t, 0, Deref(Ptr(0), 20), "零值不使用默认值")
+}
+
+func TestCoalesce(t *testing.T) {
+	assert.Equal(t, "tom", Coalesce("", "tom", "jerry"))
+	assert.Equal(t, "", Coalesce("", ""))
+	assert.Equal(t, "", Coalesce[string]())
+	assert.Equal(t, 3, Coalesce(0, 3))
+
+	var nilPtr *int
+	p := Ptr(1)
+	assert.Same(t, p, Coalesce(nilPtr, p))
+}
@@ -0,0 +1,110 @@
+package kbase
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+var (
+	ErrPanic = errors.New("panic")
+)
+
+// PanicError 由panic转换的错误
+//
+// 注意事项:
+//   - errors.Is(err, ErrPanic) 判断错误是否由panic转换
+//   - panic的值为error时可以通过 errors.Is、errors.As 检查
+type PanicError struct {
+	Value any    // panic的值
+	Stack []byte // 发生panic的协程的堆栈
+}
+
+// NewPanicError 使用panic的值和当前协程的堆栈创建错误,需要在recover的协程中调用
+func NewPanicError(value any) *PanicError {
+	return &PanicError{Value: value, Stack: debug.Stack()}
+}
+
+// Error 返回 "panic: " 加上panic的值,不包括堆栈
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Is 使 errors.Is(err, ErrPanic) 返回true
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// Unwrap panic的值为error时返回该错误
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// panicHook 发生panic时调用的钩子
+var panicHook atomic.Pointer[func(*PanicError)]
+
+// SetPanicHook 设置 Recover 和 SafeGo 捕获到panic时调用的钩子,用于统一记录日志或上报,传入nil时取消
+//
+// 示例:
+//
+//	SetPanicHook(func(err *PanicError) {
+//	    log.Printf("%v\n%s", err, err.Stack)
+//	})
+func SetPanicHook(hook func(*PanicError)) {
+	if hook == nil {
+		panicHook.Store(nil)
+		return
+	}
+	panicHook.Store(&hook)
+}
+
+// reportPanic 创建错误并调用钩子
+func reportPanic(value any) *PanicError {
+	err := NewPanicError(value)
+	if hook := panicHook.Load(); hook != nil {
+		(*hook)(err)
+	}
+	return err
+}
+
+// Recover 捕获panic并转换为错误
+//
+// 参数说明:
+//   - errp: 接收错误的指针,通常为函数的命名返回值,发生panic时覆盖为 *PanicError,为nil时只调用钩子
+//
+// 注意事项:
+//   - 必须直接使用defer调用,即 defer Recover(&err),在其他函数中调用无法捕获panic
+//   - 捕获到panic时调用 SetPanicHook 设置的钩子
+//
+// 示例:
+//
+//	func process(item Item) (err error) {
+//	    defer Recover(&err)
+//	    return handle(item)
+//	}
+func Recover(errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	err := reportPanic(r)
+	if errp != nil {
+		*errp = err
+	}
+}
+
+// SafeGo 在新的协程中执行fn,fn发生panic时不会使程序崩溃,而是调用 SetPanicHook 设置的钩子
+//
+// 示例:
+//
+//	SafeGo(func() {
+//	    consume(msgCh)
+//	})
+func SafeGo(fn func()) {
+	go func() {
+		defer Recover(nil)
+		fn()
+	}()
+}
@@ -0,0 +1,55 @@
+package kbase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecover(t *testing.T) {
+	errBoom := errors.New("boom")
+	hooked := make(chan *PanicError, 1)
+	SetPanicHook(func(err *PanicError) { hooked <- err })
+	defer SetPanicHook(nil)
+
+	t.Run("panic转换为错误", func(t *testing.T) {
+		err := func() (err error) {
+			defer Recover(&err)
+			panic("something wrong")
+		}()
+		assert.EqualError(t, err, "panic: something wrong")
+		assert.ErrorIs(t, err, ErrPanic)
+		var perr *PanicError
+		assert.ErrorAs(t, err, &perr)
+		assert.Contains(t, string(perr.Stack), "TestRecover")
+		assert.Same(t, perr, <-hooked)
+	})
+
+	t.Run("panic的值为error", func(t *testing.T) {
+		err := func() (err error) {
+			defer Recover(&err)
+			panic(errBoom)
+		}()
+		<-hooked
+		assert.ErrorIs(t, err, errBoom)
+		assert.ErrorIs(t, err, ErrPanic)
+	})
+
+	t.Run("没有panic", func(t *testing.T) {
+		err := func() (err error) {
+			defer Recover(&err)
+			return errBoom
+		}()
+		assert.Equal(t, errBoom, err)
+		assert.Empty(t, hooked)
+	})
+
+	t.Run("SafeGo", func(t *testing.T) {
+		SafeGo(func() {
+			panic(errBoom)
+		})
+		err := <-hooked
+		assert.ErrorIs(t, err, errBoom)
+	})
+}
@@ -0,0 +1,182 @@
+package kcollection
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrQueueClosed = errors.New("queue closed")
+)
+
+// BlockingQueue 有界阻塞队列,用于生产者消费者之间传递数据
+// 队列满时 Put 阻塞,队列空时 Take 阻塞
+type BlockingQueue[T any] struct {
+	ch        chan T
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBlockingQueue 创建一个新的阻塞队列
+//
+// 参数说明:
+//   - capacity: 队列容量,必须大于0
+//
+// 返回值:
+//   - *BlockingQueue[T]: 新创建的阻塞队列
+//
+// 注意事项:
+//   - capacity小于1时会panic
+//   - 线程安全
+//   - 关闭后不能再写入,但仍可以取出剩余的元素
+//
+// 示例:
+//
+//	q := NewBlockingQueue[int](10)
+//	go func() {
+//	    for i := 0; i < 100; i++ {
+//	        q.Put(i)
+//	    }
+//	    q.Close()
+//	}()
+//	for {
+//	    v, err := q.Take()
+//	    if err != nil {
+//	        break // 队列已关闭且为空
+//	    }
+//	    fmt.Println(v)
+//	}
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity < 1 {
+		panic("capacity must be greater than 0")
+	}
+	return &BlockingQueue[T]{
+		ch:   make(chan T, capacity),
+		done: make(chan struct{}),
+	}
+}
+
+// Put 写入元素,队列满时阻塞直到有空位
+// 返回:
+//   - error: 队列已关闭时返回 ErrQueueClosed
+func (q *BlockingQueue[T]) Put(v T) error {
+	return q.PutContext(context.Background(), v)
+}
+
+// PutContext 写入元素,队列满时阻塞直到有空位或ctx结束
+// 返回:
+//   - error: 队列已关闭时返回 ErrQueueClosed,ctx结束时返回ctx.Err()
+func (q *BlockingQueue[T]) PutContext(ctx context.Context, v T) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+	// 有空位时直接写入,避免与已结束的ctx竞争
+	select {
+	case q.ch <- v:
+		return nil
+	default:
+	}
+	select {
+	case q.ch <- v:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryPut 在timeout内尝试写入元素
+// 参数:
+//   - v: 需要写入的元素
+//   - timeout: 最长等待时间,小于等于0时不等待
+//
+// 返回:
+//   - bool: 是否写入成功
+func (q *BlockingQueue[T]) TryPut(v T, timeout time.Duration) bool {
+	ctx, cancel := timeoutContext(timeout)
+	defer cancel()
+	return q.PutContext(ctx, v) == nil
+}
+
+// Take 取出元素,队列空时阻塞直到有元素
+// 返回:
+//   - T: 取出的元素
+//   - error: 队列已关闭且为空时返回 ErrQueueClosed
+func (q *BlockingQueue[T]) Take() (T, error) {
+	return q.TakeContext(context.Background())
+}
+
+// TakeContext 取出元素,队列空时阻塞直到有元素或ctx结束
+// 返回:
+//   - T: 取出的元素
+//   - error: 队列已关闭且为空时返回 ErrQueueClosed,ctx结束时返回ctx.Err()
+func (q *BlockingQueue[T]) TakeContext(ctx context.Context) (T, error) {
+	var zero T
+	// 有元素时直接取出,避免与已结束的ctx竞争
+	select {
+	case v := <-q.ch:
+		return v, nil
+	default:
+	}
+	select {
+	case v := <-q.ch:
+		return v, nil
+	case <-q.done:
+		// 已关闭时优先取出剩余元素
+		select {
+		case v := <-q.ch:
+			return v, nil
+		default:
+			return zero, ErrQueueClosed
+		}
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// TryTake 在timeout内尝试取出元素
+// 参数:
+//   - timeout: 最长等待时间,小于等于0时不等待
+//
+// 返回:
+//   - T: 取出的元素
+//   - bool: 是否取出成功
+func (q *BlockingQueue[T]) TryTake(timeout time.Duration) (T, bool) {
+	ctx, cancel := timeoutContext(timeout)
+	defer cancel()
+	v, err := q.TakeContext(ctx)
+	return v, err == nil
+}
+
+// Len 返回当前元素数量
+func (q *BlockingQueue[T]) Len() int {
+	return len(q.ch)
+}
+
+// Cap 返回队列容量
+func (q *BlockingQueue[T]) Cap() int {
+	return cap(q.ch)
+}
+
+// Close 关闭队列,唤醒所有阻塞的写入者,重复调用是安全的
+func (q *BlockingQueue[T]) Close() {
+	q.closeOnce.Do(func() {
+		close(q.done)
+	})
+}
+
+// timeoutContext 创建超时上下文,timeout小于等于0时返回已取消的上下文
+func timeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx, cancel
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
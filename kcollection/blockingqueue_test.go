@@ -0,0 +1,85 @@
+package kcollection
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	t.Run("生产者消费者", func(t *testing.T) {
+		q := NewBlockingQueue[int](2)
+		go func() {
+			for i := 0; i < 100; i++ {
+				assert.NoError(t, q.Put(i))
+			}
+			q.Close()
+		}()
+		var got []int
+		for {
+			v, err := q.Take()
+			if err != nil {
+				assert.True(t, errors.Is(err, ErrQueueClosed))
+				break
+			}
+			got = append(got, v)
+		}
+		assert.Len(t, got, 100)
+		assert.Equal(t, 99, got[99])
+	})
+
+	t.Run("超时", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+		_, ok := q.TryTake(10 * time.Millisecond)
+		assert.False(t, ok)
+		assert.True(t, q.TryPut(1, 0))
+		assert.False(t, q.TryPut(2, 10*time.Millisecond))
+		assert.Equal(t, 1, q.Len())
+		assert.Equal(t, 1, q.Cap())
+		v, ok := q.TryTake(0)
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+	})
+
+	t.Run("上下文取消", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := q.TakeContext(ctx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		q.Put(1)
+		ctx2, cancel2 := context.WithCancel(context.Background())
+		cancel2()
+		assert.True(t, errors.Is(q.PutContext(ctx2, 2), context.Canceled))
+	})
+
+	t.Run("关闭唤醒阻塞的写入者", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+		q.Put(1)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.True(t, errors.Is(q.Put(2), ErrQueueClosed))
+		}()
+		time.Sleep(10 * time.Millisecond)
+		q.Close()
+		q.Close()
+		wg.Wait()
+
+		v, err := q.Take()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+		_, err = q.Take()
+		assert.True(t, errors.Is(err, ErrQueueClosed))
+	})
+
+	t.Run("非法容量", func(t *testing.T) {
+		assert.Panics(t, func() { NewBlockingQueue[int](0) })
+	})
+}
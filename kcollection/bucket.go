@@ -0,0 +1,101 @@
+package kcollection
+
+import "github.com/mtgnorton/k/kmath"
+
+// MinMaxBucket 在 Bucket 的基础上记录最小值和最大值的桶类型
+// 可用于回答"最近一分钟的最大延迟"等问题
+type MinMaxBucket[T kmath.Number] struct {
+	Sum   T     // 桶中所有值的和
+	Count int64 // 桶中值的数量
+	Min   T     // 桶中的最小值,Count为0时无意义
+	Max   T     // 桶中的最大值,Count为0时无意义
+}
+
+// Add 向桶中添加一个值
+func (b *MinMaxBucket[T]) Add(v T) {
+	if b.Count == 0 || v < b.Min {
+		b.Min = v
+	}
+	if b.Count == 0 || v > b.Max {
+		b.Max = v
+	}
+	b.Sum += v
+	b.Count++
+}
+
+// Reset 重置桶
+func (b *MinMaxBucket[T]) Reset() {
+	*b = MinMaxBucket[T]{}
+}
+
+// HistogramBucket 按桶边界统计分布的桶类型,用于计算窗口内的百分位数
+type HistogramBucket[T kmath.Number] struct {
+	*kmath.Histogram[T]
+}
+
+// NewHistogramBucket 创建一个直方图桶
+// 参数:
+//   - bounds: 直方图的桶边界,必须严格递增,参见 kmath.NewHistogram
+//
+// 示例:
+//
+//	bounds := []int64{10, 50, 100, 500, 1000}
+//	rw := NewRollingWindow(func() *HistogramBucket[int64] {
+//	    return NewHistogramBucket(bounds)
+//	}, WithSize[int64, *HistogramBucket[int64]](60), WithInterval[int64, *HistogramBucket[int64]](time.Second))
+//	rw.Add(120)
+//	p99 := ReduceHistogram(rw).Percentile(99)
+func NewHistogramBucket[T kmath.Number](bounds []T) *HistogramBucket[T] {
+	return &HistogramBucket[T]{Histogram: kmath.NewHistogram(bounds)}
+}
+
+// Add 向桶中添加一个值
+func (b *HistogramBucket[T]) Add(v T) {
+	b.Observe(v)
+}
+
+// ReduceMinMax 计算窗口内所有有效桶的最小值和最大值
+// 参数:
+//   - rw: 使用 MinMaxBucket 的滑动窗口
+//
+// 返回:
+//   - min: 窗口内的最小值
+//   - max: 窗口内的最大值
+//   - ok: 窗口内是否有值
+func ReduceMinMax[T kmath.Number](rw *RollingWindow[T, *MinMaxBucket[T]]) (min, max T, ok bool) {
+	rw.Reduce(func(b *MinMaxBucket[T]) {
+		if b.Count == 0 {
+			return
+		}
+		if !ok || b.Min < min {
+			min = b.Min
+		}
+		if !ok || b.Max > max {
+			max = b.Max
+		}
+		ok = true
+	})
+	return min, max, ok
+}
+
+// ReduceHistogram 将窗口内所有有效桶合并为一个直方图
+// 参数:
+//   - rw: 使用 HistogramBucket 的滑动窗口,所有桶的边界必须一致
+//
+// 返回:
+//   - *kmath.Histogram[T]: 合并后的直方图,可查询百分位数、计数等,窗口为空时返回nil
+//
+// 注意:
+//   - 桶边界不一致时会panic
+func ReduceHistogram[T kmath.Number](rw *RollingWindow[T, *HistogramBucket[T]]) *kmath.Histogram[T] {
+	var merged *kmath.Histogram[T]
+	rw.Reduce(func(b *HistogramBucket[T]) {
+		if merged == nil {
+			merged = kmath.NewHistogram(b.Bounds())
+		}
+		if err := merged.Merge(b.Histogram); err != nil {
+			panic(err)
+		}
+	})
+	return merged
+}
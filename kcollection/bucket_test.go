@@ -0,0 +1,62 @@
+package kcollection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMaxBucket(t *testing.T) {
+	r := NewRollingWindow(func() *MinMaxBucket[int64] {
+		return new(MinMaxBucket[int64])
+	}, WithSize[int64, *MinMaxBucket[int64]](3), WithInterval[int64, *MinMaxBucket[int64]](duration))
+
+	_, _, ok := ReduceMinMax(r)
+	assert.False(t, ok)
+
+	r.Add(5)
+	r.Add(-2)
+	elapse()
+	r.Add(10)
+	minV, maxV, ok := ReduceMinMax(r)
+	assert.True(t, ok)
+	assert.Equal(t, int64(-2), minV)
+	assert.Equal(t, int64(10), maxV)
+
+	elapse()
+	elapse()
+	minV, maxV, ok = ReduceMinMax(r)
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), minV)
+	assert.Equal(t, int64(10), maxV)
+
+	b := &MinMaxBucket[int64]{}
+	b.Add(3)
+	b.Reset()
+	assert.Equal(t, MinMaxBucket[int64]{}, *b)
+}
+
+func TestHistogramBucket(t *testing.T) {
+	bounds := []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+	r := NewRollingWindow(func() *HistogramBucket[float64] {
+		return NewHistogramBucket(bounds)
+	}, WithSize[float64, *HistogramBucket[float64]](3), WithInterval[float64, *HistogramBucket[float64]](duration))
+
+	for i := 1; i <= 50; i++ {
+		r.Add(float64(i))
+	}
+	elapse()
+	for i := 51; i <= 100; i++ {
+		r.Add(float64(i))
+	}
+	h := ReduceHistogram(r)
+	assert.Equal(t, int64(100), h.Count())
+	assert.InDelta(t, 99, h.Percentile(99), 1)
+	assert.Equal(t, 100.0, h.Max())
+
+	elapse()
+	elapse()
+	h = ReduceHistogram(r)
+	assert.Equal(t, int64(50), h.Count())
+	assert.Equal(t, 51.0, h.Min())
+}
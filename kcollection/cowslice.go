@@ -0,0 +1,114 @@
+package kcollection
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// CowSlice 写时复制(copy-on-write)切片,适用于读多写少的场景,如路由表、配置列表
+//
+// 读操作通过原子加载当前快照完成,不加锁;写操作复制一份新切片修改后再原子替换
+type CowSlice[T any] struct {
+	mu   sync.Mutex // 串行化写操作
+	data atomic.Pointer[[]T]
+}
+
+// NewCowSlice 创建一个新的写时复制切片
+//
+// 参数说明:
+//   - items: 可选的初始元素
+//
+// 返回值:
+//   - *CowSlice[T]: 新创建的写时复制切片
+//
+// 注意事项:
+//   - 线程安全,读操作无锁,写操作的时间复杂度为O(n)
+//   - Load 返回的快照为只读,不能修改,否则会影响其他读者
+//
+// 示例:
+//
+//	routes := NewCowSlice("/a", "/b")
+//	routes.Append("/c")
+//	routes.Range(func(i int, v string) bool {
+//		fmt.Println(i, v)
+//		return true
+//	})
+func NewCowSlice[T any](items ...T) *CowSlice[T] {
+	s := &CowSlice[T]{}
+	data := slices.Clone(items)
+	s.data.Store(&data)
+	return s
+}
+
+// Load 返回当前的只读快照
+// 注意:
+//   - 返回的切片与其他读者共享,不能修改
+func (s *CowSlice[T]) Load() []T {
+	return *s.data.Load()
+}
+
+// Len 返回元素数量
+func (s *CowSlice[T]) Len() int {
+	return len(s.Load())
+}
+
+// At 返回第i个元素
+// 注意:
+//   - i超出范围时会panic
+func (s *CowSlice[T]) At(i int) T {
+	return s.Load()[i]
+}
+
+// Range 按顺序遍历当前快照
+// 参数:
+//   - fn: 遍历函数,返回false时停止遍历
+//
+// 注意:
+//   - 遍历的是调用时的快照,遍历期间的写操作不会影响本次遍历
+func (s *CowSlice[T]) Range(fn func(i int, v T) bool) {
+	for i, v := range s.Load() {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
+
+// Append 在末尾追加元素
+// 参数:
+//   - items: 需要追加的元素
+func (s *CowSlice[T]) Append(items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old := s.Load()
+	data := make([]T, len(old), len(old)+len(items))
+	copy(data, old)
+	data = append(data, items...)
+	s.data.Store(&data)
+}
+
+// Replace 整体替换所有元素
+// 参数:
+//   - items: 新的元素,会被复制一份,调用方之后修改items不影响容器
+func (s *CowSlice[T]) Replace(items []T) {
+	data := slices.Clone(items)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Store(&data)
+}
+
+// Update 基于当前快照的副本修改后原子替换
+// 参数:
+//   - fn: 修改函数,接收当前元素的副本,返回新的元素
+//
+// 注意:
+//   - fn在写锁内执行,不能调用当前切片的写操作,否则会死锁
+func (s *CowSlice[T]) Update(fn func(items []T) []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := fn(slices.Clone(s.Load()))
+	s.data.Store(&data)
+}
@@ -0,0 +1,83 @@
+package kcollection
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCowSlice(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		items := []int{1, 2}
+		s := NewCowSlice(items...)
+		items[0] = 100 // 修改原切片不影响容器
+		assert.Equal(t, []int{1, 2}, s.Load())
+
+		s.Append(3, 4)
+		s.Append()
+		assert.Equal(t, 4, s.Len())
+		assert.Equal(t, 3, s.At(2))
+		assert.Equal(t, []int{1, 2, 3, 4}, s.Load())
+		assert.Panics(t, func() { s.At(4) })
+	})
+
+	t.Run("快照不受写操作影响", func(t *testing.T) {
+		s := NewCowSlice(1, 2, 3)
+		snap := s.Load()
+		s.Append(4)
+		s.Replace([]int{9})
+		assert.Equal(t, []int{1, 2, 3}, snap)
+		assert.Equal(t, []int{9}, s.Load())
+	})
+
+	t.Run("Range提前终止", func(t *testing.T) {
+		s := NewCowSlice("a", "b", "c")
+		var got []string
+		s.Range(func(i int, v string) bool {
+			got = append(got, v)
+			return i < 1
+		})
+		assert.Equal(t, []string{"a", "b"}, got)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		s := NewCowSlice(1, 2, 3)
+		snap := s.Load()
+		s.Update(func(items []int) []int {
+			items[0] = 10
+			return items[:2]
+		})
+		assert.Equal(t, []int{10, 2}, s.Load())
+		assert.Equal(t, []int{1, 2, 3}, snap)
+	})
+
+	t.Run("空切片", func(t *testing.T) {
+		s := NewCowSlice[int]()
+		assert.Equal(t, 0, s.Len())
+		s.Replace(nil)
+		assert.Equal(t, 0, s.Len())
+	})
+
+	t.Run("并发读写", func(t *testing.T) {
+		s := NewCowSlice[int]()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					s.Append(i)
+				}
+			}(i)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					s.Range(func(int, int) bool { return true })
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1000, s.Len())
+	})
+}
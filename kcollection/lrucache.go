@@ -0,0 +1,336 @@
+package kcollection
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// EvictReason 元素被移出缓存的原因
+type EvictReason int
+
+const (
+	EvictReasonCapacity EvictReason = iota // 超出最大条目数被淘汰
+	EvictReasonExpired                     // 过期被清理
+	EvictReasonRemoved                     // 被主动删除
+)
+
+// String 返回淘汰原因的名称
+func (r EvictReason) String() string {
+	switch r {
+	case EvictReasonCapacity:
+		return "capacity"
+	case EvictReasonExpired:
+		return "expired"
+	case EvictReasonRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// CacheStats 缓存统计信息
+type CacheStats struct {
+	Hits        uint64 `json:"hits"`        // 命中次数
+	Misses      uint64 `json:"misses"`      // 未命中次数(包括已过期)
+	Evictions   uint64 `json:"evictions"`   // 因容量不足淘汰的次数
+	Expirations uint64 `json:"expirations"` // 因过期清理的次数
+	Size        int    `json:"size"`        // 当前条目数
+}
+
+// HitRate 返回命中率,没有访问记录时返回0
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// LRUCache 带过期时间的LRU缓存
+type LRUCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	ll    *list.List // 链表头部为最近访问的元素
+	items map[K]*list.Element
+	stats CacheStats
+	opts  *LRUCacheOptions[K, V]
+}
+
+type lruCacheEntry[K comparable, V any] struct {
+	key      K
+	value    V
+	expireAt time.Time // 零值表示永不过期
+}
+
+func (e *lruCacheEntry[K, V]) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+type LRUCacheOptions[K comparable, V any] struct {
+	MaxEntries int                                      // 最大条目数,0表示不限制
+	TTL        time.Duration                            // 默认过期时间,0表示永不过期
+	OnEvict    func(key K, value V, reason EvictReason) // 元素被移出缓存时的回调
+}
+
+type LRUCacheOption[K comparable, V any] func(opts *LRUCacheOptions[K, V])
+
+func NewLRUCacheOptions[K comparable, V any]() *LRUCacheOptions[K, V] {
+	return &LRUCacheOptions[K, V]{
+		MaxEntries: 1000,
+	}
+}
+
+func WithMaxEntries[K comparable, V any](maxEntries int) LRUCacheOption[K, V] {
+	return func(opts *LRUCacheOptions[K, V]) {
+		opts.MaxEntries = maxEntries
+	}
+}
+
+func WithTTL[K comparable, V any](ttl time.Duration) LRUCacheOption[K, V] {
+	return func(opts *LRUCacheOptions[K, V]) {
+		opts.TTL = ttl
+	}
+}
+
+func WithOnEvict[K comparable, V any](fn func(key K, value V, reason EvictReason)) LRUCacheOption[K, V] {
+	return func(opts *LRUCacheOptions[K, V]) {
+		opts.OnEvict = fn
+	}
+}
+
+// NewLRUCache 创建一个新的带过期时间的LRU缓存
+//
+// 参数说明:
+//   - opts: 可选配置项,包括最大条目数、默认过期时间、淘汰回调等
+//
+// 返回值:
+//   - *LRUCache[K, V]: 新创建的缓存
+//
+// 注意事项:
+//   - 默认最大条目数为1000,永不过期
+//   - 过期元素在访问时惰性清理,也可以定期调用 DeleteExpired 主动清理
+//   - 淘汰回调在锁外执行,可以在回调中访问缓存
+//   - 线程安全
+//
+// 示例:
+//
+//	cache := NewLRUCache(
+//	    WithMaxEntries[string, int](100),
+//	    WithTTL[string, int](time.Minute),
+//	    WithOnEvict(func(k string, v int, reason EvictReason) {
+//	        fmt.Println("evict", k, reason)
+//	    }),
+//	)
+//	cache.Set("a", 1)
+//	cache.SetWithTTL("b", 2, time.Second)
+//	v, ok := cache.Get("a")
+func NewLRUCache[K comparable, V any](opts ...LRUCacheOption[K, V]) *LRUCache[K, V] {
+	options := NewLRUCacheOptions[K, V]()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return &LRUCache[K, V]{
+		ll:    list.New(),
+		items: make(map[K]*list.Element),
+		opts:  options,
+	}
+}
+
+// Get 获取key对应的值,并将其标记为最近访问
+// 返回:
+//   - V: key对应的值,不存在或已过期时为零值
+//   - bool: key是否存在且未过期
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	var zero V
+	c.mu.Lock()
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		c.mu.Unlock()
+		return zero, false
+	}
+	entry := e.Value.(*lruCacheEntry[K, V])
+	if entry.expired(time.Now()) {
+		c.stats.Misses++
+		c.stats.Expirations++
+		c.removeElement(e)
+		c.mu.Unlock()
+		c.notify(entry, EvictReasonExpired)
+		return zero, false
+	}
+	c.stats.Hits++
+	c.ll.MoveToFront(e)
+	c.mu.Unlock()
+	return entry.value, true
+}
+
+// Peek 获取key对应的值,不改变访问顺序,也不计入命中统计
+// 返回:
+//   - V: key对应的值,不存在或已过期时为零值,已过期的元素不会被清理
+//   - bool: key是否存在且未过期
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		if entry := e.Value.(*lruCacheEntry[K, V]); !entry.expired(time.Now()) {
+			return entry.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains 判断key是否存在且未过期,不改变访问顺序,也不计入命中统计
+func (c *LRUCache[K, V]) Contains(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+// Set 使用默认过期时间写入键值对,参见 SetWithTTL
+func (c *LRUCache[K, V]) Set(key K, value V) (evicted bool) {
+	return c.SetWithTTL(key, value, c.opts.TTL)
+}
+
+// SetWithTTL 使用指定的过期时间写入键值对
+// 参数:
+//   - key: 键
+//   - value: 值
+//   - ttl: 过期时间,小于等于0表示永不过期
+//
+// 返回:
+//   - evicted: 是否因超出最大条目数淘汰了元素,key已存在时更新其值,不会淘汰
+func (c *LRUCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) (evicted bool) {
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = time.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	if e, ok := c.items[key]; ok {
+		entry := e.Value.(*lruCacheEntry[K, V])
+		entry.value = value
+		entry.expireAt = expireAt
+		c.ll.MoveToFront(e)
+		c.mu.Unlock()
+		return false
+	}
+	c.items[key] = c.ll.PushFront(&lruCacheEntry[K, V]{key: key, value: value, expireAt: expireAt})
+	var oldest *lruCacheEntry[K, V]
+	if c.opts.MaxEntries > 0 && c.ll.Len() > c.opts.MaxEntries {
+		oldest = c.removeElement(c.ll.Back())
+		c.stats.Evictions++
+	}
+	c.mu.Unlock()
+	if oldest != nil {
+		c.notify(oldest, EvictReasonCapacity)
+	}
+	return oldest != nil
+}
+
+// Delete 删除key,会以 EvictReasonRemoved 触发淘汰回调
+// 返回:
+//   - bool: key是否存在
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	e, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return false
+	}
+	entry := c.removeElement(e)
+	c.mu.Unlock()
+	c.notify(entry, EvictReasonRemoved)
+	return true
+}
+
+// DeleteExpired 清理所有已过期的元素
+// 返回:
+//   - int: 清理的元素数量
+func (c *LRUCache[K, V]) DeleteExpired() int {
+	now := time.Now()
+	var expired []*lruCacheEntry[K, V]
+	c.mu.Lock()
+	for e := c.ll.Front(); e != nil; {
+		next := e.Next()
+		if entry := e.Value.(*lruCacheEntry[K, V]); entry.expired(now) {
+			expired = append(expired, c.removeElement(e))
+		}
+		e = next
+	}
+	c.stats.Expirations += uint64(len(expired))
+	c.mu.Unlock()
+	for _, entry := range expired {
+		c.notify(entry, EvictReasonExpired)
+	}
+	return len(expired)
+}
+
+// Len 返回当前条目数,可能包含尚未清理的过期元素
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Keys 返回所有未过期的key,顺序为从最近访问到最久未访问
+func (c *LRUCache[K, V]) Keys() []K {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, c.ll.Len())
+	for e := c.ll.Front(); e != nil; e = e.Next() {
+		if entry := e.Value.(*lruCacheEntry[K, V]); !entry.expired(now) {
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
+// MaxEntries 返回最大条目数,0表示不限制
+func (c *LRUCache[K, V]) MaxEntries() int {
+	return c.opts.MaxEntries
+}
+
+// Purge 清空缓存,不触发淘汰回调
+func (c *LRUCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ll.Init()
+	clear(c.items)
+}
+
+// Stats 返回缓存统计信息,可用于上报监控指标
+func (c *LRUCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.ll.Len()
+	return stats
+}
+
+// MarshalJSON 将统计信息编码为JSON,参见 Stats
+// 缓存可以直接注册到 kmonitor.Register,输出时编码当前的统计信息
+func (c *LRUCache[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Stats())
+}
+
+// ResetStats 重置统计信息
+func (c *LRUCache[K, V]) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = CacheStats{}
+}
+
+// removeElement 从链表和map中删除元素,调用方需持有锁
+func (c *LRUCache[K, V]) removeElement(e *list.Element) *lruCacheEntry[K, V] {
+	entry := c.ll.Remove(e).(*lruCacheEntry[K, V])
+	delete(c.items, entry.key)
+	return entry
+}
+
+// notify 触发淘汰回调,调用方不能持有锁
+func (c *LRUCache[K, V]) notify(entry *lruCacheEntry[K, V], reason EvictReason) {
+	if c.opts.OnEvict != nil {
+		c.opts.OnEvict(entry.key, entry.value, reason)
+	}
+}
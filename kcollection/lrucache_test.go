@@ -0,0 +1,113 @@
+package kcollection
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Run("容量淘汰", func(t *testing.T) {
+		var reasons []EvictReason
+		var evicted []string
+		cache := NewLRUCache(
+			WithMaxEntries[string, int](2),
+			WithOnEvict(func(k string, v int, reason EvictReason) {
+				evicted = append(evicted, k)
+				reasons = append(reasons, reason)
+			}),
+		)
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Get("a")
+		cache.Set("c", 3)
+		assert.Equal(t, []string{"b"}, evicted)
+		assert.Equal(t, []EvictReason{EvictReasonCapacity}, reasons)
+		assert.Equal(t, []string{"c", "a"}, cache.Keys())
+
+		assert.True(t, cache.Delete("a"))
+		assert.False(t, cache.Delete("a"))
+		assert.Equal(t, EvictReasonRemoved, reasons[1])
+	})
+
+	t.Run("过期", func(t *testing.T) {
+		var reasons []EvictReason
+		cache := NewLRUCache(
+			WithTTL[string, int](30*time.Millisecond),
+			WithOnEvict(func(k string, v int, reason EvictReason) {
+				reasons = append(reasons, reason)
+			}),
+		)
+		cache.Set("a", 1)
+		cache.SetWithTTL("b", 2, 0)
+		cache.SetWithTTL("c", 3, 10*time.Millisecond)
+		v, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+
+		time.Sleep(15 * time.Millisecond)
+		_, ok = cache.Get("c")
+		assert.False(t, ok)
+		assert.Equal(t, 2, cache.Len())
+
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, []string{"b"}, cache.Keys())
+		assert.Equal(t, 1, cache.DeleteExpired())
+		assert.Equal(t, 1, cache.Len())
+		_, ok = cache.Get("b")
+		assert.True(t, ok)
+		assert.Equal(t, []EvictReason{EvictReasonExpired, EvictReasonExpired}, reasons)
+	})
+
+	t.Run("统计", func(t *testing.T) {
+		cache := NewLRUCache(WithMaxEntries[int, int](1))
+		cache.Set(1, 1)
+		cache.Get(1)
+		cache.Get(2)
+		cache.Set(2, 2)
+		stats := cache.Stats()
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1, Evictions: 1, Size: 1}, stats)
+		assert.Equal(t, 0.5, stats.HitRate())
+		cache.ResetStats()
+		assert.Equal(t, CacheStats{Size: 1}, cache.Stats())
+		data, err := json.Marshal(cache)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"hits":0,"misses":0,"evictions":0,"expirations":0,"size":1}`, string(data))
+		cache.Purge()
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("更新已有key", func(t *testing.T) {
+		cache := NewLRUCache[string, int]()
+		cache.SetWithTTL("a", 1, time.Millisecond)
+		cache.Set("a", 2)
+		time.Sleep(2 * time.Millisecond)
+		v, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 2, v)
+		assert.Equal(t, "capacity", EvictReasonCapacity.String())
+	})
+	t.Run("Peek和Contains不影响顺序和统计", func(t *testing.T) {
+		cache := NewLRUCache(WithMaxEntries[string, int](2))
+		assert.False(t, cache.Set("a", 1))
+		assert.False(t, cache.Set("b", 2))
+		assert.False(t, cache.Set("a", 3))
+		v, ok := cache.Peek("b")
+		assert.True(t, ok)
+		assert.Equal(t, 2, v)
+		assert.True(t, cache.Contains("b"))
+		assert.Equal(t, []string{"a", "b"}, cache.Keys())
+		assert.Equal(t, uint64(0), cache.Stats().Hits+cache.Stats().Misses)
+
+		assert.True(t, cache.Set("c", 4))
+		assert.False(t, cache.Contains("b"))
+		assert.Equal(t, 2, cache.MaxEntries())
+
+		cache.SetWithTTL("d", 5, time.Millisecond)
+		time.Sleep(2 * time.Millisecond)
+		_, ok = cache.Peek("d")
+		assert.False(t, ok)
+	})
+}
@@ -4,12 +4,15 @@ import (
 	"time"
 
 	"github.com/mtgnorton/k/kmath"
+	"github.com/mtgnorton/k/ktime"
 )
 
 type RollingWindowOptions[T kmath.Number, B BucketInterface[T]] struct {
-	Size          int           // 窗口大小(桶的数量)
-	Interval      time.Duration // 每个桶的时间间隔
-	IgnoreCurrent bool          // 是否忽略当前桶
+	Size           int           // 窗口大小(桶的数量)
+	Interval       time.Duration // 每个桶的时间间隔
+	IgnoreCurrent  bool          // 是否忽略当前桶
+	AlignWallClock bool          // 是否将桶边界对齐到墙上时钟,如interval为1分钟时每个桶从整分开始
+	Clock          ktime.Clock   // 时钟,测试时可以使用 ktime.FakeClock 控制桶的滚动
 }
 
 type RollingWindowOption[T kmath.Number, B BucketInterface[T]] func(opts *RollingWindowOptions[T, B])
@@ -18,6 +21,7 @@ func NewRollingWindowOptions[T kmath.Number, B BucketInterface[T]]() *RollingWin
 	return &RollingWindowOptions[T, B]{
 		Size:     10,
 		Interval: time.Minute,
+		Clock:    ktime.RealClock,
 	}
 }
 
@@ -38,3 +42,18 @@ func WithIgnoreCurrent[T kmath.Number, B BucketInterface[T]](ignore bool) Rollin
 		opts.IgnoreCurrent = ignore
 	}
 }
+
+// WithAlignWallClock 设置是否将桶边界对齐到墙上时钟
+// 开启后不同实例的桶边界一致(如都从整分开始),便于跨实例比较和汇总指标
+func WithAlignWallClock[T kmath.Number, B BucketInterface[T]](align bool) RollingWindowOption[T, B] {
+	return func(opts *RollingWindowOptions[T, B]) {
+		opts.AlignWallClock = align
+	}
+}
+
+// WithClock 设置滑动窗口使用的时钟,默认为 ktime.RealClock
+func WithClock[T kmath.Number, B BucketInterface[T]](clock ktime.Clock) RollingWindowOption[T, B] {
+	return func(opts *RollingWindowOptions[T, B]) {
+		opts.Clock = clock
+	}
+}
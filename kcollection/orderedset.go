@@ -0,0 +1,147 @@
+package kcollection
+
+import (
+	"slices"
+	"sync"
+)
+
+// OrderedSet 保持插入顺序的集合,遍历顺序与元素第一次加入的顺序一致
+type OrderedSet[T comparable] struct {
+	mu    sync.RWMutex
+	items []T
+	index map[T]int // 元素 -> 在items中的位置
+}
+
+// NewOrderedSet 创建一个新的有序集合
+//
+// 参数说明:
+//   - items: 可选的初始元素,重复元素只保留第一次出现的位置
+//
+// 返回值:
+//   - *OrderedSet[T]: 新创建的有序集合
+//
+// 注意事项:
+//   - 线程安全
+//   - Remove 的时间复杂度为O(n),其余操作为O(1)
+//
+// 示例:
+//
+//	s := NewOrderedSet(3, 1, 3, 2, 1)
+//	s.Values() // [3 1 2]
+func NewOrderedSet[T comparable](items ...T) *OrderedSet[T] {
+	s := &OrderedSet[T]{
+		items: make([]T, 0, len(items)),
+		index: make(map[T]int, len(items)),
+	}
+	s.add(items...)
+	return s
+}
+
+// Add 加入元素,已存在的元素保持原有位置
+// 参数:
+//   - items: 需要加入的元素
+//
+// 返回:
+//   - int: 新加入的元素数量
+func (s *OrderedSet[T]) Add(items ...T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(items...)
+}
+
+// Remove 删除元素,后续元素的位置依次前移
+// 返回:
+//   - bool: 元素是否存在
+func (s *OrderedSet[T]) Remove(item T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.index[item]
+	if !ok {
+		return false
+	}
+	s.items = slices.Delete(s.items, i, i+1)
+	delete(s.index, item)
+	for j := i; j < len(s.items); j++ {
+		s.index[s.items[j]] = j
+	}
+	return true
+}
+
+// Contains 判断元素是否存在
+func (s *OrderedSet[T]) Contains(item T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.index[item]
+	return ok
+}
+
+// At 返回第i个加入的元素
+// 注意:
+//   - i超出范围时会panic
+func (s *OrderedSet[T]) At(i int) T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items[i]
+}
+
+// IndexOf 返回元素的位置,不存在时返回-1
+func (s *OrderedSet[T]) IndexOf(item T) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if i, ok := s.index[item]; ok {
+		return i
+	}
+	return -1
+}
+
+// Len 返回元素数量
+func (s *OrderedSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// Values 按插入顺序返回所有元素的副本
+func (s *OrderedSet[T]) Values() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.items)
+}
+
+// Range 按插入顺序遍历元素
+// 参数:
+//   - fn: 遍历回调,接收位置和元素,返回false时停止遍历
+//
+// 注意:
+//   - 遍历期间持有读锁,不能在fn中修改集合
+func (s *OrderedSet[T]) Range(fn func(i int, item T) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i, item := range s.items {
+		if !fn(i, item) {
+			return
+		}
+	}
+}
+
+// Clear 清空集合
+func (s *OrderedSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = s.items[:0]
+	clear(s.index)
+}
+
+// add 加入元素,调用方需持有锁
+func (s *OrderedSet[T]) add(items ...T) int {
+	added := 0
+	for _, item := range items {
+		if _, ok := s.index[item]; ok {
+			continue
+		}
+		s.index[item] = len(s.items)
+		s.items = append(s.items, item)
+		added++
+	}
+	return added
+}
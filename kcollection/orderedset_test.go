@@ -0,0 +1,49 @@
+package kcollection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedSet(t *testing.T) {
+	t.Run("去重并保持顺序", func(t *testing.T) {
+		s := NewOrderedSet(3, 1, 3, 2, 1)
+		assert.Equal(t, []int{3, 1, 2}, s.Values())
+		assert.Equal(t, 3, s.Len())
+		assert.Equal(t, 1, s.Add(1, 4))
+		assert.Equal(t, []int{3, 1, 2, 4}, s.Values())
+	})
+
+	t.Run("下标访问", func(t *testing.T) {
+		s := NewOrderedSet("a", "b", "c")
+		assert.Equal(t, "b", s.At(1))
+		assert.Equal(t, 2, s.IndexOf("c"))
+		assert.Equal(t, -1, s.IndexOf("x"))
+		assert.Panics(t, func() { s.At(3) })
+	})
+
+	t.Run("删除", func(t *testing.T) {
+		s := NewOrderedSet("a", "b", "c")
+		assert.True(t, s.Remove("a"))
+		assert.False(t, s.Remove("a"))
+		assert.False(t, s.Contains("a"))
+		assert.Equal(t, 0, s.IndexOf("b"))
+		assert.Equal(t, 1, s.IndexOf("c"))
+		s.Add("a")
+		assert.Equal(t, []string{"b", "c", "a"}, s.Values())
+	})
+
+	t.Run("遍历和清空", func(t *testing.T) {
+		s := NewOrderedSet(1, 2, 3)
+		var visited []int
+		s.Range(func(i int, v int) bool {
+			visited = append(visited, v)
+			return i < 1
+		})
+		assert.Equal(t, []int{1, 2}, visited)
+		s.Clear()
+		assert.Equal(t, 0, s.Len())
+		assert.False(t, s.Contains(1))
+	})
+}
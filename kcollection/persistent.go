@@ -0,0 +1,382 @@
+package kcollection
+
+import (
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+const (
+	pvBits  = 5
+	pvWidth = 1 << pvBits
+	pvMask  = pvWidth - 1
+)
+
+// PersistentList 不可变的持久化列表,基于32叉前缀树(persistent vector)实现
+//
+// 每次修改都返回一个新版本,新旧版本共享未修改的节点,旧版本保持不变,
+// 适合将配置等数据的快照无锁地传递给多个goroutine
+type PersistentList[T any] struct {
+	root  *pvNode[T]
+	shift uint // 根节点所在层级的位移量,叶子层为0
+	size  int
+}
+
+type pvNode[T any] struct {
+	children []*pvNode[T] // 内部节点的子节点
+	values   []T          // 叶子节点的元素
+}
+
+// NewPersistentList 创建一个新的持久化列表
+//
+// 参数说明:
+//   - items: 可选的初始元素
+//
+// 返回值:
+//   - *PersistentList[T]: 新创建的持久化列表
+//
+// 注意事项:
+//   - 天然线程安全,任何版本都不会被修改
+//   - Get/Set/Append 的时间复杂度为O(log32 n),可视为常数
+//
+// 示例:
+//
+//	l1 := NewPersistentList(1, 2, 3)
+//	l2 := l1.Append(4).Set(0, 10)
+//	l1.Values() // [1 2 3]
+//	l2.Values() // [10 2 3 4]
+func NewPersistentList[T any](items ...T) *PersistentList[T] {
+	l := &PersistentList[T]{}
+	for _, v := range items {
+		l = l.Append(v)
+	}
+	return l
+}
+
+// Len 返回元素数量
+func (l *PersistentList[T]) Len() int {
+	return l.size
+}
+
+// Get 返回第i个元素
+// 注意:
+//   - i超出范围时会panic
+func (l *PersistentList[T]) Get(i int) T {
+	l.checkIndex(i)
+	node := l.root
+	for level := l.shift; level > 0; level -= pvBits {
+		node = node.children[(i>>level)&pvMask]
+	}
+	return node.values[i&pvMask]
+}
+
+// Set 返回将第i个元素替换为v后的新版本
+// 注意:
+//   - i超出范围时会panic
+func (l *PersistentList[T]) Set(i int, v T) *PersistentList[T] {
+	l.checkIndex(i)
+	return &PersistentList[T]{
+		root:  pvSet(l.root, l.shift, i, v),
+		shift: l.shift,
+		size:  l.size,
+	}
+}
+
+// Append 返回在末尾追加v后的新版本
+func (l *PersistentList[T]) Append(v T) *PersistentList[T] {
+	// 当前树已满,增加一层
+	if l.root != nil && l.size == 1<<(l.shift+pvBits) {
+		return &PersistentList[T]{
+			root: &pvNode[T]{
+				children: []*pvNode[T]{l.root, pvPush(nil, l.shift, l.size, v)},
+			},
+			shift: l.shift + pvBits,
+			size:  l.size + 1,
+		}
+	}
+	return &PersistentList[T]{
+		root:  pvPush(l.root, l.shift, l.size, v),
+		shift: l.shift,
+		size:  l.size + 1,
+	}
+}
+
+// Range 按顺序遍历所有元素
+// 参数:
+//   - fn: 遍历函数,返回false时停止遍历
+func (l *PersistentList[T]) Range(fn func(i int, v T) bool) {
+	l.walk(l.root, l.shift, 0, fn)
+}
+
+// Values 返回所有元素组成的新切片
+func (l *PersistentList[T]) Values() []T {
+	values := make([]T, 0, l.size)
+	l.Range(func(_ int, v T) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+func (l *PersistentList[T]) checkIndex(i int) {
+	if i < 0 || i >= l.size {
+		panic("index out of range")
+	}
+}
+
+// walk 深度优先遍历,返回false表示已被fn终止
+func (l *PersistentList[T]) walk(node *pvNode[T], level uint, start int, fn func(i int, v T) bool) bool {
+	if node == nil {
+		return true
+	}
+	if level == 0 {
+		for j, v := range node.values {
+			if !fn(start+j, v) {
+				return false
+			}
+		}
+		return true
+	}
+	for j, child := range node.children {
+		if !l.walk(child, level-pvBits, start+j<<level, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// pvSet 复制从根到第i个元素路径上的节点并替换元素
+func pvSet[T any](node *pvNode[T], level uint, i int, v T) *pvNode[T] {
+	n := &pvNode[T]{}
+	if level == 0 {
+		n.values = slices.Clone(node.values)
+		n.values[i&pvMask] = v
+		return n
+	}
+	n.children = slices.Clone(node.children)
+	idx := (i >> level) & pvMask
+	n.children[idx] = pvSet(node.children[idx], level-pvBits, i, v)
+	return n
+}
+
+// pvPush 复制路径上的节点并在位置i追加元素,node为nil时创建新路径
+func pvPush[T any](node *pvNode[T], level uint, i int, v T) *pvNode[T] {
+	n := &pvNode[T]{}
+	if level == 0 {
+		if node != nil {
+			n.values = make([]T, len(node.values), len(node.values)+1)
+			copy(n.values, node.values)
+		}
+		n.values = append(n.values, v)
+		return n
+	}
+	var children []*pvNode[T]
+	if node != nil {
+		children = node.children
+	}
+	n.children = make([]*pvNode[T], len(children), len(children)+1)
+	copy(n.children, children)
+	idx := (i >> level) & pvMask
+	if idx < len(n.children) {
+		n.children[idx] = pvPush(n.children[idx], level-pvBits, i, v)
+	} else {
+		n.children = append(n.children, pvPush(nil, level-pvBits, i, v))
+	}
+	return n
+}
+
+// PersistentMap 不可变的持久化有序map,基于路径复制的AVL树实现
+//
+// 每次修改都返回一个新版本,新旧版本共享未修改的节点,旧版本保持不变
+type PersistentMap[K constraints.Ordered, V any] struct {
+	root *pmNode[K, V]
+	size int
+}
+
+type pmNode[K constraints.Ordered, V any] struct {
+	key         K
+	value       V
+	left, right *pmNode[K, V]
+	height      int
+}
+
+// NewPersistentMap 创建一个新的空持久化map
+//
+// 注意事项:
+//   - 天然线程安全,任何版本都不会被修改
+//   - Get/Set/Delete 的时间复杂度为O(logn),每次修改复制O(logn)个节点
+//   - Range 按key升序遍历
+//
+// 示例:
+//
+//	m1 := NewPersistentMap[string, int]().Set("a", 1)
+//	m2 := m1.Set("b", 2).Delete("a")
+//	m1.Keys() // [a]
+//	m2.Keys() // [b]
+func NewPersistentMap[K constraints.Ordered, V any]() *PersistentMap[K, V] {
+	return &PersistentMap[K, V]{}
+}
+
+// Len 返回键值对数量
+func (m *PersistentMap[K, V]) Len() int {
+	return m.size
+}
+
+// Get 查找key对应的value
+// 返回:
+//   - V: key对应的value,不存在时为零值
+//   - bool: key是否存在
+func (m *PersistentMap[K, V]) Get(key K) (V, bool) {
+	n := m.root
+	for n != nil {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains 判断key是否存在
+func (m *PersistentMap[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set 返回写入key/value后的新版本,key已存在时覆盖value
+func (m *PersistentMap[K, V]) Set(key K, value V) *PersistentMap[K, V] {
+	root, added := pmInsert(m.root, key, value)
+	size := m.size
+	if added {
+		size++
+	}
+	return &PersistentMap[K, V]{root: root, size: size}
+}
+
+// Delete 返回删除key后的新版本,key不存在时返回当前版本
+func (m *PersistentMap[K, V]) Delete(key K) *PersistentMap[K, V] {
+	root, removed := pmDelete(m.root, key)
+	if !removed {
+		return m
+	}
+	return &PersistentMap[K, V]{root: root, size: m.size - 1}
+}
+
+// Range 按key升序遍历
+// 参数:
+//   - fn: 遍历函数,返回false时停止遍历
+func (m *PersistentMap[K, V]) Range(fn func(key K, value V) bool) {
+	pmWalk(m.root, fn)
+}
+
+// Keys 按升序返回所有key
+func (m *PersistentMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+func pmWalk[K constraints.Ordered, V any](n *pmNode[K, V], fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return pmWalk(n.left, fn) && fn(n.key, n.value) && pmWalk(n.right, fn)
+}
+
+func pmHeight[K constraints.Ordered, V any](n *pmNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// pmNew 创建新节点并计算高度
+func pmNew[K constraints.Ordered, V any](key K, value V, left, right *pmNode[K, V]) *pmNode[K, V] {
+	return &pmNode[K, V]{
+		key:    key,
+		value:  value,
+		left:   left,
+		right:  right,
+		height: max(pmHeight(left), pmHeight(right)) + 1,
+	}
+}
+
+// pmBalance 以key/value为根创建节点,必要时旋转使其重新平衡
+func pmBalance[K constraints.Ordered, V any](key K, value V, left, right *pmNode[K, V]) *pmNode[K, V] {
+	lh, rh := pmHeight(left), pmHeight(right)
+	switch {
+	case lh > rh+1:
+		if pmHeight(left.left) >= pmHeight(left.right) {
+			return pmNew(left.key, left.value, left.left, pmNew(key, value, left.right, right))
+		}
+		lr := left.right
+		return pmNew(lr.key, lr.value,
+			pmNew(left.key, left.value, left.left, lr.left),
+			pmNew(key, value, lr.right, right))
+	case rh > lh+1:
+		if pmHeight(right.right) >= pmHeight(right.left) {
+			return pmNew(right.key, right.value, pmNew(key, value, left, right.left), right.right)
+		}
+		rl := right.left
+		return pmNew(rl.key, rl.value,
+			pmNew(key, value, left, rl.left),
+			pmNew(right.key, right.value, rl.right, right.right))
+	}
+	return pmNew(key, value, left, right)
+}
+
+func pmInsert[K constraints.Ordered, V any](n *pmNode[K, V], key K, value V) (*pmNode[K, V], bool) {
+	if n == nil {
+		return pmNew[K, V](key, value, nil, nil), true
+	}
+	switch {
+	case key < n.key:
+		left, added := pmInsert(n.left, key, value)
+		return pmBalance(n.key, n.value, left, n.right), added
+	case key > n.key:
+		right, added := pmInsert(n.right, key, value)
+		return pmBalance(n.key, n.value, n.left, right), added
+	}
+	return pmNew(key, value, n.left, n.right), false
+}
+
+func pmDelete[K constraints.Ordered, V any](n *pmNode[K, V], key K) (*pmNode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	switch {
+	case key < n.key:
+		left, removed := pmDelete(n.left, key)
+		if !removed {
+			return n, false
+		}
+		return pmBalance(n.key, n.value, left, n.right), true
+	case key > n.key:
+		right, removed := pmDelete(n.right, key)
+		if !removed {
+			return n, false
+		}
+		return pmBalance(n.key, n.value, n.left, right), true
+	}
+	if n.left == nil {
+		return n.right, true
+	}
+	if n.right == nil {
+		return n.left, true
+	}
+	// 用右子树的最小节点替换当前节点
+	successor := n.right
+	for successor.left != nil {
+		successor = successor.left
+	}
+	right, _ := pmDelete(n.right, successor.key)
+	return pmBalance(successor.key, successor.value, n.left, right), true
+}
@@ -0,0 +1,138 @@
+package kcollection
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentList(t *testing.T) {
+	t.Run("追加与读取", func(t *testing.T) {
+		const n = pvWidth*pvWidth + 10 // 覆盖三层树
+		l := NewPersistentList[int]()
+		for i := 0; i < n; i++ {
+			l = l.Append(i)
+		}
+		assert.Equal(t, n, l.Len())
+		for i := 0; i < n; i++ {
+			if !assert.Equal(t, i, l.Get(i)) {
+				break
+			}
+		}
+		values := l.Values()
+		assert.Len(t, values, n)
+		assert.Equal(t, n-1, values[n-1])
+	})
+
+	t.Run("旧版本保持不变", func(t *testing.T) {
+		l1 := NewPersistentList(1, 2, 3)
+		l2 := l1.Append(4)
+		l3 := l2.Set(0, 10)
+		l4 := l1.Append(5) // 从同一个旧版本分叉
+		assert.Equal(t, []int{1, 2, 3}, l1.Values())
+		assert.Equal(t, []int{1, 2, 3, 4}, l2.Values())
+		assert.Equal(t, []int{10, 2, 3, 4}, l3.Values())
+		assert.Equal(t, []int{1, 2, 3, 5}, l4.Values())
+	})
+
+	t.Run("跨层级修改", func(t *testing.T) {
+		l1 := NewPersistentList[int]()
+		for i := 0; i < pvWidth*3; i++ {
+			l1 = l1.Append(i)
+		}
+		l2 := l1.Set(pvWidth+1, -1)
+		assert.Equal(t, pvWidth+1, l1.Get(pvWidth+1))
+		assert.Equal(t, -1, l2.Get(pvWidth+1))
+		assert.Equal(t, pvWidth*2, l2.Get(pvWidth*2))
+	})
+
+	t.Run("Range提前终止", func(t *testing.T) {
+		l := NewPersistentList("a", "b", "c")
+		var got []string
+		l.Range(func(i int, v string) bool {
+			got = append(got, v)
+			return i < 1
+		})
+		assert.Equal(t, []string{"a", "b"}, got)
+	})
+
+	t.Run("越界", func(t *testing.T) {
+		l := NewPersistentList(1)
+		assert.Panics(t, func() { l.Get(1) })
+		assert.Panics(t, func() { l.Set(-1, 0) })
+		assert.Equal(t, 0, NewPersistentList[int]().Len())
+		assert.Empty(t, NewPersistentList[int]().Values())
+	})
+}
+
+func TestPersistentMap(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		m1 := NewPersistentMap[string, int]().Set("a", 1).Set("c", 3).Set("b", 2)
+		m2 := m1.Set("a", 10).Delete("c")
+		m3 := m2.Delete("x")
+
+		assert.Equal(t, 3, m1.Len())
+		assert.Equal(t, []string{"a", "b", "c"}, m1.Keys())
+		v, ok := m1.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+
+		assert.Equal(t, 2, m2.Len())
+		assert.Equal(t, []string{"a", "b"}, m2.Keys())
+		v, _ = m2.Get("a")
+		assert.Equal(t, 10, v)
+		assert.False(t, m2.Contains("c"))
+		assert.Same(t, m2, m3)
+	})
+
+	t.Run("随机操作与平衡", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		m := NewPersistentMap[int, int]()
+		ref := map[int]int{}
+		for i := 0; i < 2000; i++ {
+			k := r.Intn(500)
+			if r.Intn(3) == 0 {
+				m = m.Delete(k)
+				delete(ref, k)
+			} else {
+				m = m.Set(k, i)
+				ref[k] = i
+			}
+		}
+		assert.Equal(t, len(ref), m.Len())
+		keys := make([]int, 0, len(ref))
+		for k, v := range ref {
+			keys = append(keys, k)
+			got, ok := m.Get(k)
+			assert.True(t, ok)
+			assert.Equal(t, v, got)
+		}
+		sort.Ints(keys)
+		assert.Equal(t, keys, m.Keys())
+		assertAVL(t, m.root)
+	})
+
+	t.Run("Range提前终止", func(t *testing.T) {
+		m := NewPersistentMap[int, string]().Set(2, "b").Set(1, "a").Set(3, "c")
+		var got []string
+		m.Range(func(k int, v string) bool {
+			got = append(got, v)
+			return k < 2
+		})
+		assert.Equal(t, []string{"a", "b"}, got)
+	})
+}
+
+// assertAVL 校验每个节点的高度正确且左右子树高度差不超过1
+func assertAVL(t *testing.T, n *pmNode[int, int]) int {
+	if n == nil {
+		return 0
+	}
+	lh, rh := assertAVL(t, n.left), assertAVL(t, n.right)
+	assert.LessOrEqual(t, lh-rh, 1)
+	assert.LessOrEqual(t, rh-lh, 1)
+	assert.Equal(t, max(lh, rh)+1, n.height)
+	return n.height
+}
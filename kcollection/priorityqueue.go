@@ -0,0 +1,203 @@
+package kcollection
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// PriorityQueue 基于 container/heap 的优先队列,less(a, b)为true时a优先出队
+type PriorityQueue[T any] struct {
+	mu   sync.Mutex
+	h    *pqHeap[T]
+	opts *PriorityQueueOptions
+}
+
+// PQItem 优先队列中元素的句柄,用于 Update 和 Remove
+type PQItem[T any] struct {
+	value T
+	index int // 在堆中的位置,-1表示已出队
+}
+
+// Value 返回句柄对应的值
+func (item *PQItem[T]) Value() T {
+	return item.value
+}
+
+type PriorityQueueOptions struct {
+	ThreadSafe bool // 是否线程安全,默认为false
+	Capacity   int  // 预分配的容量
+}
+
+type PriorityQueueOption func(opts *PriorityQueueOptions)
+
+func NewPriorityQueueOptions() *PriorityQueueOptions {
+	return &PriorityQueueOptions{}
+}
+
+// WithThreadSafe 设置优先队列是否线程安全
+func WithThreadSafe(threadSafe bool) PriorityQueueOption {
+	return func(opts *PriorityQueueOptions) {
+		opts.ThreadSafe = threadSafe
+	}
+}
+
+// WithCapacity 设置预分配的容量
+func WithCapacity(capacity int) PriorityQueueOption {
+	return func(opts *PriorityQueueOptions) {
+		opts.Capacity = capacity
+	}
+}
+
+// NewPriorityQueue 创建一个新的优先队列
+//
+// 参数说明:
+//   - less: 比较函数,less(a, b)为true时a先于b出队
+//   - opts: 可选配置项,如 WithThreadSafe、WithCapacity
+//
+// 返回值:
+//   - *PriorityQueue[T]: 新创建的优先队列
+//
+// 注意事项:
+//   - 默认不是线程安全的,多个goroutine共享时需要使用 WithThreadSafe(true)
+//   - Push/Pop/Update/Remove 的时间复杂度均为O(logn)
+//
+// 示例:
+//
+//	// 按截止时间排序的任务队列
+//	pq := NewPriorityQueue(func(a, b Job) bool {
+//	    return a.Deadline.Before(b.Deadline)
+//	}, WithThreadSafe(true))
+//	item := pq.Push(job)
+//	pq.Update(item, newJob) // 修改截止时间后重新调整位置
+//	next, _ := pq.Pop()
+func NewPriorityQueue[T any](less func(a, b T) bool, opts ...PriorityQueueOption) *PriorityQueue[T] {
+	options := NewPriorityQueueOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return &PriorityQueue[T]{
+		h: &pqHeap[T]{
+			items: make([]*PQItem[T], 0, max(options.Capacity, 0)),
+			less:  less,
+		},
+		opts: options,
+	}
+}
+
+// Push 加入一个元素
+// 返回:
+//   - *PQItem[T]: 元素的句柄,可用于 Update 和 Remove
+func (pq *PriorityQueue[T]) Push(v T) *PQItem[T] {
+	pq.lock()
+	defer pq.unlock()
+	item := &PQItem[T]{value: v}
+	heap.Push(pq.h, item)
+	return item
+}
+
+// Pop 取出优先级最高的元素
+// 返回:
+//   - T: 优先级最高的元素,队列为空时为零值
+//   - bool: 队列是否不为空
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	pq.lock()
+	defer pq.unlock()
+	if pq.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(pq.h).(*PQItem[T]).value, true
+}
+
+// Peek 查看优先级最高的元素但不取出
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	pq.lock()
+	defer pq.unlock()
+	if pq.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.h.items[0].value, true
+}
+
+// Update 更新句柄对应的值并重新调整其位置
+// 返回:
+//   - bool: 元素已出队或已删除时返回false
+func (pq *PriorityQueue[T]) Update(item *PQItem[T], v T) bool {
+	pq.lock()
+	defer pq.unlock()
+	if !pq.owns(item) {
+		return false
+	}
+	item.value = v
+	heap.Fix(pq.h, item.index)
+	return true
+}
+
+// Remove 删除句柄对应的元素
+// 返回:
+//   - bool: 元素已出队或已删除时返回false
+func (pq *PriorityQueue[T]) Remove(item *PQItem[T]) bool {
+	pq.lock()
+	defer pq.unlock()
+	if !pq.owns(item) {
+		return false
+	}
+	heap.Remove(pq.h, item.index)
+	return true
+}
+
+// Len 返回元素数量
+func (pq *PriorityQueue[T]) Len() int {
+	pq.lock()
+	defer pq.unlock()
+	return pq.h.Len()
+}
+
+// owns 判断句柄是否仍在当前队列中
+func (pq *PriorityQueue[T]) owns(item *PQItem[T]) bool {
+	return item != nil && item.index >= 0 && item.index < pq.h.Len() && pq.h.items[item.index] == item
+}
+
+func (pq *PriorityQueue[T]) lock() {
+	if pq.opts.ThreadSafe {
+		pq.mu.Lock()
+	}
+}
+
+func (pq *PriorityQueue[T]) unlock() {
+	if pq.opts.ThreadSafe {
+		pq.mu.Unlock()
+	}
+}
+
+// pqHeap 实现 heap.Interface
+type pqHeap[T any] struct {
+	items []*PQItem[T]
+	less  func(a, b T) bool
+}
+
+func (h *pqHeap[T]) Len() int { return len(h.items) }
+
+func (h *pqHeap[T]) Less(i, j int) bool { return h.less(h.items[i].value, h.items[j].value) }
+
+func (h *pqHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *pqHeap[T]) Push(x any) {
+	item := x.(*PQItem[T])
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+}
+
+func (h *pqHeap[T]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items[n-1] = nil // 避免内存泄漏
+	h.items = h.items[:n-1]
+	item.index = -1
+	return item
+}
@@ -0,0 +1,76 @@
+package kcollection
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	t.Run("按优先级出队", func(t *testing.T) {
+		pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+		for _, v := range []int{5, 1, 4, 2, 3} {
+			pq.Push(v)
+		}
+		assert.Equal(t, 5, pq.Len())
+		v, ok := pq.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+		for i := 1; i <= 5; i++ {
+			v, ok := pq.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+		_, ok = pq.Pop()
+		assert.False(t, ok)
+		_, ok = pq.Peek()
+		assert.False(t, ok)
+	})
+
+	t.Run("按截止时间调度", func(t *testing.T) {
+		type job struct {
+			name     string
+			deadline time.Time
+		}
+		now := time.Now()
+		pq := NewPriorityQueue(func(a, b job) bool {
+			return a.deadline.Before(b.deadline)
+		}, WithCapacity(4))
+		a := pq.Push(job{"a", now.Add(3 * time.Second)})
+		b := pq.Push(job{"b", now.Add(2 * time.Second)})
+		c := pq.Push(job{"c", now.Add(1 * time.Second)})
+
+		assert.True(t, pq.Update(a, job{"a", now}))
+		assert.Equal(t, "a", a.Value().name)
+		assert.True(t, pq.Remove(c))
+		assert.False(t, pq.Remove(c))
+
+		v, _ := pq.Pop()
+		assert.Equal(t, "a", v.name)
+		assert.False(t, pq.Update(a, job{"a", now}))
+		v, _ = pq.Pop()
+		assert.Equal(t, "b", v.name)
+		assert.False(t, pq.Remove(b))
+		assert.Equal(t, 0, pq.Len())
+	})
+
+	t.Run("线程安全", func(t *testing.T) {
+		pq := NewPriorityQueue(func(a, b int) bool { return a > b }, WithThreadSafe(true))
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					pq.Push(i*100 + j)
+				}
+			}(i)
+		}
+		wg.Wait()
+		assert.Equal(t, 1000, pq.Len())
+		v, _ := pq.Pop()
+		assert.Equal(t, 999, v)
+	})
+}
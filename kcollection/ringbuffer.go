@@ -0,0 +1,127 @@
+package kcollection
+
+import "sync"
+
+// RingBufferPolicy 环形缓冲区写满后的处理策略
+type RingBufferPolicy int
+
+const (
+	RingBufferOverwrite RingBufferPolicy = iota // 覆盖最旧的元素
+	RingBufferReject                            // 拒绝写入新元素
+)
+
+// RingBuffer 固定容量的环形缓冲区,常用于保存"最近N条日志/错误"
+type RingBuffer[T any] struct {
+	mu     sync.RWMutex
+	buf    []T
+	start  int // 最旧元素的位置
+	size   int // 当前元素数量
+	policy RingBufferPolicy
+}
+
+// NewRingBuffer 创建一个新的环形缓冲区
+//
+// 参数说明:
+//   - capacity: 容量,必须大于0
+//   - policy: 可选的写满策略,默认为 RingBufferOverwrite
+//
+// 返回值:
+//   - *RingBuffer[T]: 新创建的环形缓冲区
+//
+// 注意事项:
+//   - capacity小于1时会panic
+//   - 线程安全
+//
+// 示例:
+//
+//	rb := NewRingBuffer[string](3)
+//	for _, line := range []string{"a", "b", "c", "d"} {
+//	    rb.Push(line)
+//	}
+//	rb.Snapshot() // [b c d]
+func NewRingBuffer[T any](capacity int, policy ...RingBufferPolicy) *RingBuffer[T] {
+	if capacity < 1 {
+		panic("capacity must be greater than 0")
+	}
+	rb := &RingBuffer[T]{buf: make([]T, capacity)}
+	if len(policy) > 0 {
+		rb.policy = policy[0]
+	}
+	return rb
+}
+
+// Push 写入一个元素
+// 返回:
+//   - bool: 缓冲区已满且策略为 RingBufferReject 时返回false
+func (rb *RingBuffer[T]) Push(v T) bool {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if rb.size == len(rb.buf) {
+		if rb.policy == RingBufferReject {
+			return false
+		}
+		rb.buf[rb.start] = v
+		rb.start = (rb.start + 1) % len(rb.buf)
+		return true
+	}
+	rb.buf[(rb.start+rb.size)%len(rb.buf)] = v
+	rb.size++
+	return true
+}
+
+// Pop 取出最旧的元素
+// 返回:
+//   - T: 最旧的元素,缓冲区为空时为零值
+//   - bool: 缓冲区是否不为空
+func (rb *RingBuffer[T]) Pop() (T, bool) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	var zero T
+	if rb.size == 0 {
+		return zero, false
+	}
+	v := rb.buf[rb.start]
+	rb.buf[rb.start] = zero
+	rb.start = (rb.start + 1) % len(rb.buf)
+	rb.size--
+	return v, true
+}
+
+// Snapshot 按从旧到新的顺序返回当前所有元素的副本
+func (rb *RingBuffer[T]) Snapshot() []T {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+	result := make([]T, rb.size)
+	for i := 0; i < rb.size; i++ {
+		result[i] = rb.buf[(rb.start+i)%len(rb.buf)]
+	}
+	return result
+}
+
+// Len 返回当前元素数量
+func (rb *RingBuffer[T]) Len() int {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+	return rb.size
+}
+
+// Cap 返回容量
+func (rb *RingBuffer[T]) Cap() int {
+	return len(rb.buf)
+}
+
+// IsFull 判断缓冲区是否已满
+func (rb *RingBuffer[T]) IsFull() bool {
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+	return rb.size == len(rb.buf)
+}
+
+// Clear 清空缓冲区
+func (rb *RingBuffer[T]) Clear() {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	clear(rb.buf)
+	rb.start = 0
+	rb.size = 0
+}
@@ -0,0 +1,65 @@
+package kcollection
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBuffer(t *testing.T) {
+	t.Run("覆盖最旧元素", func(t *testing.T) {
+		rb := NewRingBuffer[string](3)
+		for _, s := range []string{"a", "b", "c", "d", "e"} {
+			assert.True(t, rb.Push(s))
+		}
+		assert.Equal(t, []string{"c", "d", "e"}, rb.Snapshot())
+		assert.True(t, rb.IsFull())
+		assert.Equal(t, 3, rb.Cap())
+
+		v, ok := rb.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, "c", v)
+		rb.Push("f")
+		assert.Equal(t, []string{"d", "e", "f"}, rb.Snapshot())
+	})
+
+	t.Run("写满拒绝", func(t *testing.T) {
+		rb := NewRingBuffer[int](2, RingBufferReject)
+		assert.True(t, rb.Push(1))
+		assert.True(t, rb.Push(2))
+		assert.False(t, rb.Push(3))
+		assert.Equal(t, []int{1, 2}, rb.Snapshot())
+	})
+
+	t.Run("清空", func(t *testing.T) {
+		rb := NewRingBuffer[int](2)
+		rb.Push(1)
+		rb.Clear()
+		assert.Equal(t, 0, rb.Len())
+		assert.Equal(t, []int{}, rb.Snapshot())
+		_, ok := rb.Pop()
+		assert.False(t, ok)
+	})
+
+	t.Run("非法容量", func(t *testing.T) {
+		assert.Panics(t, func() { NewRingBuffer[int](0) })
+	})
+
+	t.Run("并发", func(t *testing.T) {
+		rb := NewRingBuffer[int](10)
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					rb.Push(j)
+					rb.Snapshot()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 10, rb.Len())
+	})
+}
@@ -73,13 +73,80 @@ func NewRollingWindow[T kmath.Number, B BucketInterface[T]](newBucket func() B,
 		panic("size must be greater than 0")
 	}
 	w := &RollingWindow[T, B]{
-		win:      newWindow(newBucket, options.Size),
-		lastTime: ktime.Now(),
-		Opts:     options,
+		win:  newWindow(newBucket, options.Size),
+		Opts: options,
 	}
+	w.lastTime = w.alignedNow()
 	return w
 }
 
+// Resize 在运行时修改窗口大小(桶的数量)
+// 参数:
+//   - size: 新的窗口大小
+//
+// 注意:
+//   - size必须大于0,否则会panic
+//   - 扩大窗口时保留所有桶的数据,新增的桶位于最旧的一侧
+//   - 缩小窗口时保留最近的size个桶,丢弃更旧的桶
+func (rw *RollingWindow[T, B]) Resize(size int) {
+	if size < 1 {
+		panic("size must be greater than 0")
+	}
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	rw.updateOffset()
+	if size == rw.Opts.Size {
+		return
+	}
+	oldSize := rw.Opts.Size
+	buckets := make([]B, size)
+	// 从当前桶开始由新到旧复制,不足的部分使用新桶
+	for i := 0; i < size; i++ {
+		if i < oldSize {
+			buckets[size-1-i] = rw.win.buckets[(rw.offset-i+oldSize)%oldSize]
+		} else {
+			buckets[size-1-i] = rw.win.newBucket()
+		}
+	}
+	rw.win.buckets = buckets
+	rw.win.size = size
+	rw.offset = size - 1
+	rw.Opts.Size = size
+}
+
+// SetInterval 在运行时修改每个桶的时间间隔
+// 参数:
+//   - interval: 新的时间间隔
+//
+// 注意:
+//   - interval必须大于0,否则会panic
+//   - 已有桶的数据会保留,之后按新的时间间隔滚动
+//   - 当前桶的起始时间会按新的时间间隔重新对齐
+func (rw *RollingWindow[T, B]) SetInterval(interval time.Duration) {
+	if interval <= 0 {
+		panic("interval must be greater than 0")
+	}
+	rw.lock.Lock()
+	defer rw.lock.Unlock()
+	rw.updateOffset()
+	rw.Opts.Interval = interval
+	rw.lastTime = rw.alignedNow()
+}
+
+// Size 返回当前窗口大小
+func (rw *RollingWindow[T, B]) Size() int {
+	rw.lock.RLock()
+	defer rw.lock.RUnlock()
+	return rw.Opts.Size
+}
+
+// Interval 返回当前每个桶的时间间隔
+func (rw *RollingWindow[T, B]) Interval() time.Duration {
+	rw.lock.RLock()
+	defer rw.lock.RUnlock()
+	return rw.Opts.Interval
+}
+
 // Add 向当前桶中添加一个值
 // 参数:
 //   - v: 要添加的值
@@ -98,6 +165,19 @@ func (rw *RollingWindow[T, B]) Add(v T) {
 //   - 如果设置了ignoreCurrent为true,则不会处理当前桶
 //   - 遍历顺序为从旧到新,即最早的桶到最近的桶
 func (rw *RollingWindow[T, B]) Reduce(fn func(b B)) {
+	rw.ReduceWithAge(func(b B, _ int) {
+		fn(b)
+	})
+}
+
+// ReduceWithAge 同 Reduce,同时传入每个桶距离当前时间的间隔数
+// 参数:
+//   - fn: 处理每个桶的函数,age为0表示当前桶,1表示上一个时间间隔的桶,以此类推
+//
+// 注意:
+//   - 已过期的桶不会被遍历,age不一定连续,按时间范围统计时缺少的age应视为空桶
+//   - 遍历顺序为从旧到新,age从大到小
+func (rw *RollingWindow[T, B]) ReduceWithAge(fn func(b B, age int)) {
 	rw.lock.RLock()
 	defer rw.lock.RUnlock()
 
@@ -111,7 +191,10 @@ func (rw *RollingWindow[T, B]) Reduce(fn func(b B)) {
 	}
 	if diff > 0 {
 		offset := (rw.offset + span + 1) % rw.Opts.Size
-		rw.win.reduce(offset, diff, fn)
+		// 第i个有效的桶距离当前时间 Size-1-i 个间隔,与经过的间隔数无关
+		rw.win.reduce(offset, diff, func(b B, i int) {
+			fn(b, rw.Opts.Size-1-i)
+		})
 	}
 }
 
@@ -146,7 +229,7 @@ func (rw *RollingWindow[T, B]) GetLastValidBucket() (B, bool) {
 // 返回:
 //   - int: 经过的时间间隔数
 func (rw *RollingWindow[T, B]) span() int {
-	offset := int(ktime.Since(rw.lastTime) / rw.Opts.Interval)
+	offset := int((rw.now() - rw.lastTime) / rw.Opts.Interval)
 	if 0 <= offset && offset < rw.Opts.Size {
 		return offset
 	}
@@ -168,11 +251,26 @@ func (rw *RollingWindow[T, B]) updateOffset() {
 	}
 
 	rw.offset = (offset + span) % rw.Opts.Size
-	now := ktime.Now()
+	now := rw.now()
 
 	rw.lastTime = now - (now-rw.lastTime)%rw.Opts.Interval
 }
 
+// alignedNow 返回当前桶的起始时间
+// 开启 AlignWallClock 时对齐到墙上时钟的整数倍间隔,否则为当前时间
+func (rw *RollingWindow[T, B]) alignedNow() time.Duration {
+	now := rw.now()
+	if !rw.Opts.AlignWallClock {
+		return now
+	}
+	return now - time.Duration(rw.Opts.Clock.Now().UnixNano()%int64(rw.Opts.Interval))
+}
+
+// now 返回相对于系统启动时间的当前时间,参见 ktime.RelativeNow
+func (rw *RollingWindow[T, B]) now() time.Duration {
+	return ktime.RelativeNow(rw.Opts.Clock)
+}
+
 // Bucket 实现了BucketInterface接口的基础桶类型
 type Bucket[T kmath.Number] struct {
 	Sum   T     // 桶中所有值的和
@@ -193,8 +291,9 @@ func (b *Bucket[T]) Reset() {
 
 // window 窗口的内部实现
 type window[T kmath.Number, B BucketInterface[T]] struct {
-	buckets []B // 所有桶的切片
-	size    int // 窗口大小
+	buckets   []B      // 所有桶的切片
+	size      int      // 窗口大小
+	newBucket func() B // 创建新桶的函数,调整窗口大小时使用
 }
 
 // newWindow 创建一个新的窗口
@@ -204,8 +303,9 @@ func newWindow[T kmath.Number, B BucketInterface[T]](newBucket func() B, size in
 		buckets[i] = newBucket()
 	}
 	return &window[T, B]{
-		buckets: buckets,
-		size:    size,
+		buckets:   buckets,
+		size:      size,
+		newBucket: newBucket,
 	}
 }
 
@@ -214,10 +314,10 @@ func (w *window[T, B]) add(offset int, v T) {
 	w.buckets[offset%w.size].Add(v)
 }
 
-// reduce 从指定位置开始遍历指定数量的桶
-func (w *window[T, B]) reduce(start, count int, fn func(b B)) {
+// reduce 从指定位置开始遍历指定数量的桶,i为遍历的序号
+func (w *window[T, B]) reduce(start, count int, fn func(b B, i int)) {
 	for i := 0; i < count; i++ {
-		fn(w.buckets[(start+i)%w.size])
+		fn(w.buckets[(start+i)%w.size], i)
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mtgnorton/k/ktime"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -183,3 +184,156 @@ func TestRollingWindowDataRace(t *testing.T) {
 func elapse() {
 	time.Sleep(duration)
 }
+
+func TestRollingWindowResize(t *testing.T) {
+	newRW := func() *RollingWindow[float64, *Bucket[float64]] {
+		r := NewRollingWindow[float64, *Bucket[float64]](func() *Bucket[float64] {
+			return new(Bucket[float64])
+		}, WithSize[float64, *Bucket[float64]](3), WithInterval[float64, *Bucket[float64]](time.Hour))
+		return r
+	}
+	listBuckets := func(r *RollingWindow[float64, *Bucket[float64]]) []float64 {
+		var buckets []float64
+		r.Reduce(func(b *Bucket[float64]) {
+			buckets = append(buckets, b.Sum)
+		})
+		return buckets
+	}
+	// 直接构造各桶数据,避免依赖时间流逝
+	fill := func(r *RollingWindow[float64, *Bucket[float64]]) {
+		r.win.buckets[0].Sum = 1
+		r.win.buckets[1].Sum = 2
+		r.win.buckets[2].Sum = 3
+		r.offset = 1 // 当前桶为2,最旧的桶为3
+	}
+
+	t.Run("扩大窗口保留全部数据", func(t *testing.T) {
+		r := newRW()
+		fill(r)
+		assert.Equal(t, []float64{3, 1, 2}, listBuckets(r))
+		r.Resize(5)
+		assert.Equal(t, 5, r.Size())
+		assert.Equal(t, []float64{0, 0, 3, 1, 2}, listBuckets(r))
+		r.Add(4)
+		assert.Equal(t, []float64{0, 0, 3, 1, 6}, listBuckets(r))
+	})
+
+	t.Run("缩小窗口保留最近的桶", func(t *testing.T) {
+		r := newRW()
+		fill(r)
+		r.Resize(2)
+		assert.Equal(t, 2, r.Size())
+		assert.Equal(t, []float64{1, 2}, listBuckets(r))
+	})
+
+	t.Run("大小不变", func(t *testing.T) {
+		r := newRW()
+		fill(r)
+		r.Resize(3)
+		assert.Equal(t, []float64{3, 1, 2}, listBuckets(r))
+	})
+
+	t.Run("非法大小", func(t *testing.T) {
+		assert.Panics(t, func() { newRW().Resize(0) })
+	})
+}
+
+func TestRollingWindowSetInterval(t *testing.T) {
+	const size = 3
+	r := NewRollingWindow[float64, *Bucket[float64]](func() *Bucket[float64] {
+		return new(Bucket[float64])
+	}, WithSize[float64, *Bucket[float64]](size), WithInterval[float64, *Bucket[float64]](time.Hour))
+	r.Add(1)
+	r.SetInterval(duration)
+	assert.Equal(t, duration, r.Interval())
+
+	var sum float64
+	r.Reduce(func(b *Bucket[float64]) { sum += b.Sum })
+	assert.Equal(t, 1.0, sum, "修改间隔后应保留已有数据")
+
+	// 新的间隔生效后,数据会按新间隔滚出窗口
+	time.Sleep(duration * (size + 1))
+	sum = 0
+	r.Reduce(func(b *Bucket[float64]) { sum += b.Sum })
+	assert.Equal(t, 0.0, sum)
+
+	assert.Panics(t, func() { r.SetInterval(0) })
+}
+
+func TestRollingWindowAlignWallClock(t *testing.T) {
+	const interval = time.Minute
+	r := NewRollingWindow[float64, *Bucket[float64]](func() *Bucket[float64] {
+		return new(Bucket[float64])
+	}, WithInterval[float64, *Bucket[float64]](interval), WithAlignWallClock[float64, *Bucket[float64]](true))
+
+	// 当前桶的起始时间对应的墙上时钟应为整分
+	start := time.Now().Add(r.lastTime - ktime.Now())
+	offset := start.Sub(start.Truncate(interval))
+	if offset > interval/2 {
+		offset = interval - offset
+	}
+	assert.Less(t, offset, time.Millisecond*10)
+
+	r.SetInterval(time.Second)
+	start = time.Now().Add(r.lastTime - ktime.Now())
+	offset = start.Sub(start.Truncate(time.Second))
+	if offset > time.Second/2 {
+		offset = time.Second - offset
+	}
+	assert.Less(t, offset, time.Millisecond*10)
+}
+
+func TestRollingWindowFakeClock(t *testing.T) {
+	clock := ktime.NewFakeClock(time.Date(2024, 5, 15, 0, 0, 30, 0, time.UTC))
+	r := NewRollingWindow[float64, *Bucket[float64]](func() *Bucket[float64] {
+		return new(Bucket[float64])
+	}, WithSize[float64, *Bucket[float64]](3),
+		WithInterval[float64, *Bucket[float64]](time.Minute),
+		WithAlignWallClock[float64, *Bucket[float64]](true),
+		WithClock[float64, *Bucket[float64]](clock))
+	listBuckets := func() []float64 {
+		var buckets []float64
+		r.Reduce(func(b *Bucket[float64]) {
+			buckets = append(buckets, b.Sum)
+		})
+		return buckets
+	}
+
+	r.Add(1)
+	// 对齐到整分,30秒后进入下一个桶
+	clock.Advance(29 * time.Second)
+	r.Add(2)
+	assert.Equal(t, []float64{0, 0, 3}, listBuckets())
+	clock.Advance(time.Second)
+	r.Add(3)
+	assert.Equal(t, []float64{0, 3, 3}, listBuckets())
+	// 过期的桶不参与统计
+	clock.Advance(2 * time.Minute)
+	assert.Equal(t, []float64{3}, listBuckets())
+	clock.Advance(time.Hour)
+	assert.Nil(t, listBuckets())
+}
+
+func TestRollingWindowReduceWithAge(t *testing.T) {
+	clock := ktime.NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+	r := NewRollingWindow[float64, *Bucket[float64]](func() *Bucket[float64] {
+		return new(Bucket[float64])
+	}, WithSize[float64, *Bucket[float64]](4),
+		WithInterval[float64, *Bucket[float64]](time.Second),
+		WithClock[float64, *Bucket[float64]](clock))
+	listAges := func() map[int]float64 {
+		ages := make(map[int]float64)
+		r.ReduceWithAge(func(b *Bucket[float64], age int) {
+			ages[age] = b.Sum
+		})
+		return ages
+	}
+	r.Add(1)
+	clock.Advance(time.Second)
+	r.Add(2)
+	assert.Equal(t, map[int]float64{0: 2, 1: 1, 2: 0, 3: 0}, listAges())
+
+	// 经过2个间隔后没有新的数据,过期的桶不会被遍历
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, map[int]float64{2: 2, 3: 1}, listAges())
+}
@@ -0,0 +1,212 @@
+package kcollection
+
+import "sync"
+
+// Stack 后进先出的栈,支持可选的最大容量
+type Stack[T any] struct {
+	mu       sync.Mutex
+	items    []T
+	capacity int // 最大容量,0表示不限制
+}
+
+// NewStack 创建一个新的栈
+//
+// 参数说明:
+//   - capacity: 可选的最大容量,不传或小于等于0表示不限制
+//
+// 返回值:
+//   - *Stack[T]: 新创建的栈
+//
+// 注意事项:
+//   - 线程安全
+//
+// 示例:
+//
+//	s := NewStack[int](2)
+//	s.Push(1)
+//	s.Push(2)
+//	s.Push(3) // false,栈已满
+//	v, _ := s.Pop() // v = 2
+func NewStack[T any](capacity ...int) *Stack[T] {
+	s := &Stack[T]{}
+	if len(capacity) > 0 && capacity[0] > 0 {
+		s.capacity = capacity[0]
+	}
+	return s
+}
+
+// Push 将元素压入栈顶
+// 返回:
+//   - bool: 栈已满时返回false
+func (s *Stack[T]) Push(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.capacity > 0 && len(s.items) >= s.capacity {
+		return false
+	}
+	s.items = append(s.items, v)
+	return true
+}
+
+// Pop 弹出栈顶元素
+// 返回:
+//   - T: 栈顶元素,栈为空时为零值
+//   - bool: 栈是否不为空
+func (s *Stack[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var zero T
+	n := len(s.items)
+	if n == 0 {
+		return zero, false
+	}
+	v := s.items[n-1]
+	s.items[n-1] = zero // 避免内存泄漏
+	s.items = s.items[:n-1]
+	return v, true
+}
+
+// Peek 查看栈顶元素但不弹出
+func (s *Stack[T]) Peek() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Len 返回元素数量
+func (s *Stack[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// IsFull 判断栈是否已满,不限制容量时始终返回false
+func (s *Stack[T]) IsFull() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.capacity > 0 && len(s.items) >= s.capacity
+}
+
+// Clear 清空栈
+func (s *Stack[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.items)
+	s.items = s.items[:0]
+}
+
+// Queue 先进先出的队列,支持可选的最大容量
+type Queue[T any] struct {
+	mu       sync.Mutex
+	items    []T
+	head     int // 队首元素在items中的位置
+	capacity int // 最大容量,0表示不限制
+}
+
+// NewQueue 创建一个新的队列
+//
+// 参数说明:
+//   - capacity: 可选的最大容量,不传或小于等于0表示不限制
+//
+// 返回值:
+//   - *Queue[T]: 新创建的队列
+//
+// 注意事项:
+//   - 线程安全
+//   - 出队不会立即释放底层数组,已出队部分超过一半时才会整理
+//   - 需要阻塞等待的生产者消费者场景请使用 BlockingQueue
+//
+// 示例:
+//
+//	q := NewQueue[string]()
+//	q.Push("a")
+//	q.Push("b")
+//	v, _ := q.Pop() // v = "a"
+func NewQueue[T any](capacity ...int) *Queue[T] {
+	q := &Queue[T]{}
+	if len(capacity) > 0 && capacity[0] > 0 {
+		q.capacity = capacity[0]
+	}
+	return q
+}
+
+// Push 将元素加入队尾
+// 返回:
+//   - bool: 队列已满时返回false
+func (q *Queue[T]) Push(v T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.capacity > 0 && q.len() >= q.capacity {
+		return false
+	}
+	q.items = append(q.items, v)
+	return true
+}
+
+// Pop 取出队首元素
+// 返回:
+//   - T: 队首元素,队列为空时为零值
+//   - bool: 队列是否不为空
+func (q *Queue[T]) Pop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if q.len() == 0 {
+		return zero, false
+	}
+	v := q.items[q.head]
+	q.items[q.head] = zero // 避免内存泄漏
+	q.head++
+	if q.head == len(q.items) {
+		q.items = q.items[:0]
+		q.head = 0
+	} else if q.head > len(q.items)/2 {
+		n := copy(q.items, q.items[q.head:])
+		clear(q.items[n:])
+		q.items = q.items[:n]
+		q.head = 0
+	}
+	return v, true
+}
+
+// Peek 查看队首元素但不取出
+func (q *Queue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[q.head], true
+}
+
+// Len 返回元素数量
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.len()
+}
+
+// IsFull 判断队列是否已满,不限制容量时始终返回false
+func (q *Queue[T]) IsFull() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.capacity > 0 && q.len() >= q.capacity
+}
+
+// Clear 清空队列
+func (q *Queue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	clear(q.items)
+	q.items = q.items[:0]
+	q.head = 0
+}
+
+func (q *Queue[T]) len() int {
+	return len(q.items) - q.head
+}
@@ -0,0 +1,77 @@
+package kcollection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStack(t *testing.T) {
+	t.Run("后进先出", func(t *testing.T) {
+		s := NewStack[int]()
+		s.Push(1)
+		s.Push(2)
+		s.Push(3)
+		assert.Equal(t, 3, s.Len())
+		v, ok := s.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, 3, v)
+		for _, expected := range []int{3, 2, 1} {
+			v, ok := s.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, expected, v)
+		}
+		_, ok = s.Pop()
+		assert.False(t, ok)
+		_, ok = s.Peek()
+		assert.False(t, ok)
+	})
+
+	t.Run("容量限制", func(t *testing.T) {
+		s := NewStack[int](2)
+		assert.True(t, s.Push(1))
+		assert.True(t, s.Push(2))
+		assert.True(t, s.IsFull())
+		assert.False(t, s.Push(3))
+		s.Pop()
+		assert.True(t, s.Push(3))
+		s.Clear()
+		assert.Equal(t, 0, s.Len())
+	})
+}
+
+func TestQueue(t *testing.T) {
+	t.Run("先进先出", func(t *testing.T) {
+		q := NewQueue[int]()
+		for i := 0; i < 100; i++ {
+			q.Push(i)
+		}
+		for i := 0; i < 60; i++ {
+			v, ok := q.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+		q.Push(100)
+		assert.Equal(t, 41, q.Len())
+		v, _ := q.Peek()
+		assert.Equal(t, 60, v)
+		for i := 60; i <= 100; i++ {
+			v, ok := q.Pop()
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+		_, ok := q.Pop()
+		assert.False(t, ok)
+	})
+
+	t.Run("容量限制", func(t *testing.T) {
+		q := NewQueue[string](1)
+		assert.True(t, q.Push("a"))
+		assert.False(t, q.Push("b"))
+		assert.True(t, q.IsFull())
+		q.Clear()
+		assert.True(t, q.Push("b"))
+		v, _ := q.Pop()
+		assert.Equal(t, "b", v)
+	})
+}
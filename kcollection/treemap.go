@@ -0,0 +1,382 @@
+package kcollection
+
+import (
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// TreeMap 按key排序的map,基于左倾红黑树实现
+// 与普通map相比,支持有序遍历、Floor/Ceiling查找和范围扫描
+type TreeMap[K constraints.Ordered, V any] struct {
+	mu   sync.RWMutex
+	root *rbNode[K, V]
+	size int
+}
+
+type rbNode[K constraints.Ordered, V any] struct {
+	key         K
+	value       V
+	left, right *rbNode[K, V]
+	red         bool
+}
+
+// NewTreeMap 创建一个新的有序map
+//
+// 注意事项:
+//   - Put/Get/Delete/Floor/Ceiling 的时间复杂度均为O(logn)
+//   - 线程安全,遍历期间持有读锁,不能在回调中修改TreeMap
+//
+// 示例:
+//
+//	m := NewTreeMap[int, string]()
+//	m.Put(10, "a")
+//	m.Put(20, "b")
+//	m.Put(30, "c")
+//	k, v, ok := m.Floor(25) // k = 20, v = "b", ok = true
+//	m.Range(15, 30, func(k int, v string) bool {
+//	    fmt.Println(k, v) // 20 b, 30 c
+//	    return true
+//	})
+func NewTreeMap[K constraints.Ordered, V any]() *TreeMap[K, V] {
+	return &TreeMap[K, V]{}
+}
+
+// Put 写入键值对,key已存在时覆盖原值
+// 返回:
+//   - bool: 是否为新插入的key
+func (m *TreeMap[K, V]) Put(key K, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var inserted bool
+	m.root = m.root.put(key, value, &inserted)
+	m.root.red = false
+	if inserted {
+		m.size++
+	}
+	return inserted
+}
+
+// Get 获取key对应的值
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if n := m.root.find(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains 判断key是否存在
+func (m *TreeMap[K, V]) Contains(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.root.find(key) != nil
+}
+
+// Delete 删除key
+// 返回:
+//   - bool: key是否存在
+func (m *TreeMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.root.find(key) == nil {
+		return false
+	}
+	if !isRed(m.root.left) && !isRed(m.root.right) {
+		m.root.red = true
+	}
+	m.root = m.root.delete(key)
+	if m.root != nil {
+		m.root.red = false
+	}
+	m.size--
+	return true
+}
+
+// Len 返回键值对数量
+func (m *TreeMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size
+}
+
+// Min 返回最小的key及其值
+func (m *TreeMap[K, V]) Min() (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.root == nil {
+		return zeroEntry[K, V]()
+	}
+	n := m.root.min()
+	return n.key, n.value, true
+}
+
+// Max 返回最大的key及其值
+func (m *TreeMap[K, V]) Max() (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.root == nil {
+		return zeroEntry[K, V]()
+	}
+	n := m.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+// Floor 返回小于等于key的最大key及其值
+func (m *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var found *rbNode[K, V]
+	for n := m.root; n != nil; {
+		switch {
+		case key == n.key:
+			return n.key, n.value, true
+		case key < n.key:
+			n = n.left
+		default:
+			found = n
+			n = n.right
+		}
+	}
+	if found == nil {
+		return zeroEntry[K, V]()
+	}
+	return found.key, found.value, true
+}
+
+// Ceiling 返回大于等于key的最小key及其值
+func (m *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var found *rbNode[K, V]
+	for n := m.root; n != nil; {
+		switch {
+		case key == n.key:
+			return n.key, n.value, true
+		case key > n.key:
+			n = n.right
+		default:
+			found = n
+			n = n.left
+		}
+	}
+	if found == nil {
+		return zeroEntry[K, V]()
+	}
+	return found.key, found.value, true
+}
+
+// Range 按key升序遍历[from, to]闭区间内的键值对
+// 参数:
+//   - from: 起始key(包含)
+//   - to: 结束key(包含)
+//   - fn: 遍历回调,返回false时停止遍历
+func (m *TreeMap[K, V]) Range(from, to K, fn func(key K, value V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.root.rangeAsc(&from, &to, fn)
+}
+
+// Ascend 按key升序遍历所有键值对,fn返回false时停止遍历
+func (m *TreeMap[K, V]) Ascend(fn func(key K, value V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.root.rangeAsc(nil, nil, fn)
+}
+
+// Descend 按key降序遍历所有键值对,fn返回false时停止遍历
+func (m *TreeMap[K, V]) Descend(fn func(key K, value V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.root.descend(fn)
+}
+
+// Keys 按升序返回所有key
+func (m *TreeMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := make([]K, 0, m.size)
+	m.root.rangeAsc(nil, nil, func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+func zeroEntry[K constraints.Ordered, V any]() (K, V, bool) {
+	var (
+		k K
+		v V
+	)
+	return k, v, false
+}
+
+func isRed[K constraints.Ordered, V any](n *rbNode[K, V]) bool {
+	return n != nil && n.red
+}
+
+func (n *rbNode[K, V]) find(key K) *rbNode[K, V] {
+	for n != nil {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+func (n *rbNode[K, V]) min() *rbNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func (n *rbNode[K, V]) put(key K, value V, inserted *bool) *rbNode[K, V] {
+	if n == nil {
+		*inserted = true
+		return &rbNode[K, V]{key: key, value: value, red: true}
+	}
+	switch {
+	case key < n.key:
+		n.left = n.left.put(key, value, inserted)
+	case key > n.key:
+		n.right = n.right.put(key, value, inserted)
+	default:
+		n.value = value
+	}
+	return n.balance()
+}
+
+// delete 删除key,调用方需保证key存在
+func (n *rbNode[K, V]) delete(key K) *rbNode[K, V] {
+	if key < n.key {
+		if !isRed(n.left) && !isRed(n.left.left) {
+			n = n.moveRedLeft()
+		}
+		n.left = n.left.delete(key)
+	} else {
+		if isRed(n.left) {
+			n = n.rotateRight()
+		}
+		if key == n.key && n.right == nil {
+			return nil
+		}
+		if !isRed(n.right) && !isRed(n.right.left) {
+			n = n.moveRedRight()
+		}
+		if key == n.key {
+			m := n.right.min()
+			n.key, n.value = m.key, m.value
+			n.right = n.right.deleteMin()
+		} else {
+			n.right = n.right.delete(key)
+		}
+	}
+	return n.balance()
+}
+
+func (n *rbNode[K, V]) deleteMin() *rbNode[K, V] {
+	if n.left == nil {
+		return nil
+	}
+	if !isRed(n.left) && !isRed(n.left.left) {
+		n = n.moveRedLeft()
+	}
+	n.left = n.left.deleteMin()
+	return n.balance()
+}
+
+func (n *rbNode[K, V]) rotateLeft() *rbNode[K, V] {
+	x := n.right
+	n.right = x.left
+	x.left = n
+	x.red = n.red
+	n.red = true
+	return x
+}
+
+func (n *rbNode[K, V]) rotateRight() *rbNode[K, V] {
+	x := n.left
+	n.left = x.right
+	x.right = n
+	x.red = n.red
+	n.red = true
+	return x
+}
+
+func (n *rbNode[K, V]) flipColors() {
+	n.red = !n.red
+	n.left.red = !n.left.red
+	n.right.red = !n.right.red
+}
+
+func (n *rbNode[K, V]) moveRedLeft() *rbNode[K, V] {
+	n.flipColors()
+	if isRed(n.right.left) {
+		n.right = n.right.rotateRight()
+		n = n.rotateLeft()
+		n.flipColors()
+	}
+	return n
+}
+
+func (n *rbNode[K, V]) moveRedRight() *rbNode[K, V] {
+	n.flipColors()
+	if isRed(n.left.left) {
+		n = n.rotateRight()
+		n.flipColors()
+	}
+	return n
+}
+
+// balance 恢复左倾红黑树的性质
+func (n *rbNode[K, V]) balance() *rbNode[K, V] {
+	if isRed(n.right) && !isRed(n.left) {
+		n = n.rotateLeft()
+	}
+	if isRed(n.left) && isRed(n.left.left) {
+		n = n.rotateRight()
+	}
+	if isRed(n.left) && isRed(n.right) {
+		n.flipColors()
+	}
+	return n
+}
+
+// rangeAsc 升序遍历,from/to为nil表示不限制,返回false表示停止遍历
+func (n *rbNode[K, V]) rangeAsc(from, to *K, fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if from == nil || *from < n.key {
+		if !n.left.rangeAsc(from, to, fn) {
+			return false
+		}
+	}
+	if (from == nil || *from <= n.key) && (to == nil || n.key <= *to) {
+		if !fn(n.key, n.value) {
+			return false
+		}
+	}
+	if to == nil || n.key < *to {
+		return n.right.rangeAsc(from, to, fn)
+	}
+	return true
+}
+
+func (n *rbNode[K, V]) descend(fn func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.right.descend(fn) && fn(n.key, n.value) && n.left.descend(fn)
+}
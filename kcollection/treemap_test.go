@@ -0,0 +1,139 @@
+package kcollection
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeMap(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		m := NewTreeMap[int, string]()
+		assert.True(t, m.Put(20, "b"))
+		assert.True(t, m.Put(10, "a"))
+		assert.True(t, m.Put(30, "c"))
+		assert.False(t, m.Put(30, "cc"))
+		assert.Equal(t, 3, m.Len())
+
+		v, ok := m.Get(30)
+		assert.True(t, ok)
+		assert.Equal(t, "cc", v)
+		assert.False(t, m.Contains(40))
+		assert.Equal(t, []int{10, 20, 30}, m.Keys())
+
+		k, _, ok := m.Min()
+		assert.True(t, ok)
+		assert.Equal(t, 10, k)
+		k, _, ok = m.Max()
+		assert.True(t, ok)
+		assert.Equal(t, 30, k)
+
+		assert.True(t, m.Delete(20))
+		assert.False(t, m.Delete(20))
+		assert.Equal(t, []int{10, 30}, m.Keys())
+	})
+
+	t.Run("Floor和Ceiling", func(t *testing.T) {
+		m := NewTreeMap[int, string]()
+		for _, k := range []int{10, 20, 30} {
+			m.Put(k, "")
+		}
+		k, _, ok := m.Floor(25)
+		assert.True(t, ok)
+		assert.Equal(t, 20, k)
+		k, _, _ = m.Floor(20)
+		assert.Equal(t, 20, k)
+		_, _, ok = m.Floor(5)
+		assert.False(t, ok)
+
+		k, _, ok = m.Ceiling(25)
+		assert.True(t, ok)
+		assert.Equal(t, 30, k)
+		_, _, ok = m.Ceiling(35)
+		assert.False(t, ok)
+	})
+
+	t.Run("范围扫描", func(t *testing.T) {
+		m := NewTreeMap[string, int]()
+		for i, k := range []string{"a", "b", "c", "d", "e"} {
+			m.Put(k, i)
+		}
+		var keys []string
+		m.Range("b", "d", func(k string, v int) bool {
+			keys = append(keys, k)
+			return true
+		})
+		assert.Equal(t, []string{"b", "c", "d"}, keys)
+
+		keys = nil
+		m.Ascend(func(k string, v int) bool {
+			keys = append(keys, k)
+			return k < "b"
+		})
+		assert.Equal(t, []string{"a", "b"}, keys)
+
+		keys = nil
+		m.Descend(func(k string, v int) bool {
+			keys = append(keys, k)
+			return true
+		})
+		assert.Equal(t, []string{"e", "d", "c", "b", "a"}, keys)
+	})
+
+	t.Run("空map", func(t *testing.T) {
+		m := NewTreeMap[int, int]()
+		_, _, ok := m.Min()
+		assert.False(t, ok)
+		_, _, ok = m.Max()
+		assert.False(t, ok)
+		assert.False(t, m.Delete(1))
+		assert.Equal(t, []int{}, m.Keys())
+	})
+
+	t.Run("随机操作与map一致", func(t *testing.T) {
+		m := NewTreeMap[int, int]()
+		expected := make(map[int]int)
+		for i := 0; i < 5000; i++ {
+			k := rand.Intn(500)
+			if rand.Intn(3) == 0 {
+				_, exists := expected[k]
+				assert.Equal(t, exists, m.Delete(k))
+				delete(expected, k)
+			} else {
+				m.Put(k, i)
+				expected[k] = i
+			}
+		}
+		assert.Equal(t, len(expected), m.Len())
+		keys := make([]int, 0, len(expected))
+		for k := range expected {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		assert.Equal(t, keys, m.Keys())
+		for k, v := range expected {
+			got, ok := m.Get(k)
+			assert.True(t, ok)
+			assert.Equal(t, v, got)
+		}
+		assertRBInvariant(t, m.root)
+	})
+}
+
+// assertRBInvariant 校验左倾红黑树的性质,返回黑高
+func assertRBInvariant[V any](t *testing.T, n *rbNode[int, V]) int {
+	if n == nil {
+		return 1
+	}
+	assert.False(t, isRed(n.right), "右链接不能为红色")
+	assert.False(t, isRed(n) && isRed(n.left), "不能有连续的红链接")
+	lh := assertRBInvariant(t, n.left)
+	rh := assertRBInvariant(t, n.right)
+	assert.Equal(t, lh, rh, "黑高必须平衡")
+	if n.red {
+		return lh
+	}
+	return lh + 1
+}
@@ -17,7 +17,7 @@ import (
 //
 // 注意事项:
 //   - 仅支持可比较类型的key
-//   - 对于值为引用类型的情况,只会复制引用而不是深度复制
+//   - 对于值为引用类型的情况,只会复制引用而不是深度复制,需要深拷贝时使用 kreflect.DeepCopy
 //
 // 示例:
 //
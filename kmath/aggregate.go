@@ -0,0 +1,158 @@
+package kmath
+
+import (
+	"math"
+	"slices"
+)
+
+// Sum 返回切片中所有元素的和
+//
+// 参数说明:
+//   - s: 需要求和的切片
+//
+// 返回值:
+//   - 所有元素的和,空切片返回0
+//
+// 注意事项:
+//   - 结果类型与元素类型一致,整数类型求和时可能溢出
+//
+// 示例:
+//
+//	sum := Sum([]int{1, 2, 3})
+//	// sum = 6
+func Sum[T Number](s []T) T {
+	var sum T
+	for _, v := range s {
+		sum += v
+	}
+	return sum
+}
+
+// Avg 返回切片中所有元素的平均值
+//
+// 参数说明:
+//   - s: 需要求平均值的切片
+//
+// 返回值:
+//   - 平均值,空切片返回0
+//
+// 注意事项:
+//   - 先转换为float64再累加,避免整数求和溢出
+//
+// 示例:
+//
+//	avg := Avg([]int{1, 2, 3, 4})
+//	// avg = 2.5
+func Avg[T Number](s []T) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range s {
+		sum += float64(v)
+	}
+	return sum / float64(len(s))
+}
+
+// Median 返回切片的中位数
+//
+// 参数说明:
+//   - s: 需要求中位数的切片
+//
+// 返回值:
+//   - 中位数,元素个数为偶数时返回中间两个数的平均值,空切片返回0
+//
+// 注意事项:
+//   - 不会修改原切片
+//
+// 示例:
+//
+//	median := Median([]int{3, 1, 2})
+//	// median = 2
+//
+//	median := Median([]int{4, 1, 3, 2})
+//	// median = 2.5
+func Median[T Number](s []T) float64 {
+	return Percentile(s, 50)
+}
+
+// Mode 返回切片中出现次数最多的元素(众数)
+//
+// 参数说明:
+//   - s: 需要求众数的切片
+//
+// 返回值:
+//   - 众数,空切片返回零值
+//
+// 注意事项:
+//   - 出现次数相同时,返回最先出现的元素
+//
+// 示例:
+//
+//	mode := Mode([]int{1, 2, 2, 3})
+//	// mode = 2
+func Mode[T Number](s []T) T {
+	var (
+		mode     T
+		maxCount int
+		counts   = make(map[T]int, len(s))
+	)
+	for _, v := range s {
+		counts[v]++
+	}
+	for _, v := range s {
+		if counts[v] > maxCount {
+			maxCount = counts[v]
+			mode = v
+		}
+	}
+	return mode
+}
+
+// Percentile 返回切片的第p百分位数
+//
+// 参数说明:
+//   - s: 需要计算百分位数的切片
+//   - p: 百分位,范围为0到100
+//
+// 返回值:
+//   - 第p百分位数,空切片返回0
+//
+// 注意事项:
+//   - 不会修改原切片
+//   - 采用线性插值法,p落在两个元素之间时按比例插值
+//   - p小于0按0处理,大于100按100处理,为NaN时返回NaN
+//
+// 示例:
+//
+//	p := Percentile([]int{1, 2, 3, 4, 5}, 90)
+//	// p = 4.6
+func Percentile[T Number](s []T, p float64) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	return percentileSorted(sorted, p)
+}
+
+// percentileSorted 对已排序的切片计算百分位数
+func percentileSorted[T Number](sorted []T, p float64) float64 {
+	if math.IsNaN(p) {
+		return math.NaN()
+	}
+	if p <= 0 {
+		return float64(sorted[0])
+	}
+	if p >= 100 {
+		return float64(sorted[len(sorted)-1])
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return float64(sorted[lower])
+	}
+	frac := rank - float64(lower)
+	return float64(sorted[lower]) + frac*(float64(sorted[upper])-float64(sorted[lower]))
+}
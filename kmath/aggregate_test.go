@@ -0,0 +1,51 @@
+package kmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum(t *testing.T) {
+	assert.Equal(t, 6, Sum([]int{1, 2, 3}))
+	assert.InDelta(t, 3.3, Sum([]float64{1.1, 2.2}), 1e-9)
+	assert.Equal(t, 0, Sum([]int{}))
+}
+
+func TestAvg(t *testing.T) {
+	assert.Equal(t, 2.5, Avg([]int{1, 2, 3, 4}))
+	assert.Equal(t, 0.0, Avg([]int(nil)))
+}
+
+func TestMedian(t *testing.T) {
+	t.Run("奇数个元素", func(t *testing.T) {
+		s := []int{3, 1, 2}
+		assert.Equal(t, 2.0, Median(s))
+		assert.Equal(t, []int{3, 1, 2}, s) // 原切片不变
+	})
+	t.Run("偶数个元素", func(t *testing.T) {
+		assert.Equal(t, 2.5, Median([]int{4, 1, 3, 2}))
+	})
+	t.Run("空切片", func(t *testing.T) {
+		assert.Equal(t, 0.0, Median([]int{}))
+	})
+}
+
+func TestMode(t *testing.T) {
+	assert.Equal(t, 2, Mode([]int{1, 2, 2, 3}))
+	assert.Equal(t, 3, Mode([]int{3, 1, 1, 3}))
+	assert.Equal(t, 0, Mode([]int{}))
+}
+
+func TestPercentile(t *testing.T) {
+	s := []int{5, 1, 4, 2, 3}
+	assert.Equal(t, 1.0, Percentile(s, 0))
+	assert.Equal(t, 3.0, Percentile(s, 50))
+	assert.InDelta(t, 4.6, Percentile(s, 90), 1e-9)
+	assert.Equal(t, 5.0, Percentile(s, 100))
+	assert.Equal(t, 5.0, Percentile(s, 150))
+	assert.Equal(t, 1.0, Percentile(s, -1))
+	assert.Equal(t, 0.0, Percentile([]int{}, 50))
+	assert.True(t, math.IsNaN(Percentile(s, math.NaN())))
+}
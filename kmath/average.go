@@ -0,0 +1,170 @@
+package kmath
+
+import "sync"
+
+// MovingAverage 简单移动平均计算器,计算最近window个样本的平均值
+// 与 kcollection.RollingWindow 按时间分桶不同,MovingAverage 按样本个数滑动
+type MovingAverage[T Number] struct {
+	mu      sync.Mutex
+	samples []T     // 环形缓冲区
+	next    int     // 下一个写入位置
+	count   int     // 已写入的样本数量,最大为窗口大小
+	sum     float64 // 当前窗口内样本的和
+}
+
+// NewMovingAverage 创建一个新的移动平均计算器
+//
+// 参数说明:
+//   - window: 窗口大小,即参与平均的最近样本数量
+//
+// 返回值:
+//   - *MovingAverage[T]: 新创建的移动平均计算器
+//
+// 注意事项:
+//   - window必须大于0,否则会panic
+//   - 线程安全
+//
+// 示例:
+//
+//	ma := NewMovingAverage[int](3)
+//	ma.Add(1)
+//	ma.Add(2)
+//	ma.Add(3)
+//	ma.Add(4)
+//	// ma.Value() = 3
+func NewMovingAverage[T Number](window int) *MovingAverage[T] {
+	if window < 1 {
+		panic("window must be greater than 0")
+	}
+	return &MovingAverage[T]{
+		samples: make([]T, window),
+	}
+}
+
+// Add 添加一个样本,窗口已满时淘汰最旧的样本
+// 参数:
+//   - v: 样本值
+func (m *MovingAverage[T]) Add(v T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.count == len(m.samples) {
+		m.sum -= float64(m.samples[m.next])
+	} else {
+		m.count++
+	}
+	m.samples[m.next] = v
+	m.sum += float64(v)
+	m.next = (m.next + 1) % len(m.samples)
+}
+
+// Value 返回当前窗口内样本的平均值
+// 返回:
+//   - float64: 平均值,没有样本时返回0
+func (m *MovingAverage[T]) Value() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.count == 0 {
+		return 0
+	}
+	return m.sum / float64(m.count)
+}
+
+// Count 返回当前窗口内的样本数量
+// 返回:
+//   - int: 样本数量,不超过窗口大小
+func (m *MovingAverage[T]) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.count
+}
+
+// Reset 清空所有样本
+func (m *MovingAverage[T]) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.samples)
+	m.next = 0
+	m.count = 0
+	m.sum = 0
+}
+
+// EMA 指数移动平均计算器
+// 新值 = alpha*样本 + (1-alpha)*旧值,alpha越大对新样本越敏感
+type EMA[T Number] struct {
+	mu    sync.Mutex
+	alpha float64 // 平滑系数
+	value float64 // 当前平均值
+	init  bool    // 是否已经有样本
+}
+
+// NewEMA 创建一个新的指数移动平均计算器
+//
+// 参数说明:
+//   - alpha: 平滑系数,范围为(0, 1]
+//
+// 返回值:
+//   - *EMA[T]: 新创建的指数移动平均计算器
+//
+// 注意事项:
+//   - alpha不在(0, 1]范围内会panic
+//   - 第一个样本直接作为初始值
+//   - 线程安全
+//
+// 示例:
+//
+//	ema := NewEMA[float64](0.5)
+//	ema.Add(10)
+//	ema.Add(20)
+//	// ema.Value() = 15
+func NewEMA[T Number](alpha float64) *EMA[T] {
+	if alpha <= 0 || alpha > 1 {
+		panic("alpha must be in (0, 1]")
+	}
+	return &EMA[T]{alpha: alpha}
+}
+
+// NewEMAWithWindow 根据等效窗口大小创建指数移动平均计算器,alpha = 2/(window+1)
+//
+// 参数说明:
+//   - window: 等效窗口大小,必须大于0,否则会panic
+//
+// 示例:
+//
+//	ema := NewEMAWithWindow[int64](9) // alpha = 0.2
+func NewEMAWithWindow[T Number](window int) *EMA[T] {
+	if window < 1 {
+		panic("window must be greater than 0")
+	}
+	return NewEMA[T](2 / (float64(window) + 1))
+}
+
+// Add 添加一个样本
+// 参数:
+//   - v: 样本值
+func (e *EMA[T]) Add(v T) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.init {
+		e.value = float64(v)
+		e.init = true
+		return
+	}
+	e.value = e.alpha*float64(v) + (1-e.alpha)*e.value
+}
+
+// Value 返回当前的指数移动平均值
+// 返回:
+//   - float64: 平均值,没有样本时返回0
+func (e *EMA[T]) Value() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.value
+}
+
+// Reset 清空当前平均值
+func (e *EMA[T]) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.value = 0
+	e.init = false
+}
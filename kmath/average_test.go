@@ -0,0 +1,74 @@
+package kmath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovingAverage(t *testing.T) {
+	t.Run("窗口未满", func(t *testing.T) {
+		ma := NewMovingAverage[int](3)
+		assert.Equal(t, 0.0, ma.Value())
+		ma.Add(1)
+		ma.Add(2)
+		assert.Equal(t, 1.5, ma.Value())
+		assert.Equal(t, 2, ma.Count())
+	})
+
+	t.Run("窗口滑动", func(t *testing.T) {
+		ma := NewMovingAverage[int](3)
+		for _, v := range []int{1, 2, 3, 4, 5} {
+			ma.Add(v)
+		}
+		assert.Equal(t, 4.0, ma.Value())
+		assert.Equal(t, 3, ma.Count())
+	})
+
+	t.Run("重置", func(t *testing.T) {
+		ma := NewMovingAverage[float64](2)
+		ma.Add(1)
+		ma.Reset()
+		assert.Equal(t, 0.0, ma.Value())
+		ma.Add(3)
+		assert.Equal(t, 3.0, ma.Value())
+	})
+
+	t.Run("非法窗口", func(t *testing.T) {
+		assert.Panics(t, func() { NewMovingAverage[int](0) })
+	})
+}
+
+func TestEMA(t *testing.T) {
+	t.Run("平滑计算", func(t *testing.T) {
+		ema := NewEMA[float64](0.5)
+		assert.Equal(t, 0.0, ema.Value())
+		ema.Add(10)
+		assert.Equal(t, 10.0, ema.Value())
+		ema.Add(20)
+		assert.Equal(t, 15.0, ema.Value())
+		ema.Add(20)
+		assert.Equal(t, 17.5, ema.Value())
+	})
+
+	t.Run("按窗口创建", func(t *testing.T) {
+		ema := NewEMAWithWindow[int](9)
+		ema.Add(0)
+		ema.Add(10)
+		assert.InDelta(t, 2.0, ema.Value(), 1e-9)
+	})
+
+	t.Run("重置", func(t *testing.T) {
+		ema := NewEMA[int](0.5)
+		ema.Add(10)
+		ema.Reset()
+		ema.Add(4)
+		assert.Equal(t, 4.0, ema.Value())
+	})
+
+	t.Run("非法参数", func(t *testing.T) {
+		assert.Panics(t, func() { NewEMA[int](0) })
+		assert.Panics(t, func() { NewEMA[int](1.5) })
+		assert.Panics(t, func() { NewEMAWithWindow[int](0) })
+	})
+}
@@ -0,0 +1,133 @@
+package kmath
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrDivisionByZero = errors.New("division by zero")
+	ErrOverflow       = errors.New("result overflows")
+)
+
+// RoundingMode 舍入方式
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // 四舍五入,0.5远离0舍入
+	RoundHalfEven                     // 银行家舍入,0.5向偶数舍入
+	RoundDown                         // 向0截断
+	RoundUp                           // 远离0进位
+	RoundFloor                        // 向负无穷舍入
+	RoundCeil                         // 向正无穷舍入
+)
+
+// BigSum 使用高精度有理数对切片求和
+//
+// 参数说明:
+//   - s: 需要求和的切片
+//
+// 返回值:
+//   - *big.Rat: 精确的和,不会溢出也不会丢失精度
+//
+// 注意事项:
+//   - 浮点数会按其二进制值精确转换,NaN和Inf会panic
+//   - 可通过 Rat.Float64()、Rat.FloatString(n) 等方法转换结果
+//
+// 示例:
+//
+//	sum := BigSum([]int64{math.MaxInt64, math.MaxInt64})
+//	// sum.String() = "18446744073709551614/1"
+func BigSum[T Number](s []T) *big.Rat {
+	sum := new(big.Rat)
+	for _, v := range s {
+		sum.Add(sum, ToBigRat(v))
+	}
+	return sum
+}
+
+// ToBigRat 将数字转换为 *big.Rat
+//
+// 参数说明:
+//   - v: 需要转换的数字
+//
+// 返回值:
+//   - *big.Rat: 转换结果
+//
+// 注意事项:
+//   - 浮点数为NaN或Inf时会panic
+func ToBigRat[T Number](v T) *big.Rat {
+	if isFloat[T]() {
+		r := new(big.Rat)
+		if r.SetFloat64(float64(v)) == nil {
+			panic("cannot convert NaN or Inf to big.Rat")
+		}
+		return r
+	}
+	if v < 0 {
+		return new(big.Rat).SetInt64(int64(v))
+	}
+	return new(big.Rat).SetInt(new(big.Int).SetUint64(uint64(v)))
+}
+
+// BigMulDiv 计算 a*b/c 并按指定方式舍入,中间结果使用高精度整数,不会溢出
+//
+// 参数说明:
+//   - a: 乘数
+//   - b: 乘数
+//   - c: 除数
+//   - mode: 舍入方式
+//
+// 返回值:
+//   - T: 舍入后的结果
+//   - error: c为0时返回 ErrDivisionByZero,结果超出T的范围时返回 ErrOverflow
+//
+// 注意事项:
+//   - 适用于金额按比例分摊、费率计算等a*b可能溢出而最终结果不会溢出的场景
+//
+// 示例:
+//
+//	r, err := BigMulDiv(int64(math.MaxInt64), 3, 4, RoundHalfUp)
+//	// r = 6917529027641081855
+//
+//	r, err := BigMulDiv(int64(5), 1, 2, RoundHalfEven)
+//	// r = 2
+func BigMulDiv[T Signed](a, b, c T, mode RoundingMode) (T, error) {
+	if c == 0 {
+		return 0, ErrDivisionByZero
+	}
+	num := new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(int64(b)))
+	den := big.NewInt(int64(c))
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 {
+		sign := int64(num.Sign() * den.Sign())
+		// cmpHalf: 余数的两倍与除数比较,判断是否超过一半
+		cmpHalf := new(big.Int).Abs(new(big.Int).Lsh(r, 1)).Cmp(new(big.Int).Abs(den))
+		var inc bool
+		switch mode {
+		case RoundDown:
+		case RoundUp:
+			inc = true
+		case RoundFloor:
+			inc = sign < 0
+		case RoundCeil:
+			inc = sign > 0
+		case RoundHalfEven:
+			inc = cmpHalf > 0 || (cmpHalf == 0 && q.Bit(0) == 1)
+		default:
+			inc = cmpHalf >= 0
+		}
+		if inc {
+			q.Add(q, big.NewInt(sign))
+		}
+	}
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	result := T(q.Int64())
+	if int64(result) != q.Int64() {
+		return 0, ErrOverflow
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package kmath
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigSum(t *testing.T) {
+	sum := BigSum([]int64{math.MaxInt64, math.MaxInt64})
+	expected, _ := new(big.Int).SetString("18446744073709551614", 10)
+	assert.Equal(t, 0, sum.Cmp(new(big.Rat).SetInt(expected)))
+
+	sum = BigSum([]uint64{math.MaxUint64, 1})
+	expected, _ = new(big.Int).SetString("18446744073709551616", 10)
+	assert.Equal(t, 0, sum.Cmp(new(big.Rat).SetInt(expected)))
+
+	f, _ := BigSum([]float64{0.5, 0.25}).Float64()
+	assert.Equal(t, 0.75, f)
+
+	assert.Equal(t, "0", BigSum([]int{}).RatString())
+	assert.Panics(t, func() { BigSum([]float64{math.NaN()}) })
+}
+
+func TestBigMulDiv(t *testing.T) {
+	t.Run("中间结果溢出", func(t *testing.T) {
+		r, err := BigMulDiv(int64(math.MaxInt64), 3, 4, RoundDown)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6917529027641081855), r)
+	})
+
+	t.Run("舍入方式", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			a, b, c  int64
+			mode     RoundingMode
+			expected int64
+		}{
+			{"四舍五入", 5, 1, 2, RoundHalfUp, 3},
+			{"四舍五入负数", -5, 1, 2, RoundHalfUp, -3},
+			{"银行家舍入向偶", 5, 1, 2, RoundHalfEven, 2},
+			{"银行家舍入向偶2", 7, 1, 2, RoundHalfEven, 4},
+			{"银行家舍入超过一半", 7, 1, 3, RoundHalfEven, 2},
+			{"截断", 7, 1, 2, RoundDown, 3},
+			{"截断负数", -7, 1, 2, RoundDown, -3},
+			{"进位", 7, 1, 3, RoundUp, 3},
+			{"进位负数", -7, 1, 3, RoundUp, -3},
+			{"向下", -7, 1, 2, RoundFloor, -4},
+			{"向上", 7, 1, 2, RoundCeil, 4},
+			{"向上负数", -7, 1, 2, RoundCeil, -3},
+			{"除尽", 6, 2, 3, RoundUp, 4},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				r, err := BigMulDiv(tt.a, tt.b, tt.c, tt.mode)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, r)
+			})
+		}
+	})
+
+	t.Run("错误", func(t *testing.T) {
+		_, err := BigMulDiv(int64(1), 2, 0, RoundHalfUp)
+		assert.True(t, errors.Is(err, ErrDivisionByZero))
+
+		_, err = BigMulDiv(int64(math.MaxInt64), 2, 1, RoundHalfUp)
+		assert.True(t, errors.Is(err, ErrOverflow))
+
+		_, err = BigMulDiv(int8(100), 2, 1, RoundHalfUp)
+		assert.True(t, errors.Is(err, ErrOverflow))
+	})
+}
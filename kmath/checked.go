@@ -0,0 +1,103 @@
+package kmath
+
+// Signed 有符号整数类型约束
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// SafeDiv 安全除法,除数为0时返回默认值
+//
+// 参数说明:
+//   - a: 被除数
+//   - b: 除数
+//   - def: 除数为0时返回的默认值
+//
+// 返回值:
+//   - b不为0时返回a/b,否则返回def
+//
+// 注意事项:
+//   - 整数类型按整数除法截断
+//   - 浮点数除以0在go中不会panic而是得到Inf/NaN,这里同样返回def
+//
+// 示例:
+//
+//	r := SafeDiv(10, 2, 0)
+//	// r = 5
+//
+//	r := SafeDiv(10.0, 0, -1)
+//	// r = -1
+func SafeDiv[T Number](a, b, def T) T {
+	if b == 0 {
+		return def
+	}
+	return a / b
+}
+
+// AddInt 带溢出检测的整数加法
+//
+// 参数说明:
+//   - a: 加数
+//   - b: 加数
+//
+// 返回值:
+//   - result: a+b的结果,溢出时为回绕后的值
+//   - overflowed: 是否发生溢出
+//
+// 示例:
+//
+//	r, overflowed := AddInt(int64(math.MaxInt64), 1)
+//	// overflowed = true
+func AddInt[T Signed](a, b T) (result T, overflowed bool) {
+	result = a + b
+	overflowed = (a > 0 && b > 0 && result < 0) || (a < 0 && b < 0 && result >= 0)
+	return result, overflowed
+}
+
+// SubInt 带溢出检测的整数减法
+//
+// 参数说明:
+//   - a: 被减数
+//   - b: 减数
+//
+// 返回值:
+//   - result: a-b的结果,溢出时为回绕后的值
+//   - overflowed: 是否发生溢出
+//
+// 示例:
+//
+//	r, overflowed := SubInt(int64(math.MinInt64), 1)
+//	// overflowed = true
+func SubInt[T Signed](a, b T) (result T, overflowed bool) {
+	result = a - b
+	overflowed = (a >= 0 && b < 0 && result < 0) || (a < 0 && b > 0 && result >= 0)
+	return result, overflowed
+}
+
+// MulInt 带溢出检测的整数乘法
+//
+// 参数说明:
+//   - a: 乘数
+//   - b: 乘数
+//
+// 返回值:
+//   - result: a*b的结果,溢出时为回绕后的值
+//   - overflowed: 是否发生溢出
+//
+// 注意事项:
+//   - 适用于以分为单位的金额计算等不允许静默回绕的场景
+//
+// 示例:
+//
+//	r, overflowed := MulInt(int64(100), 25)
+//	// r = 2500, overflowed = false
+func MulInt[T Signed](a, b T) (result T, overflowed bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+	result = a * b
+	if b == -1 {
+		// 最小值取反时溢出,结果等于自身
+		return result, result == a
+	}
+	return result, result/b != a
+}
@@ -0,0 +1,65 @@
+package kmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeDiv(t *testing.T) {
+	assert.Equal(t, 5, SafeDiv(10, 2, 0))
+	assert.Equal(t, -1, SafeDiv(10, 0, -1))
+	assert.Equal(t, 2.5, SafeDiv(5.0, 2, 0))
+	assert.Equal(t, 0.0, SafeDiv(5.0, 0, 0))
+}
+
+func TestAddInt(t *testing.T) {
+	r, overflowed := AddInt(int64(1), 2)
+	assert.Equal(t, int64(3), r)
+	assert.False(t, overflowed)
+
+	_, overflowed = AddInt(int64(math.MaxInt64), 1)
+	assert.True(t, overflowed)
+
+	_, overflowed = AddInt(int64(math.MinInt64), -1)
+	assert.True(t, overflowed)
+
+	_, overflowed = AddInt(int8(100), 28)
+	assert.True(t, overflowed)
+}
+
+func TestSubInt(t *testing.T) {
+	r, overflowed := SubInt(int64(1), 2)
+	assert.Equal(t, int64(-1), r)
+	assert.False(t, overflowed)
+
+	_, overflowed = SubInt(int64(math.MinInt64), 1)
+	assert.True(t, overflowed)
+
+	_, overflowed = SubInt(int64(0), math.MinInt64)
+	assert.True(t, overflowed)
+}
+
+func TestMulInt(t *testing.T) {
+	r, overflowed := MulInt(int64(100), 25)
+	assert.Equal(t, int64(2500), r)
+	assert.False(t, overflowed)
+
+	r, overflowed = MulInt(int64(-3), -4)
+	assert.Equal(t, int64(12), r)
+	assert.False(t, overflowed)
+
+	_, overflowed = MulInt(int64(math.MaxInt64), 2)
+	assert.True(t, overflowed)
+
+	_, overflowed = MulInt(int64(math.MinInt64), -1)
+	assert.True(t, overflowed)
+
+	_, overflowed = MulInt(int64(-1), math.MinInt64)
+	assert.True(t, overflowed)
+
+	r, overflowed = MulInt(int64(0), math.MaxInt64)
+	assert.Equal(t, int64(0), r)
+	assert.False(t, overflowed)
+}
@@ -0,0 +1,240 @@
+package kmath
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidNumberFormat = errors.New("invalid number format")
+)
+
+var (
+	siUnits    = []string{"", "k", "M", "G", "T", "P", "E"}
+	bytesUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+)
+
+// FormatThousands 使用千分位分隔符格式化数字
+//
+// 参数说明:
+//   - v: 需要格式化的数字
+//
+// 返回值:
+//   - string: 每三位整数插入一个逗号的字符串,小数部分保持不变
+//
+// 示例:
+//
+//	s := FormatThousands(1234567)
+//	// s = "1,234,567"
+//
+//	s := FormatThousands(-1234.5)
+//	// s = "-1,234.5"
+func FormatThousands[T Number](v T) string {
+	var s string
+	if isFloat[T]() {
+		s = strconv.FormatFloat(float64(v), 'f', -1, reflect.TypeOf(v).Bits())
+	} else {
+		s = fmt.Sprint(v)
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// ParseThousands 解析带千分位分隔符的数字字符串,FormatThousands 的逆操作
+//
+// 参数说明:
+//   - s: 需要解析的字符串,如"1,234,567"
+//
+// 返回值:
+//   - T: 解析结果
+//   - error: 格式错误时返回 ErrInvalidNumberFormat
+//
+// 示例:
+//
+//	v, err := ParseThousands[int64]("1,234,567")
+//	// v = 1234567
+func ParseThousands[T Number](s string) (T, error) {
+	return parseNumber[T](strings.ReplaceAll(strings.TrimSpace(s), ",", ""))
+}
+
+// FormatSI 使用国际单位制前缀(k/M/G/T/P/E,1000进制)格式化数字
+//
+// 参数说明:
+//   - v: 需要格式化的数字
+//
+// 返回值:
+//   - string: 格式化后的字符串,最多保留两位小数
+//
+// 注意事项:
+//   - 绝对值小于1000时不带单位
+//
+// 示例:
+//
+//	s := FormatSI(1500000)
+//	// s = "1.5M"
+//
+//	s := FormatSI(999)
+//	// s = "999"
+func FormatSI[T Number](v T) string {
+	return formatWithUnits(float64(v), 1000, siUnits, "")
+}
+
+// ParseSI 解析带国际单位制前缀的数字字符串,FormatSI 的逆操作
+//
+// 参数说明:
+//   - s: 需要解析的字符串,如"1.5M"、"2k"、"300"
+//
+// 返回值:
+//   - float64: 解析结果
+//   - error: 格式错误时返回 ErrInvalidNumberFormat
+//
+// 注意事项:
+//   - k不区分大小写,其余单位区分大小写
+//
+// 示例:
+//
+//	v, err := ParseSI("1.5M")
+//	// v = 1500000
+func ParseSI(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	for i := len(siUnits) - 1; i > 0; i-- {
+		unit := siUnits[i]
+		if strings.HasSuffix(s, unit) || (unit == "k" && strings.HasSuffix(s, "K")) {
+			f, err := parseNumber[float64](strings.TrimSpace(s[:len(s)-len(unit)]))
+			if err != nil {
+				return 0, err
+			}
+			return f * math.Pow(1000, float64(i)), nil
+		}
+	}
+	return parseNumber[float64](s)
+}
+
+// FormatBytes 使用二进制前缀(KiB/MiB/GiB...,1024进制)格式化字节数
+//
+// 参数说明:
+//   - v: 字节数
+//
+// 返回值:
+//   - string: 格式化后的字符串,最多保留两位小数
+//
+// 示例:
+//
+//	s := FormatBytes(1536)
+//	// s = "1.5 KiB"
+//
+//	s := FormatBytes(512)
+//	// s = "512 B"
+func FormatBytes[T Number](v T) string {
+	return formatWithUnits(float64(v), 1024, bytesUnits, " ")
+}
+
+// ParseBytes 解析字节数字符串,FormatBytes 的逆操作
+//
+// 参数说明:
+//   - s: 需要解析的字符串,如"1.5 KiB"、"10MB"、"100"
+//
+// 返回值:
+//   - int64: 字节数,小数部分会被截断
+//   - error: 格式错误时返回 ErrInvalidNumberFormat
+//
+// 注意事项:
+//   - 单位不区分大小写
+//   - KiB/MiB等二进制前缀按1024进制计算,KB/MB等十进制前缀按1000进制计算
+//   - 不带单位时按字节处理
+//
+// 示例:
+//
+//	v, err := ParseBytes("1.5 KiB")
+//	// v = 1536
+//
+//	v, err := ParseBytes("1MB")
+//	// v = 1000000
+func ParseBytes(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	idx := strings.LastIndexFunc(s, func(r rune) bool {
+		return (r >= '0' && r <= '9') || r == '.'
+	})
+	numStr, unit := strings.TrimSpace(s[:idx+1]), strings.ToLower(strings.TrimSpace(s[idx+1:]))
+	f, err := parseNumber[float64](numStr)
+	if err != nil {
+		return 0, err
+	}
+	if unit == "" || unit == "b" {
+		return int64(f), nil
+	}
+	for i := 1; i < len(bytesUnits); i++ {
+		prefix := strings.ToLower(bytesUnits[i][:1])
+		switch unit {
+		case prefix + "ib":
+			return int64(f * math.Pow(1024, float64(i))), nil
+		case prefix + "b":
+			return int64(f * math.Pow(1000, float64(i))), nil
+		}
+	}
+	return 0, errors.Wrapf(ErrInvalidNumberFormat, "unknown unit %q", unit)
+}
+
+// formatWithUnits 按进制base选择合适的单位并格式化
+func formatWithUnits(f float64, base float64, units []string, sep string) string {
+	i := 0
+	for math.Abs(f) >= base && i < len(units)-1 {
+		f /= base
+		i++
+	}
+	r := Round(f, 2)
+	if math.Abs(r) >= base && i < len(units)-1 {
+		// 四舍五入后达到进制时使用下一个单位,如999999为"1M"而不是"1000k"
+		i++
+		r = Round(f/base, 2)
+	}
+	s := strconv.FormatFloat(r, 'f', -1, 64)
+	if units[i] == "" {
+		return s
+	}
+	return s + sep + units[i]
+}
+
+// parseNumber 根据T的类型解析整数或浮点数
+func parseNumber[T Number](s string) (T, error) {
+	if isFloat[T]() {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, errors.Wrapf(ErrInvalidNumberFormat, "%q", s)
+		}
+		return T(f), nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(ErrInvalidNumberFormat, "%q", s)
+	}
+	return T(i), nil
+}
+
+// isFloat 判断T是否为浮点数类型
+func isFloat[T Number]() bool {
+	half := 0.5
+	return T(half) != 0
+}
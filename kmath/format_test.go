@@ -0,0 +1,99 @@
+package kmath
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatThousands(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"整数", FormatThousands(1234567), "1,234,567"},
+		{"三位以内", FormatThousands(123), "123"},
+		{"刚好三位的倍数", FormatThousands(123456), "123,456"},
+		{"负数", FormatThousands(-1234567), "-1,234,567"},
+		{"浮点数", FormatThousands(-1234.5), "-1,234.5"},
+		{"float32", FormatThousands(float32(1234.1)), "1,234.1"},
+		{"零", FormatThousands(0), "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.got)
+		})
+	}
+}
+
+func TestParseThousands(t *testing.T) {
+	v, err := ParseThousands[int64]("1,234,567")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1234567), v)
+
+	f, err := ParseThousands[float64]("-1,234.5")
+	assert.NoError(t, err)
+	assert.Equal(t, -1234.5, f)
+
+	_, err = ParseThousands[int]("1,2a4")
+	assert.True(t, errors.Is(err, ErrInvalidNumberFormat))
+}
+
+func TestFormatSI(t *testing.T) {
+	assert.Equal(t, "999", FormatSI(999))
+	assert.Equal(t, "1.5k", FormatSI(1500))
+	assert.Equal(t, "1.5M", FormatSI(1500000))
+	assert.Equal(t, "1.23M", FormatSI(1234567))
+	assert.Equal(t, "-2G", FormatSI(-2000000000))
+	assert.Equal(t, "1M", FormatSI(999999))
+	assert.Equal(t, "-1M", FormatSI(-999999))
+	assert.Equal(t, "1k", FormatSI(999.999))
+}
+
+func TestParseSI(t *testing.T) {
+	tests := map[string]float64{
+		"1.5M": 1500000,
+		"2k":   2000,
+		"2K":   2000,
+		"300":  300,
+		"1 G":  1e9,
+	}
+	for s, expected := range tests {
+		v, err := ParseSI(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, v, s)
+	}
+	_, err := ParseSI("abc")
+	assert.True(t, errors.Is(err, ErrInvalidNumberFormat))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536))
+	assert.Equal(t, "1 MiB", FormatBytes(1024*1024))
+	assert.Equal(t, "1 MiB", FormatBytes(1048575))
+	assert.Equal(t, "1 KiB", FormatBytes(1023.999))
+	assert.Equal(t, "2.5 GiB", FormatBytes(int64(2.5*1024*1024*1024)))
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := map[string]int64{
+		"1.5 KiB": 1536,
+		"1.5KiB":  1536,
+		"1mib":    1024 * 1024,
+		"1MB":     1000000,
+		"100":     100,
+		"100 B":   100,
+	}
+	for s, expected := range tests {
+		v, err := ParseBytes(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, v, s)
+	}
+	_, err := ParseBytes("1 XB")
+	assert.True(t, errors.Is(err, ErrInvalidNumberFormat))
+	_, err = ParseBytes("KiB")
+	assert.True(t, errors.Is(err, ErrInvalidNumberFormat))
+}
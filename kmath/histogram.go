@@ -0,0 +1,245 @@
+package kmath
+
+import (
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrBoundsMismatch = errors.New("histogram bounds mismatch")
+)
+
+// Histogram 直方图,按给定的桶边界统计观测值的分布
+//
+// 桶的划分规则:
+//   - 第i个桶统计 (bounds[i-1], bounds[i]] 区间内的值,第0个桶统计 <= bounds[0] 的值
+//   - 额外有一个溢出桶,统计 > bounds[len(bounds)-1] 的值
+type Histogram[T Number] struct {
+	mu     sync.RWMutex
+	bounds []T     // 桶的上边界,严格递增
+	counts []int64 // 每个桶的计数,长度为len(bounds)+1
+	count  int64   // 观测值总数
+	sum    T       // 观测值总和
+	min    T       // 最小观测值
+	max    T       // 最大观测值
+}
+
+// NewHistogram 创建一个新的直方图
+//
+// 参数说明:
+//   - bounds: 桶的上边界,必须严格递增
+//
+// 返回值:
+//   - *Histogram[T]: 新创建的直方图
+//
+// 注意事项:
+//   - bounds为空或不是严格递增时会panic
+//   - 会复制bounds,调用方之后修改bounds不影响直方图
+//   - 线程安全
+//
+// 示例:
+//
+//	h := NewHistogram([]int64{10, 50, 100, 500})
+//	h.Observe(30)
+//	h.Observe(200)
+//	// h.Counts() = [0 1 0 1 0]
+func NewHistogram[T Number](bounds []T) *Histogram[T] {
+	if len(bounds) == 0 {
+		panic("bounds must not be empty")
+	}
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] <= bounds[i-1] {
+			panic("bounds must be strictly increasing")
+		}
+	}
+	return &Histogram[T]{
+		bounds: slices.Clone(bounds),
+		counts: make([]int64, len(bounds)+1),
+	}
+}
+
+// Observe 记录一个观测值
+// 参数:
+//   - v: 观测值
+func (h *Histogram[T]) Observe(v T) {
+	idx, _ := slices.BinarySearch(h.bounds, v)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[idx]++
+	if h.count == 0 || v < h.min {
+		h.min = v
+	}
+	if h.count == 0 || v > h.max {
+		h.max = v
+	}
+	h.count++
+	h.sum += v
+}
+
+// Bounds 返回桶的上边界
+// 返回:
+//   - []T: 桶上边界的副本
+func (h *Histogram[T]) Bounds() []T {
+	return slices.Clone(h.bounds)
+}
+
+// Counts 返回每个桶的计数
+// 返回:
+//   - []int64: 长度为len(bounds)+1,最后一个元素为溢出桶的计数
+func (h *Histogram[T]) Counts() []int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return slices.Clone(h.counts)
+}
+
+// Count 返回观测值总数
+func (h *Histogram[T]) Count() int64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.count
+}
+
+// Sum 返回观测值总和
+func (h *Histogram[T]) Sum() T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.sum
+}
+
+// Min 返回最小观测值,没有观测值时返回零值
+func (h *Histogram[T]) Min() T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.min
+}
+
+// Max 返回最大观测值,没有观测值时返回零值
+func (h *Histogram[T]) Max() T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.max
+}
+
+// Mean 返回观测值的平均值,没有观测值时返回0
+func (h *Histogram[T]) Mean() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.count == 0 {
+		return 0
+	}
+	return float64(h.sum) / float64(h.count)
+}
+
+// Percentile 返回第p百分位数的近似值
+//
+// 参数说明:
+//   - p: 百分位,范围为0到100
+//
+// 返回值:
+//   - float64: 近似的百分位数,没有观测值时返回0,p为NaN时返回NaN
+//
+// 注意事项:
+//   - 先定位百分位所在的桶,再在桶的上下边界间线性插值
+//   - 第一个桶的下边界取最小观测值,溢出桶的上边界取最大观测值
+//   - 结果会被限制在[Min, Max]内,精度取决于桶边界的粒度
+//
+// 示例:
+//
+//	p99 := h.Percentile(99)
+func (h *Histogram[T]) Percentile(p float64) float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.count == 0 {
+		return 0
+	}
+	if math.IsNaN(p) {
+		return math.NaN()
+	}
+	minV, maxV := float64(h.min), float64(h.max)
+	if p <= 0 {
+		return minV
+	}
+	if p >= 100 {
+		return maxV
+	}
+	rank := p / 100 * float64(h.count)
+	var cumulative int64
+	for i, c := range h.counts {
+		if c == 0 || float64(cumulative+c) < rank {
+			cumulative += c
+			continue
+		}
+		lower, upper := minV, maxV
+		if i > 0 {
+			lower = Max(float64(h.bounds[i-1]), minV)
+		}
+		if i < len(h.bounds) {
+			upper = Min(float64(h.bounds[i]), maxV)
+		}
+		v := lower + (upper-lower)*(rank-float64(cumulative))/float64(c)
+		return Clamp(v, minV, maxV)
+	}
+	return maxV
+}
+
+// Merge 将另一个直方图的观测值合并到当前直方图
+//
+// 参数说明:
+//   - other: 需要合并的直方图,桶边界必须与当前直方图一致
+//
+// 返回值:
+//   - error: 桶边界不一致时返回 ErrBoundsMismatch
+//
+// 注意事项:
+//   - 先复制other的数据再合并,other为当前直方图时相当于合并一个相同的副本,所有计数翻倍
+//
+// 示例:
+//
+//	total := NewHistogram(bounds)
+//	for _, h := range perInstance {
+//	    total.Merge(h)
+//	}
+func (h *Histogram[T]) Merge(other *Histogram[T]) error {
+	if !slices.Equal(h.bounds, other.bounds) {
+		return ErrBoundsMismatch
+	}
+	// 先复制再加锁当前直方图,other为当前直方图时不会死锁,也不会在遍历时读到已合并的计数
+	other.mu.RLock()
+	counts := slices.Clone(other.counts)
+	count, sum, minV, maxV := other.count, other.sum, other.min, other.max
+	other.mu.RUnlock()
+	if count == 0 {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, c := range counts {
+		h.counts[i] += c
+	}
+	if h.count == 0 || minV < h.min {
+		h.min = minV
+	}
+	if h.count == 0 || maxV > h.max {
+		h.max = maxV
+	}
+	h.count += count
+	h.sum += sum
+	return nil
+}
+
+// Reset 清空所有观测值
+func (h *Histogram[T]) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clear(h.counts)
+	var zero T
+	h.count = 0
+	h.sum = zero
+	h.min = zero
+	h.max = zero
+}
@@ -0,0 +1,77 @@
+package kmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHistogram(t *testing.T) {
+	assert.NotNil(t, NewHistogram([]int{1, 2, 3}))
+	assert.Panics(t, func() { NewHistogram([]int{}) })
+	assert.Panics(t, func() { NewHistogram([]int{1, 1, 2}) })
+	assert.Panics(t, func() { NewHistogram([]int{3, 2}) })
+}
+
+func TestHistogramObserve(t *testing.T) {
+	h := NewHistogram([]int64{10, 50, 100, 500})
+	for _, v := range []int64{5, 10, 30, 200, 1000} {
+		h.Observe(v)
+	}
+	assert.Equal(t, []int64{2, 1, 0, 1, 1}, h.Counts())
+	assert.Equal(t, int64(5), h.Count())
+	assert.Equal(t, int64(1245), h.Sum())
+	assert.Equal(t, int64(5), h.Min())
+	assert.Equal(t, int64(1000), h.Max())
+	assert.Equal(t, 249.0, h.Mean())
+	assert.Equal(t, []int64{10, 50, 100, 500}, h.Bounds())
+
+	h.Reset()
+	assert.Equal(t, []int64{0, 0, 0, 0, 0}, h.Counts())
+	assert.Equal(t, int64(0), h.Count())
+	assert.Equal(t, 0.0, h.Percentile(50))
+}
+
+func TestHistogramPercentile(t *testing.T) {
+	h := NewHistogram([]float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100})
+	for i := 1; i <= 100; i++ {
+		h.Observe(float64(i))
+	}
+	assert.Equal(t, 1.0, h.Percentile(0))
+	assert.InDelta(t, 50, h.Percentile(50), 1)
+	assert.InDelta(t, 99, h.Percentile(99), 1)
+	assert.Equal(t, 100.0, h.Percentile(100))
+	assert.True(t, math.IsNaN(h.Percentile(math.NaN())))
+
+	t.Run("溢出桶", func(t *testing.T) {
+		h := NewHistogram([]float64{10})
+		h.Observe(20)
+		h.Observe(40)
+		assert.InDelta(t, 30, h.Percentile(50), 1e-9)
+	})
+}
+
+func TestHistogramMerge(t *testing.T) {
+	a := NewHistogram([]int{10, 20})
+	b := NewHistogram([]int{10, 20})
+	a.Observe(5)
+	b.Observe(15)
+	b.Observe(30)
+	assert.NoError(t, a.Merge(b))
+	assert.NoError(t, a.Merge(NewHistogram([]int{10, 20})))
+	assert.Equal(t, []int64{1, 1, 1}, a.Counts())
+	assert.Equal(t, 5, a.Min())
+	assert.Equal(t, 30, a.Max())
+	assert.Equal(t, 50, a.Sum())
+
+	assert.ErrorIs(t, a.Merge(NewHistogram([]int{10})), ErrBoundsMismatch)
+
+	// 合并自身相当于合并一个相同的副本
+	assert.NoError(t, a.Merge(a))
+	assert.Equal(t, []int64{2, 2, 2}, a.Counts())
+	assert.Equal(t, int64(6), a.Count())
+	assert.Equal(t, 100, a.Sum())
+	assert.Equal(t, 5, a.Min())
+	assert.Equal(t, 30, a.Max())
+}
@@ -3,6 +3,8 @@
 // 主要功能:
 //   - Max: 返回两个可比较类型值中的较大值
 //   - Min: 返回两个可比较类型值中的较小值
+//   - Clamp: 将值限制在指定区间内
+//   - InRange: 判断值是否在指定区间内
 //   - Round: 四舍五入保留n位小数
 //   - Floor: 向下取整
 //   - Ceil: 向上取整
@@ -11,6 +13,14 @@
 //   - Sqrt: 返回一个数的平方根
 //   - RandInt: 返回一个随机整数
 //   - RandFloat: 返回一个随机浮点数
+//   - Sum/Avg/Median/Mode/Percentile: 对切片进行聚合统计
+//   - SafeDiv: 除数为0时返回默认值的安全除法
+//   - AddInt/SubInt/MulInt: 带溢出检测的整数运算
+//   - MovingAverage/EMA: 按样本滑动的移动平均和指数移动平均
+//   - Histogram: 按桶边界统计分布的直方图
+//   - FormatThousands/FormatSI/FormatBytes: 数字的可读格式化及对应的解析函数
+//   - Range/LinSpace: 生成等差数列
+//   - BigSum/BigMulDiv: 基于math/big的高精度求和及乘除运算
 package kmath
 
 import (
@@ -69,6 +79,57 @@ func Min[T cmp.Ordered](a, b T) T {
 	return b
 }
 
+// Clamp 将值限制在[min, max]区间内
+//
+// 参数说明:
+//   - v: 需要限制的值
+//   - min: 区间下界
+//   - max: 区间上界
+//
+// 返回值:
+//   - v小于min时返回min,大于max时返回max,否则返回v
+//
+// 注意事项:
+//   - 调用方需保证min <= max,否则结果无意义
+//
+// 示例:
+//
+//	c := Clamp(15, 0, 10)
+//	// c = 10
+//
+//	c := Clamp(-1.5, 0.0, 1.0)
+//	// c = 0.0
+func Clamp[T cmp.Ordered](v, min, max T) T {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// InRange 判断值是否在[min, max]闭区间内
+//
+// 参数说明:
+//   - v: 需要判断的值
+//   - min: 区间下界(包含)
+//   - max: 区间上界(包含)
+//
+// 返回值:
+//   - v在区间内返回true,否则返回false
+//
+// 示例:
+//
+//	ok := InRange(5, 1, 10)
+//	// ok = true
+//
+//	ok := InRange("z", "a", "m")
+//	// ok = false
+func InRange[T cmp.Ordered](v, min, max T) bool {
+	return v >= min && v <= max
+}
+
 // Round 四舍五入保留n位小数
 //
 // 参数说明:
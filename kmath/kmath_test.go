@@ -20,6 +20,30 @@ func TestMin(t *testing.T) {
 	}
 }
 
+func TestClamp(t *testing.T) {
+	if Clamp(15, 0, 10) != 10 {
+		t.Error("Clamp(15, 0, 10) != 10")
+	}
+	if Clamp(-1, 0, 10) != 0 {
+		t.Error("Clamp(-1, 0, 10) != 0")
+	}
+	if Clamp(0.5, 0.0, 1.0) != 0.5 {
+		t.Error("Clamp(0.5, 0.0, 1.0) != 0.5")
+	}
+}
+
+func TestInRange(t *testing.T) {
+	if !InRange(5, 1, 10) {
+		t.Error("InRange(5, 1, 10) != true")
+	}
+	if !InRange(10, 1, 10) {
+		t.Error("InRange(10, 1, 10) != true")
+	}
+	if InRange("z", "a", "m") {
+		t.Error(`InRange("z", "a", "m") != false`)
+	}
+}
+
 func TestRound(t *testing.T) {
 	if Round(1.234, 2) != 1.23 {
 		t.Error("Round(1.234, 2) != 1.23")
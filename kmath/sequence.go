@@ -0,0 +1,86 @@
+package kmath
+
+// Range 生成从start开始,以step为步长,到end(不包含)为止的数列
+//
+// 参数说明:
+//   - start: 起始值(包含)
+//   - end: 结束值(不包含)
+//   - step: 步长,可以为负数
+//
+// 返回值:
+//   - []T: 生成的数列,start无法按step方向到达end时返回空切片
+//
+// 注意事项:
+//   - step为0时会panic
+//   - 浮点数步长存在精度误差,需要精确等分时请使用 LinSpace
+//   - 接近类型边界时不会溢出,如 Range[int8](120, 127, 10) 返回 [120]
+//
+// 示例:
+//
+//	r := Range(0, 10, 3)
+//	// r = [0 3 6 9]
+//
+//	r := Range(5, 0, -2)
+//	// r = [5 3 1]
+func Range[T Number](start, end, step T) []T {
+	if step == 0 {
+		panic("step must not be zero")
+	}
+	var zero T
+	result := make([]T, 0)
+	// 整数在接近类型边界时 v+step 会溢出回绕,导致循环无法结束,
+	// 因此在相加后检查是否仍然按step的方向前进
+	if step > zero {
+		for v := start; v < end; {
+			result = append(result, v)
+			next := v + step
+			if next <= v {
+				break
+			}
+			v = next
+		}
+	} else {
+		for v := start; v > end; {
+			result = append(result, v)
+			next := v + step
+			if next >= v {
+				break
+			}
+			v = next
+		}
+	}
+	return result
+}
+
+// LinSpace 生成在[start, end]区间内均匀分布的n个数
+//
+// 参数说明:
+//   - start: 起始值(包含)
+//   - end: 结束值(包含)
+//   - n: 生成的数量
+//
+// 返回值:
+//   - []float64: 生成的数列,n<=0时返回空切片,n==1时只包含start
+//
+// 注意事项:
+//   - 每个元素按 start+i*(end-start)/(n-1) 独立计算,最后一个元素精确等于end
+//
+// 示例:
+//
+//	l := LinSpace(0, 1, 5)
+//	// l = [0 0.25 0.5 0.75 1]
+func LinSpace(start, end float64, n int) []float64 {
+	if n <= 0 {
+		return []float64{}
+	}
+	if n == 1 {
+		return []float64{start}
+	}
+	result := make([]float64, n)
+	step := (end - start) / float64(n-1)
+	for i := 0; i < n-1; i++ {
+		result[i] = start + float64(i)*step
+	}
+	result[n-1] = end
+	return result
+}
@@ -0,0 +1,28 @@
+package kmath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRange(t *testing.T) {
+	assert.Equal(t, []int{0, 3, 6, 9}, Range(0, 10, 3))
+	assert.Equal(t, []int{5, 3, 1}, Range(5, 0, -2))
+	assert.Equal(t, []int{}, Range(5, 0, 1))
+	assert.Equal(t, []float64{0, 0.5, 1, 1.5}, Range(0.0, 2, 0.5))
+	assert.Panics(t, func() { Range(0, 10, 0) })
+
+	// 接近类型边界时不能溢出
+	assert.Equal(t, []int8{120}, Range[int8](120, 127, 10))
+	assert.Equal(t, []int8{-120}, Range[int8](-120, -128, -10))
+	assert.Equal(t, []uint8{250, 253}, Range[uint8](250, 255, 3))
+	assert.Equal(t, []int16{32760}, Range[int16](32760, 32767, 100))
+}
+
+func TestLinSpace(t *testing.T) {
+	assert.Equal(t, []float64{0, 0.25, 0.5, 0.75, 1}, LinSpace(0, 1, 5))
+	assert.Equal(t, []float64{10, 5, 0}, LinSpace(10, 0, 3))
+	assert.Equal(t, []float64{3}, LinSpace(3, 7, 1))
+	assert.Equal(t, []float64{}, LinSpace(0, 1, 0))
+}
@@ -0,0 +1,200 @@
+package kmonitor
+
+import (
+	"sync"
+	"time"
+)
+
+// AlerterOptions 阈值告警的配置项
+type AlerterOptions struct {
+	Recover    float64          // 恢复阈值,值小于等于该阈值时告警恢复,用于实现迟滞,默认等于告警阈值
+	Cooldown   time.Duration    // 两次告警回调之间的最小间隔
+	Interval   time.Duration    // 定期检测的间隔
+	OnResolve  func(AlertEvent) // 告警恢复时的回调
+	hasRecover bool             // 是否设置了恢复阈值
+}
+
+type AlerterOption func(*AlerterOptions)
+
+func NewAlerterOptions() *AlerterOptions {
+	return &AlerterOptions{
+		Interval: time.Second,
+	}
+}
+
+// WithHysteresis 设置恢复阈值,值超过告警阈值后,需要回落到恢复阈值及以下才视为恢复,避免在阈值附近来回抖动
+func WithHysteresis(recoverThreshold float64) AlerterOption {
+	return func(o *AlerterOptions) {
+		o.Recover = recoverThreshold
+		o.hasRecover = true
+	}
+}
+
+// WithCooldown 设置两次告警回调之间的最小间隔
+func WithCooldown(d time.Duration) AlerterOption {
+	return func(o *AlerterOptions) {
+		o.Cooldown = d
+	}
+}
+
+// WithEvaluateInterval 设置定期检测的间隔
+func WithEvaluateInterval(d time.Duration) AlerterOption {
+	return func(o *AlerterOptions) {
+		o.Interval = d
+	}
+}
+
+// WithOnResolve 设置告警恢复时的回调
+func WithOnResolve(fn func(AlertEvent)) AlerterOption {
+	return func(o *AlerterOptions) {
+		o.OnResolve = fn
+	}
+}
+
+// AlertEvent 告警事件
+type AlertEvent struct {
+	Value     float64   // 触发事件时的值
+	Threshold float64   // 告警阈值
+	Firing    bool      // true为告警,false为恢复
+	Time      time.Time // 事件发生的时间
+}
+
+// Alerter 阈值告警,定期读取指标的值,超过阈值时调用告警回调
+//
+// 状态变化规则:
+//   - 值大于告警阈值时进入告警状态,并调用告警回调
+//   - 处于告警状态时,值小于等于恢复阈值才退出告警状态,并调用恢复回调
+//   - 距上次告警回调不足 Cooldown 时不会再次回调,若冷却结束时仍处于告警状态则补发一次
+type Alerter struct {
+	mu        sync.Mutex
+	source    func() float64
+	threshold float64
+	onAlert   func(AlertEvent)
+	opts      *AlerterOptions
+	firing    bool      // 是否处于告警状态
+	notified  bool      // 本次告警是否已回调
+	lastAlert time.Time // 上次告警回调的时间
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewAlerter 创建一个阈值告警
+//
+// 参数说明:
+//   - source: 获取指标当前值的函数,如计数器、窗口的统计值
+//   - threshold: 告警阈值
+//   - onAlert: 告警回调
+//   - opts: 可选配置项,参见 AlerterOptions
+//
+// 返回值:
+//   - *Alerter: 阈值告警,需要调用 Start 定期检测,或手动调用 Evaluate
+//
+// 注意事项:
+//   - 回调在检测的goroutine中同步执行,耗时的操作(如调用webhook)应自行异步处理
+//
+// 示例:
+//
+//	// 1分钟内错误数超过100时告警,回落到50以下恢复,5分钟内最多告警一次
+//	errs := NewRateCounter[int64](
+//	    kcollection.WithSize[int64, *kcollection.Bucket[int64]](60),
+//	    kcollection.WithInterval[int64, *kcollection.Bucket[int64]](time.Second))
+//	alerter := NewAlerter(func() float64 { return errs.Total(time.Minute) }, 100, func(e AlertEvent) {
+//	    go callPagerDuty(e)
+//	}, WithHysteresis(50), WithCooldown(5*time.Minute))
+//	alerter.Start()
+//	defer alerter.Stop()
+func NewAlerter(source func() float64, threshold float64, onAlert func(AlertEvent), opts ...AlerterOption) *Alerter {
+	o := NewAlerterOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if !o.hasRecover {
+		o.Recover = threshold
+	}
+	return &Alerter{
+		source:    source,
+		threshold: threshold,
+		onAlert:   onAlert,
+		opts:      o,
+	}
+}
+
+// Evaluate 立即检测一次
+// 返回:
+//   - bool: 检测后是否处于告警状态
+func (a *Alerter) Evaluate() bool {
+	v := a.source()
+	now := time.Now()
+
+	a.mu.Lock()
+	var events []func()
+	switch {
+	case !a.firing && v > a.threshold:
+		a.firing = true
+		a.notified = false
+	case a.firing && v <= a.opts.Recover:
+		a.firing = false
+		if a.notified && a.opts.OnResolve != nil {
+			event := AlertEvent{Value: v, Threshold: a.threshold, Time: now}
+			events = append(events, func() { a.opts.OnResolve(event) })
+		}
+	}
+	if a.firing && !a.notified && (a.lastAlert.IsZero() || now.Sub(a.lastAlert) >= a.opts.Cooldown) {
+		a.notified = true
+		a.lastAlert = now
+		event := AlertEvent{Value: v, Threshold: a.threshold, Firing: true, Time: now}
+		events = append(events, func() { a.onAlert(event) })
+	}
+	firing := a.firing
+	a.mu.Unlock()
+
+	// 在锁外执行回调,允许回调中读取告警状态
+	for _, fn := range events {
+		fn()
+	}
+	return firing
+}
+
+// Firing 返回是否处于告警状态
+func (a *Alerter) Firing() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.firing
+}
+
+// Start 按 Interval 定期检测,重复调用无效果
+func (a *Alerter) Start() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stop != nil {
+		return
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	a.stop, a.done = stop, done
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(a.opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.Evaluate()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定期检测并等待其退出,未启动时无效果
+func (a *Alerter) Stop() {
+	a.mu.Lock()
+	stop, done := a.stop, a.done
+	a.stop, a.done = nil, nil
+	a.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
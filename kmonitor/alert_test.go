@@ -0,0 +1,101 @@
+package kmonitor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlerter(t *testing.T) {
+	t.Run("迟滞", func(t *testing.T) {
+		var (
+			value    float64
+			alerts   []AlertEvent
+			resolves []AlertEvent
+		)
+		a := NewAlerter(func() float64 { return value }, 100, func(e AlertEvent) {
+			alerts = append(alerts, e)
+		}, WithHysteresis(50), WithOnResolve(func(e AlertEvent) {
+			resolves = append(resolves, e)
+		}))
+
+		value = 100
+		assert.False(t, a.Evaluate(), "等于阈值不告警")
+		value = 101
+		assert.True(t, a.Evaluate())
+		assert.Len(t, alerts, 1)
+		assert.True(t, alerts[0].Firing)
+		assert.Equal(t, 101.0, alerts[0].Value)
+		assert.Equal(t, 100.0, alerts[0].Threshold)
+
+		// 在阈值附近抖动不会重复告警,也不会恢复
+		for _, v := range []float64{90, 120, 60, 150} {
+			value = v
+			assert.True(t, a.Evaluate())
+		}
+		assert.Len(t, alerts, 1)
+		assert.Empty(t, resolves)
+
+		value = 50
+		assert.False(t, a.Evaluate())
+		assert.False(t, a.Firing())
+		assert.Len(t, resolves, 1)
+		assert.False(t, resolves[0].Firing)
+
+		value = 101
+		a.Evaluate()
+		assert.Len(t, alerts, 2, "恢复后再次超过阈值会再次告警")
+	})
+
+	t.Run("冷却", func(t *testing.T) {
+		var (
+			value    float64
+			alerts   int
+			resolves int
+		)
+		a := NewAlerter(func() float64 { return value }, 10, func(e AlertEvent) {
+			alerts++
+		}, WithCooldown(50*time.Millisecond), WithOnResolve(func(e AlertEvent) {
+			resolves++
+		}))
+
+		value = 20
+		a.Evaluate()
+		value = 0
+		a.Evaluate()
+		value = 20
+		a.Evaluate()
+		assert.Equal(t, 1, alerts, "冷却期内不回调")
+		assert.Equal(t, 1, resolves)
+
+		// 冷却期内恢复,由于没有告警回调,也不会有恢复回调
+		value = 0
+		a.Evaluate()
+		assert.Equal(t, 1, resolves)
+
+		// 冷却结束时仍处于告警状态则补发
+		value = 20
+		a.Evaluate()
+		time.Sleep(60 * time.Millisecond)
+		a.Evaluate()
+		assert.Equal(t, 2, alerts)
+	})
+
+	t.Run("定期检测", func(t *testing.T) {
+		errs := NewRateCounter[int64]()
+		var alerts atomic.Int32
+		a := NewAlerter(func() float64 { return errs.Total() }, 100, func(e AlertEvent) {
+			alerts.Add(1)
+		}, WithEvaluateInterval(10*time.Millisecond))
+		a.Start()
+		a.Start()
+		errs.Add(101)
+		time.Sleep(50 * time.Millisecond)
+		a.Stop()
+		a.Stop()
+		assert.Equal(t, int32(1), alerts.Load())
+		assert.True(t, a.Firing())
+	})
+}
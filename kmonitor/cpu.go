@@ -0,0 +1,240 @@
+package kmonitor
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/mtgnorton/k/kcollection"
+	"github.com/mtgnorton/k/ktime"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrCPUUnsupported = errors.New("cpu usage is not supported on this platform")
+)
+
+// CPUMonitorOptions CPU使用率监控的配置项
+type CPUMonitorOptions struct {
+	Interval time.Duration // 采样间隔
+	Window   time.Duration // 滚动窗口的时长,即可查询的最长时间范围
+}
+
+type CPUMonitorOption func(*CPUMonitorOptions)
+
+func NewCPUMonitorOptions() *CPUMonitorOptions {
+	return &CPUMonitorOptions{
+		Interval: time.Second,
+		Window:   time.Minute,
+	}
+}
+
+// WithSampleInterval 设置采样间隔
+func WithSampleInterval(d time.Duration) CPUMonitorOption {
+	return func(o *CPUMonitorOptions) {
+		o.Interval = d
+	}
+}
+
+// WithSampleWindow 设置滚动窗口的时长
+func WithSampleWindow(d time.Duration) CPUMonitorOption {
+	return func(o *CPUMonitorOptions) {
+		o.Window = d
+	}
+}
+
+// cpuTimes 单个CPU核心的累计时间
+type cpuTimes struct {
+	busy  float64 // 非空闲时间
+	total float64 // 总时间
+}
+
+type usageWindow = kcollection.RollingWindow[float64, *kcollection.Bucket[float64]]
+
+// CPUMonitor CPU使用率监控,定期采样进程和每个核心的CPU使用率并保存在滚动窗口中
+type CPUMonitor struct {
+	mu       sync.RWMutex
+	opts     *CPUMonitorOptions
+	process  *usageWindow
+	cores    []*usageWindow // 不支持按核心采样时为nil
+	lastProc time.Duration  // 上次采样时进程的累计CPU时间
+	lastWall time.Time      // 上次采样的时间
+	lastCore []cpuTimes     // 上次采样时每个核心的累计时间
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewCPUMonitor 创建并启动CPU使用率监控
+//
+// 参数说明:
+//   - opts: 可选配置项,默认每秒采样一次,保留最近1分钟的数据
+//
+// 返回值:
+//   - *CPUMonitor: CPU使用率监控
+//   - error: 当前平台不支持采样进程CPU时间时返回 ErrCPUUnsupported,目前仅支持Linux
+//
+// 注意事项:
+//   - 使用率为0~100的百分比,进程使用率已按核心数归一化,100表示占满所有核心
+//   - 采样在独立的goroutine中进行,不再使用时需要调用 Stop
+//   - Interval和Window必须大于0,否则会panic
+//
+// 示例:
+//
+//	m, err := NewCPUMonitor()
+//	if err != nil {
+//	    return err
+//	}
+//	defer m.Stop()
+//	if m.Above(80, 10*time.Second) {
+//	    // 最近10秒CPU使用率平均超过80%,进行限流
+//	}
+func NewCPUMonitor(opts ...CPUMonitorOption) (*CPUMonitor, error) {
+	o := NewCPUMonitorOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.Interval <= 0 || o.Window <= 0 {
+		panic("interval and window must be greater than 0")
+	}
+	proc, err := processCPUTime()
+	if err != nil {
+		return nil, err
+	}
+	size := max(int(o.Window/o.Interval), 1)
+	m := &CPUMonitor{
+		opts:     o,
+		process:  newUsageWindow(size, o.Interval, ktime.RealClock),
+		lastProc: proc,
+		lastWall: time.Now(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if cores, err := coreCPUTimes(); err == nil {
+		m.lastCore = cores
+		m.cores = make([]*usageWindow, len(cores))
+		for i := range cores {
+			m.cores[i] = newUsageWindow(size, o.Interval, ktime.RealClock)
+		}
+	}
+	go m.run(m.stop)
+	return m, nil
+}
+
+func newUsageWindow(size int, interval time.Duration, clock ktime.Clock) *usageWindow {
+	return kcollection.NewRollingWindow(func() *kcollection.Bucket[float64] {
+		return &kcollection.Bucket[float64]{}
+	}, kcollection.WithSize[float64, *kcollection.Bucket[float64]](size),
+		kcollection.WithInterval[float64, *kcollection.Bucket[float64]](interval),
+		kcollection.WithClock[float64, *kcollection.Bucket[float64]](clock))
+}
+
+func (m *CPUMonitor) run(stop chan struct{}) {
+	defer close(m.done)
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.sample()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// sample 采样一次,计算与上次采样之间的使用率
+func (m *CPUMonitor) sample() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if proc, err := processCPUTime(); err == nil {
+		wall := now.Sub(m.lastWall)
+		if wall > 0 {
+			usage := float64(proc-m.lastProc) / float64(wall) / float64(runtime.NumCPU()) * 100
+			m.process.Add(min(usage, 100))
+		}
+		m.lastProc, m.lastWall = proc, now
+	}
+	if m.cores == nil {
+		return
+	}
+	cores, err := coreCPUTimes()
+	if err != nil || len(cores) != len(m.lastCore) {
+		return
+	}
+	for i, c := range cores {
+		total := c.total - m.lastCore[i].total
+		if total > 0 {
+			m.cores[i].Add((c.busy - m.lastCore[i].busy) / total * 100)
+		}
+	}
+	m.lastCore = cores
+}
+
+// Usage 返回最近一段时间进程的平均CPU使用率
+// 参数:
+//   - last: 可选的时间范围,默认为整个窗口
+//
+// 返回:
+//   - float64: 0~100的百分比,还没有采样数据时返回0
+func (m *CPUMonitor) Usage(last ...time.Duration) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return windowAvg(m.process, last...)
+}
+
+// CoreUsage 返回最近一段时间每个核心的平均CPU使用率
+// 参数:
+//   - last: 可选的时间范围,默认为整个窗口
+//
+// 返回:
+//   - []float64: 每个核心0~100的百分比,不支持按核心采样时返回nil
+func (m *CPUMonitor) CoreUsage(last ...time.Duration) []float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.cores == nil {
+		return nil
+	}
+	usage := make([]float64, len(m.cores))
+	for i, w := range m.cores {
+		usage[i] = windowAvg(w, last...)
+	}
+	return usage
+}
+
+// Above 判断最近一段时间进程的平均CPU使用率是否超过阈值
+// 参数:
+//   - threshold: 0~100的百分比阈值
+//   - last: 时间范围
+func (m *CPUMonitor) Above(threshold float64, last time.Duration) bool {
+	return m.Usage(last) > threshold
+}
+
+// Stop 停止采样,可以重复调用
+func (m *CPUMonitor) Stop() {
+	m.mu.Lock()
+	stop := m.stop
+	m.stop = nil
+	m.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-m.done
+}
+
+// windowAvg 计算窗口内最近一段时间所有值的平均值,和与数量在同一次遍历中计算
+func windowAvg(rw *usageWindow, last ...time.Duration) float64 {
+	var (
+		sum   float64
+		count int64
+	)
+	windowReduce(rw, func(b *kcollection.Bucket[float64]) {
+		sum += b.Sum
+		count += b.Count
+	}, last...)
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
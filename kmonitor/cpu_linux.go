@@ -0,0 +1,63 @@
+//go:build linux
+
+package kmonitor
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// processCPUTime 返回当前进程累计使用的CPU时间(用户态+内核态)
+func processCPUTime() (time.Duration, error) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, err
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano()), nil
+}
+
+// coreCPUTimes 从/proc/stat读取每个核心的累计时间
+func coreCPUTimes() ([]cpuTimes, error) {
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cores []cpuTimes
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// 格式: cpu0 user nice system idle iowait irq softirq steal ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "cpu") || fields[0] == "cpu" {
+			continue
+		}
+		var t cpuTimes
+		for i, field := range fields[1:] {
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, err
+			}
+			// guest和guest_nice已包含在user和nice中
+			if i >= 8 {
+				break
+			}
+			t.total += v
+			if i != 3 && i != 4 { // idle和iowait为空闲时间
+				t.busy += v
+			}
+		}
+		cores = append(cores, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(cores) == 0 {
+		return nil, ErrCPUUnsupported
+	}
+	return cores, nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package kmonitor
+
+import "time"
+
+// processCPUTime 当前平台不支持采样进程CPU时间
+func processCPUTime() (time.Duration, error) {
+	return 0, ErrCPUUnsupported
+}
+
+// coreCPUTimes 当前平台不支持按核心采样
+func coreCPUTimes() ([]cpuTimes, error) {
+	return nil, ErrCPUUnsupported
+}
@@ -0,0 +1,46 @@
+package kmonitor
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPUMonitor(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		_, err := NewCPUMonitor()
+		assert.ErrorIs(t, err, ErrCPUUnsupported)
+		return
+	}
+
+	const interval = 20 * time.Millisecond
+	m, err := NewCPUMonitor(WithSampleInterval(interval), WithSampleWindow(time.Second))
+	assert.NoError(t, err)
+	defer m.Stop()
+
+	assert.Equal(t, 0.0, m.Usage(), "还没有采样数据")
+
+	// 占用CPU一段时间
+	deadline := time.Now().Add(interval * 10)
+	for time.Now().Before(deadline) {
+	}
+
+	usage := m.Usage()
+	assert.Greater(t, usage, 0.0)
+	assert.LessOrEqual(t, usage, 100.0)
+	assert.True(t, m.Above(0, time.Second))
+	assert.False(t, m.Above(100, time.Second))
+
+	cores := m.CoreUsage(interval * 5)
+	assert.NotEmpty(t, cores)
+	for _, c := range cores {
+		assert.GreaterOrEqual(t, c, 0.0)
+		assert.LessOrEqual(t, c, 100.0)
+	}
+
+	m.Stop()
+	m.Stop() // 重复调用无影响
+	assert.Panics(t, func() { NewCPUMonitor(WithSampleInterval(0)) })
+}
@@ -20,6 +20,20 @@ func ExampleSampling() {
 	// 9
 }
 
+func ExampleSamplingBatch() {
+	rch, clear := SamplingBatch(time.Second, 5, func(batch []int) {
+		fmt.Println(batch)
+	})
+
+	for i := 0; i < 5; i++ {
+		rch <- i
+	}
+	time.Sleep(100 * time.Millisecond)
+	clear()
+	// Output:
+	// [0 1 2 3 4]
+}
+
 func ExampleConsumeTimeStatistics() {
 	stats := ConsumeTimeStatistics("MyProcess")
 
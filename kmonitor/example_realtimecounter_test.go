@@ -2,11 +2,12 @@ package kmonitor
 
 import (
 	"fmt"
+	"time"
 )
 
 // 示例1: 使用实时计数器统计QPS
 func Example_qps() {
-	// 创建一个每秒回调的实时计数器
+	// 创建一个实时计数器
 	counter := NewRealtimeCounter[int64]()
 
 	// 模拟请求
@@ -21,7 +22,7 @@ func Example_qps() {
 
 // 示例2: 使用实时计数器统计内存使用
 func Example_memoryUsage() {
-	// 创建一个每5秒回调的实时计数器
+	// 创建一个实时计数器
 	counter := NewRealtimeCounter[int64]()
 
 	// 模拟内存分配和释放
@@ -32,3 +33,23 @@ func Example_memoryUsage() {
 	// Output:
 	// 73400320
 }
+
+// 示例3: 定时回调输出QPS,回调后重置计数
+func Example_report() {
+	reports := make(chan int64, 1)
+	counter := NewRealtimeCounterWithReport(100*time.Millisecond, func(v int64) {
+		select {
+		case reports <- v:
+		default:
+		}
+	}, true)
+	defer counter.Stop()
+
+	// 模拟请求
+	counter.Add(1)
+	counter.Add(1)
+
+	fmt.Println(<-reports)
+	// Output:
+	// 2
+}
@@ -0,0 +1,333 @@
+package kmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mtgnorton/k/kbase"
+	"github.com/pkg/errors"
+)
+
+// HealthStatus 健康状态
+type HealthStatus int
+
+const (
+	HealthHealthy  HealthStatus = iota // 健康
+	HealthDegraded                     // 降级,非关键检查失败
+	HealthDown                         // 不可用,关键检查失败
+)
+
+// String 返回健康状态的名称
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthHealthy:
+		return "healthy"
+	case HealthDegraded:
+		return "degraded"
+	case HealthDown:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON 以健康状态的名称编码为JSON
+func (s HealthStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON 从健康状态的名称解码
+func (s *HealthStatus) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for _, status := range []HealthStatus{HealthHealthy, HealthDegraded, HealthDown} {
+		if status.String() == name {
+			*s = status
+			return nil
+		}
+	}
+	return errors.Errorf("unknown health status: %s", name)
+}
+
+// HealthCheckFunc 健康检查函数,返回nil表示健康,需要在ctx结束时尽快返回
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthCheckOptions 单个健康检查的配置项
+type HealthCheckOptions struct {
+	Timeout  time.Duration // 检查的超时时间
+	Critical bool          // 是否为关键检查,关键检查失败时整体状态为down,否则为degraded
+}
+
+type HealthCheckOption func(*HealthCheckOptions)
+
+func NewHealthCheckOptions() *HealthCheckOptions {
+	return &HealthCheckOptions{
+		Timeout:  5 * time.Second,
+		Critical: true,
+	}
+}
+
+// WithCheckTimeout 设置检查的超时时间
+func WithCheckTimeout(d time.Duration) HealthCheckOption {
+	return func(o *HealthCheckOptions) {
+		o.Timeout = d
+	}
+}
+
+// WithCritical 设置是否为关键检查
+func WithCritical(critical bool) HealthCheckOption {
+	return func(o *HealthCheckOptions) {
+		o.Critical = critical
+	}
+}
+
+// CheckResult 单个健康检查的结果
+type CheckResult struct {
+	Status    HealthStatus  `json:"status"`          // 检查的状态
+	Error     string        `json:"error,omitempty"` // 检查失败的原因
+	Duration  time.Duration `json:"duration"`        // 检查的耗时
+	CheckedAt time.Time     `json:"checked_at"`      // 检查的时间
+}
+
+// HealthReport 健康检查报告
+type HealthReport struct {
+	Status HealthStatus           `json:"status"` // 整体状态,取所有检查中最差的状态
+	Checks map[string]CheckResult `json:"checks"` // 每个检查的结果
+}
+
+type healthCheck struct {
+	fn   HealthCheckFunc
+	opts *HealthCheckOptions
+}
+
+// HealthChecker 健康检查器,汇总各组件注册的健康检查,可按需或定期执行,并提供存活/就绪探针的 http.Handler
+type HealthChecker struct {
+	mu     sync.RWMutex
+	checks map[string]*healthCheck
+	last   *HealthReport // 最近一次检查的报告,定期检查时使用
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewHealthChecker 创建一个新的健康检查器
+//
+// 注意事项:
+//   - 线程安全
+//   - 所有检查并发执行,每个检查有独立的超时时间
+//   - 未注册任何检查时状态为healthy
+//
+// 示例:
+//
+//	hc := NewHealthChecker()
+//	hc.Register("mysql", func(ctx context.Context) error {
+//	    return db.PingContext(ctx)
+//	}, WithCheckTimeout(time.Second))
+//	hc.Register("cache", func(ctx context.Context) error {
+//	    return redis.Ping(ctx).Err()
+//	}, WithCritical(false))
+//	hc.Start(10 * time.Second)
+//	defer hc.Stop()
+//	http.Handle("/healthz", hc.LivenessHandler())
+//	http.Handle("/readyz", hc)
+func NewHealthChecker() *HealthChecker {
+	return &HealthChecker{
+		checks: make(map[string]*healthCheck),
+	}
+}
+
+// Register 注册健康检查,同名检查会被覆盖
+// 参数:
+//   - name: 检查名称
+//   - fn: 检查函数
+//   - opts: 可选配置项,默认超时5秒,为关键检查
+func (h *HealthChecker) Register(name string, fn HealthCheckFunc, opts ...HealthCheckOption) {
+	o := NewHealthCheckOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = &healthCheck{fn: fn, opts: o}
+}
+
+// Unregister 注销健康检查
+func (h *HealthChecker) Unregister(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.checks, name)
+}
+
+// Names 按字母顺序返回所有检查名称
+func (h *HealthChecker) Names() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check 立即并发执行所有检查并返回报告
+// 参数:
+//   - ctx: 上下文,取消后尚未完成的检查视为失败
+func (h *HealthChecker) Check(ctx context.Context) HealthReport {
+	h.mu.RLock()
+	checks := make(map[string]*healthCheck, len(h.checks))
+	for name, c := range h.checks {
+		checks[name] = c
+	}
+	h.mu.RUnlock()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report = HealthReport{Status: HealthHealthy, Checks: make(map[string]CheckResult, len(checks))}
+	)
+	for name, c := range checks {
+		wg.Add(1)
+		go func(name string, c *healthCheck) {
+			defer wg.Done()
+			result := runHealthCheck(ctx, c)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			report.Status = max(report.Status, result.Status)
+		}(name, c)
+	}
+	wg.Wait()
+
+	h.mu.Lock()
+	h.last = &report
+	h.mu.Unlock()
+	return report
+}
+
+// runHealthCheck 在超时时间内执行单个检查
+func runHealthCheck(ctx context.Context, c *healthCheck) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() {
+			errCh <- err
+		}()
+		defer kbase.Recover(&err)
+		err = c.fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = errors.Wrap(ctx.Err(), "health check timeout")
+	}
+	result := CheckResult{Status: HealthHealthy, Duration: time.Since(start), CheckedAt: start}
+	if err != nil {
+		result.Error = err.Error()
+		result.Status = HealthDegraded
+		if c.opts.Critical {
+			result.Status = HealthDown
+		}
+	}
+	return result
+}
+
+// Report 返回最近一次检查的报告
+// 注意:
+//   - 还没有执行过检查时会立即执行一次
+func (h *HealthChecker) Report() HealthReport {
+	h.mu.RLock()
+	last := h.last
+	h.mu.RUnlock()
+	if last != nil {
+		return *last
+	}
+	return h.Check(context.Background())
+}
+
+// Start 启动定期检查,会立即执行一次,重复调用无效果
+// 参数:
+//   - interval: 检查间隔,必须大于0,否则会panic
+func (h *HealthChecker) Start(interval time.Duration) {
+	if interval <= 0 {
+		panic("interval must be greater than 0")
+	}
+	h.mu.Lock()
+	if h.stop != nil {
+		h.mu.Unlock()
+		return
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	h.stop, h.done = stop, done
+	h.mu.Unlock()
+
+	h.Check(context.Background())
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				h.Check(context.Background())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定期检查并等待其退出,未启动时无效果
+func (h *HealthChecker) Stop() {
+	h.mu.Lock()
+	stop, done := h.stop, h.done
+	h.stop, h.done = nil, nil
+	h.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// ServeHTTP 就绪探针,以JSON的形式输出健康检查报告
+//
+// 注意事项:
+//   - 启动了定期检查时输出最近一次的报告,否则按需执行所有检查
+//   - 状态为healthy或degraded时返回200,为down时返回503
+func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	h.mu.RLock()
+	periodic := h.stop != nil
+	h.mu.RUnlock()
+
+	var report HealthReport
+	if periodic {
+		report = h.Report()
+	} else {
+		report = h.Check(req.Context())
+	}
+	code := http.StatusOK
+	if report.Status == HealthDown {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, report)
+}
+
+// LivenessHandler 返回存活探针的 http.Handler
+// 只要进程能处理请求就返回200,不执行任何检查,避免依赖故障导致进程被重启
+func (h *HealthChecker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]HealthStatus{"status": HealthHealthy})
+	})
+}
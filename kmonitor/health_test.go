@@ -0,0 +1,113 @@
+package kmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStatus(t *testing.T) {
+	assert.Equal(t, "healthy", HealthHealthy.String())
+	assert.Equal(t, "degraded", HealthDegraded.String())
+	assert.Equal(t, "down", HealthDown.String())
+	assert.Equal(t, "unknown", HealthStatus(10).String())
+
+	data, err := json.Marshal(HealthDegraded)
+	assert.NoError(t, err)
+	assert.Equal(t, `"degraded"`, string(data))
+	var s HealthStatus
+	assert.NoError(t, json.Unmarshal([]byte(`"down"`), &s))
+	assert.Equal(t, HealthDown, s)
+	assert.Error(t, json.Unmarshal([]byte(`"bad"`), &s))
+}
+
+func TestHealthChecker(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	fail := func(ctx context.Context) error { return errors.New("connection refused") }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	t.Run("汇总状态", func(t *testing.T) {
+		hc := NewHealthChecker()
+		assert.Equal(t, HealthHealthy, hc.Check(context.Background()).Status)
+
+		hc.Register("db", ok)
+		assert.Equal(t, HealthHealthy, hc.Check(context.Background()).Status)
+
+		hc.Register("cache", fail, WithCritical(false))
+		report := hc.Check(context.Background())
+		assert.Equal(t, HealthDegraded, report.Status)
+		assert.Equal(t, "connection refused", report.Checks["cache"].Error)
+		assert.Equal(t, HealthHealthy, report.Checks["db"].Status)
+
+		hc.Register("mq", slow, WithCheckTimeout(20*time.Millisecond))
+		report = hc.Check(context.Background())
+		assert.Equal(t, HealthDown, report.Status)
+		assert.Contains(t, report.Checks["mq"].Error, "timeout")
+		assert.Equal(t, []string{"cache", "db", "mq"}, hc.Names())
+
+		hc.Unregister("mq")
+		assert.Equal(t, HealthDegraded, hc.Check(context.Background()).Status)
+	})
+
+	t.Run("检查函数panic", func(t *testing.T) {
+		hc := NewHealthChecker()
+		hc.Register("bad", func(ctx context.Context) error { panic("boom") })
+		report := hc.Check(context.Background())
+		assert.Equal(t, HealthDown, report.Status)
+		assert.Contains(t, report.Checks["bad"].Error, "boom")
+	})
+
+	t.Run("定期检查", func(t *testing.T) {
+		var calls atomic.Int32
+		hc := NewHealthChecker()
+		hc.Register("db", func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})
+		hc.Start(20 * time.Millisecond)
+		hc.Start(20 * time.Millisecond) // 重复调用无效果
+		assert.Equal(t, int32(1), calls.Load(), "启动时立即执行一次")
+		time.Sleep(70 * time.Millisecond)
+		hc.Stop()
+		hc.Stop()
+		n := calls.Load()
+		assert.GreaterOrEqual(t, n, int32(3))
+
+		// 读取报告不会触发检查
+		assert.Equal(t, HealthHealthy, hc.Report().Status)
+		assert.Equal(t, n, calls.Load())
+	})
+
+	t.Run("就绪与存活探针", func(t *testing.T) {
+		hc := NewHealthChecker()
+		hc.Register("db", fail)
+
+		rec := httptest.NewRecorder()
+		hc.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var report HealthReport
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, HealthDown, report.Status)
+		assert.Equal(t, HealthDown, report.Checks["db"].Status)
+
+		rec = httptest.NewRecorder()
+		hc.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"status":"healthy"}`, rec.Body.String())
+
+		hc.Register("db", ok)
+		rec = httptest.NewRecorder()
+		hc.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,115 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/mtgnorton/k/kcollection"
+	"github.com/mtgnorton/k/kmath"
+)
+
+// Histogram 基于滑动窗口的直方图指标,用于统计请求大小、延迟等数值的分布
+// 只统计窗口时间范围内的观测值,过期的桶会随时间滚出窗口,实现按窗口衰减
+type Histogram[T kmath.Number] struct {
+	bounds []T
+	window *kcollection.RollingWindow[T, *kcollection.HistogramBucket[T]]
+}
+
+// HistogramSnapshot 直方图指标的快照
+type HistogramSnapshot struct {
+	Bounds []float64 `json:"bounds"` // 桶的上边界
+	Counts []int64   `json:"counts"` // 每个桶的计数,长度为len(Bounds)+1,最后一个为溢出桶
+	Count  int64     `json:"count"`  // 观测值总数
+	Sum    float64   `json:"sum"`    // 观测值总和
+	Min    float64   `json:"min"`    // 最小观测值
+	Max    float64   `json:"max"`    // 最大观测值
+	P50    float64   `json:"p50"`    // 50分位数
+	P90    float64   `json:"p90"`    // 90分位数
+	P99    float64   `json:"p99"`    // 99分位数
+}
+
+// NewHistogram 创建一个新的直方图指标
+// 参数:
+//   - bounds: 桶的上边界,必须严格递增,参见 kmath.NewHistogram
+//   - opts: 可选配置项,包括窗口大小、时间间隔等,默认统计最近10分钟
+//
+// 返回:
+//   - *Histogram[T]: 新创建的直方图指标
+//
+// 注意:
+//   - bounds为空或不是严格递增时会panic
+//   - 线程安全
+//
+// 示例:
+//
+//	h := NewHistogram([]int64{1024, 4096, 16384, 65536},
+//	    kcollection.WithSize[int64, *kcollection.HistogramBucket[int64]](60),
+//	    kcollection.WithInterval[int64, *kcollection.HistogramBucket[int64]](time.Second))
+//	h.Observe(2048)
+//	p99 := h.Percentile(99)
+func NewHistogram[T kmath.Number](bounds []T, opts ...kcollection.RollingWindowOption[T, *kcollection.HistogramBucket[T]]) *Histogram[T] {
+	// 提前校验并复制bounds,避免在创建桶时才panic
+	bounds = kmath.NewHistogram(bounds).Bounds()
+	return &Histogram[T]{
+		bounds: bounds,
+		window: kcollection.NewRollingWindow(func() *kcollection.HistogramBucket[T] {
+			return kcollection.NewHistogramBucket(bounds)
+		}, opts...),
+	}
+}
+
+// Observe 记录一个观测值
+func (h *Histogram[T]) Observe(v T) {
+	h.window.Add(v)
+}
+
+// Bounds 返回桶的上边界
+func (h *Histogram[T]) Bounds() []T {
+	return slices.Clone(h.bounds)
+}
+
+// Merged 返回窗口内所有观测值合并后的直方图
+// 返回:
+//   - *kmath.Histogram[T]: 合并后的直方图副本,修改它不影响指标
+func (h *Histogram[T]) Merged() *kmath.Histogram[T] {
+	merged := kcollection.ReduceHistogram(h.window)
+	if merged == nil {
+		return kmath.NewHistogram(h.bounds)
+	}
+	return merged
+}
+
+// Count 返回窗口内的观测值数量
+func (h *Histogram[T]) Count() int64 {
+	return h.Merged().Count()
+}
+
+// Percentile 返回窗口内观测值第p百分位数的近似值,参见 kmath.Histogram.Percentile
+func (h *Histogram[T]) Percentile(p float64) float64 {
+	return h.Merged().Percentile(p)
+}
+
+// Snapshot 返回窗口内观测值分布的快照
+func (h *Histogram[T]) Snapshot() HistogramSnapshot {
+	merged := h.Merged()
+	bounds := make([]float64, len(h.bounds))
+	for i, b := range h.bounds {
+		bounds[i] = float64(b)
+	}
+	return HistogramSnapshot{
+		Bounds: bounds,
+		Counts: merged.Counts(),
+		Count:  merged.Count(),
+		Sum:    float64(merged.Sum()),
+		Min:    float64(merged.Min()),
+		Max:    float64(merged.Max()),
+		P50:    merged.Percentile(50),
+		P90:    merged.Percentile(90),
+		P99:    merged.Percentile(99),
+	}
+}
+
+// MarshalJSON 将直方图的快照编码为JSON,参见 Snapshot
+func (h *Histogram[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.Snapshot())
+}
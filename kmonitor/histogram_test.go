@@ -0,0 +1,57 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mtgnorton/k/kcollection"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Run("统计分布", func(t *testing.T) {
+		h := NewHistogram([]int64{10, 100, 1000})
+		for i := int64(1); i <= 100; i++ {
+			h.Observe(i)
+		}
+		h.Observe(5000)
+
+		assert.Equal(t, []int64{10, 100, 1000}, h.Bounds())
+		assert.Equal(t, int64(101), h.Count())
+		assert.Equal(t, 1.0, h.Percentile(0))
+		assert.Equal(t, 5000.0, h.Percentile(100))
+
+		s := h.Snapshot()
+		assert.Equal(t, []float64{10, 100, 1000}, s.Bounds)
+		assert.Equal(t, []int64{10, 90, 0, 1}, s.Counts)
+		assert.Equal(t, int64(101), s.Count)
+		assert.Equal(t, 10050.0, s.Sum)
+		assert.Equal(t, 1.0, s.Min)
+		assert.Equal(t, 5000.0, s.Max)
+		assert.InDelta(t, 50, s.P50, 5)
+
+		data, err := json.Marshal(h)
+		assert.NoError(t, err)
+		var decoded HistogramSnapshot
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, s, decoded)
+	})
+
+	t.Run("按窗口衰减", func(t *testing.T) {
+		const interval = 50 * time.Millisecond
+		h := NewHistogram([]float64{1, 2},
+			kcollection.WithSize[float64, *kcollection.HistogramBucket[float64]](2),
+			kcollection.WithInterval[float64, *kcollection.HistogramBucket[float64]](interval))
+		h.Observe(1.5)
+		assert.Equal(t, int64(1), h.Count())
+		time.Sleep(interval * 3)
+		assert.Equal(t, int64(0), h.Count())
+		assert.Equal(t, []int64{0, 0, 0}, h.Snapshot().Counts)
+	})
+
+	t.Run("非法边界", func(t *testing.T) {
+		assert.Panics(t, func() { NewHistogram([]int{}) })
+		assert.Panics(t, func() { NewHistogram([]int{2, 1}) })
+	})
+}
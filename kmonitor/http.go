@@ -0,0 +1,63 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+)
+
+// ServeHTTP 以JSON的形式输出所有指标的当前值,key为指标标识,参见 MetricID
+//
+// 注意事项:
+//   - 支持通过 name 查询参数只输出单个指标,如 /debug/kmonitor?name=qps,指标不存在时返回404
+//   - 带标签的指标需要使用完整的指标标识查询,如 name=rpc{method="GET"}
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body any
+	if id := req.URL.Query().Get("name"); id != "" {
+		r.mu.RLock()
+		e, ok := r.metrics[id]
+		r.mu.RUnlock()
+		if !ok {
+			http.Error(w, "metric not found: "+id, http.StatusNotFound)
+			return
+		}
+		body = metricValue(e.metric)
+	} else {
+		body = r.Values()
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+// PublishExpvar 将注册表发布到expvar,可通过 /debug/vars 查看
+// 参数:
+//   - name: expvar中的变量名
+//
+// 注意:
+//   - 与 expvar.Publish 一致,同一个name重复发布会panic
+func (r *Registry) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return r.Values()
+	}))
+}
+
+// Handler 返回输出默认注册表的 http.Handler
+//
+// 示例:
+//
+//	http.Handle("/debug/kmonitor", kmonitor.Handler())
+func Handler() http.Handler {
+	return DefaultRegistry
+}
+
+// writeJSON 以JSON的形式输出body
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	data, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
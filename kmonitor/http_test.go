@@ -0,0 +1,89 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	qps := NewRealtimeCounter[int64]()
+	qps.Add(3)
+	rpc := NewRollingResultCounter[int64]()
+	rpc.AddSuccess(10)
+	rpc.AddFail(20)
+	tc := NewTimeoutController()
+	end := tc.Do(time.Hour, func() {})
+	defer end()
+
+	r := NewRegistry()
+	r.Register("qps", qps)
+	r.Register("rpc", rpc)
+	r.Register("timeout", tc)
+	r.Register("version", func() any { return "v1" })
+	r.Register("tmp", 1)
+	r.Unregister("tmp")
+	assert.Equal(t, []string{"qps", "rpc", "timeout", "version"}, r.Names())
+
+	t.Run("输出所有指标", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/kmonitor", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+
+		var body struct {
+			QPS int64 `json:"qps"`
+			RPC struct {
+				Success LatencyStats `json:"success"`
+				Fail    LatencyStats `json:"fail"`
+			} `json:"rpc"`
+			Timeout struct {
+				Active int `json:"active"`
+			} `json:"timeout"`
+			Version string `json:"version"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, int64(3), body.QPS)
+		assert.Equal(t, int64(1), body.RPC.Success.Count)
+		assert.Equal(t, 20.0, body.RPC.Fail.Max)
+		assert.Equal(t, 1, body.Timeout.Active)
+		assert.Equal(t, "v1", body.Version)
+	})
+
+	t.Run("按名称输出单个指标", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/kmonitor?name=qps", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, "3", rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/kmonitor?name=none", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("发布到expvar", func(t *testing.T) {
+		r.PublishExpvar("kmonitor_test")
+		v := expvar.Get("kmonitor_test")
+		assert.NotNil(t, v)
+		var body map[string]json.RawMessage
+		assert.NoError(t, json.Unmarshal([]byte(v.String()), &body))
+		assert.JSONEq(t, "3", string(body["qps"]))
+	})
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	Register("default_test", 1)
+	defer DefaultRegistry.Unregister("default_test")
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var body map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Contains(t, body, "timeout")
+	assert.Contains(t, body, "default_test")
+}
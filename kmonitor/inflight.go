@@ -0,0 +1,167 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/mtgnorton/k/kcollection"
+)
+
+// InFlightOptions 在途请求统计的配置项
+type InFlightOptions struct {
+	Size     int           // 滑动窗口的桶数量
+	Interval time.Duration // 每个桶的时间间隔,Size*Interval为峰值和耗时统计的时间范围
+	Bounds   []int64       // 耗时直方图的桶边界,单位毫秒
+}
+
+type InFlightOption func(*InFlightOptions)
+
+// NewInFlightOptions 默认统计最近1分钟,耗时边界参见 RollingResultCounter
+func NewInFlightOptions() *InFlightOptions {
+	return &InFlightOptions{
+		Size:     60,
+		Interval: time.Second,
+		Bounds:   defaultLatencyBounds[int64](),
+	}
+}
+
+// WithInFlightWindow 设置滑动窗口的桶数量和每个桶的时间间隔
+func WithInFlightWindow(size int, interval time.Duration) InFlightOption {
+	return func(o *InFlightOptions) {
+		o.Size = size
+		o.Interval = interval
+	}
+}
+
+// WithInFlightBounds 设置耗时直方图的桶边界,单位毫秒
+func WithInFlightBounds(bounds []int64) InFlightOption {
+	return func(o *InFlightOptions) {
+		o.Bounds = bounds
+	}
+}
+
+// InFlightSnapshot 在途请求统计的快照
+type InFlightSnapshot struct {
+	Current int64        `json:"current"` // 当前并发数
+	Peak    int64        `json:"peak"`    // 窗口内的峰值并发数
+	Latency LatencyStats `json:"latency"` // 窗口内已完成请求的耗时分布,单位毫秒
+}
+
+// InFlight 在途请求统计,记录当前并发数、窗口内的峰值并发数和请求耗时的分布
+type InFlight struct {
+	current atomic.Int64
+	peak    *kcollection.RollingWindow[int64, *kcollection.MinMaxBucket[int64]]
+	latency *Histogram[int64]
+}
+
+// NewInFlight 创建一个新的在途请求统计
+//
+// 参数说明:
+//   - opts: 可选配置项,参见 InFlightOptions
+//
+// 注意事项:
+//   - 线程安全
+//   - 峰值只在进入时记录,窗口内没有新请求时峰值取当前并发数
+//   - 耗时在退出时记录,仍在进行中的请求不计入
+//
+// 示例:
+//
+//	inflight := NewInFlight()
+//	http.Handle("/api", inflight.Handler(apiHandler))
+//
+//	exit := inflight.Enter()
+//	defer exit()
+//
+//	resultCh, cancel := kslice.LoopConcAsync(data, InFlightFunc(inflight, process), 10)
+func NewInFlight(opts ...InFlightOption) *InFlight {
+	o := NewInFlightOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &InFlight{
+		peak: kcollection.NewRollingWindow(func() *kcollection.MinMaxBucket[int64] {
+			return &kcollection.MinMaxBucket[int64]{}
+		}, kcollection.WithSize[int64, *kcollection.MinMaxBucket[int64]](o.Size),
+			kcollection.WithInterval[int64, *kcollection.MinMaxBucket[int64]](o.Interval)),
+		latency: NewHistogram(o.Bounds,
+			kcollection.WithSize[int64, *kcollection.HistogramBucket[int64]](o.Size),
+			kcollection.WithInterval[int64, *kcollection.HistogramBucket[int64]](o.Interval)),
+	}
+}
+
+// Enter 记录一个请求进入
+// 返回:
+//   - exit: 请求结束时调用,记录耗时并减少并发数,重复调用无效果
+func (f *InFlight) Enter() (exit func()) {
+	start := time.Now()
+	f.peak.Add(f.current.Add(1))
+	var exited atomic.Bool
+	return func() {
+		if !exited.CompareAndSwap(false, true) {
+			return
+		}
+		f.current.Add(-1)
+		f.latency.Observe(time.Since(start).Milliseconds())
+	}
+}
+
+// Track 执行fn并记录为一个在途请求
+func (f *InFlight) Track(fn func()) {
+	exit := f.Enter()
+	defer exit()
+	fn()
+}
+
+// Handler 返回记录在途请求的 http.Handler 中间件
+func (f *InFlight) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		exit := f.Enter()
+		defer exit()
+		next.ServeHTTP(w, req)
+	})
+}
+
+// InFlightFunc 包装exec,每次执行都记录为一个在途请求,可直接用于 kslice.LoopConcAsync 等函数
+func InFlightFunc[T, V any](f *InFlight, exec func(T) (V, error)) func(T) (V, error) {
+	return func(item T) (V, error) {
+		exit := f.Enter()
+		defer exit()
+		return exec(item)
+	}
+}
+
+// Current 返回当前并发数
+func (f *InFlight) Current() int64 {
+	return f.current.Load()
+}
+
+// Peak 返回窗口内的峰值并发数
+func (f *InFlight) Peak() int64 {
+	current := f.current.Load()
+	_, peak, ok := kcollection.ReduceMinMax(f.peak)
+	if !ok {
+		return current
+	}
+	return max(peak, current)
+}
+
+// Latency 返回窗口内已完成请求的耗时分布,单位毫秒
+func (f *InFlight) Latency() LatencyStats {
+	return latencyStats(f.latency.Merged())
+}
+
+// Snapshot 返回在途请求统计的快照
+func (f *InFlight) Snapshot() InFlightSnapshot {
+	return InFlightSnapshot{
+		Current: f.Current(),
+		Peak:    f.Peak(),
+		Latency: f.Latency(),
+	}
+}
+
+// MarshalJSON 将快照编码为JSON,参见 Snapshot
+func (f *InFlight) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Snapshot())
+}
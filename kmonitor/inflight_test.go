@@ -0,0 +1,94 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlight(t *testing.T) {
+	t.Run("并发数与峰值", func(t *testing.T) {
+		f := NewInFlight()
+		assert.Equal(t, int64(0), f.Peak())
+
+		exits := make([]func(), 3)
+		for i := range exits {
+			exits[i] = f.Enter()
+		}
+		assert.Equal(t, int64(3), f.Current())
+		exits[0]()
+		exits[0]()
+		exits[1]()
+		assert.Equal(t, int64(1), f.Current(), "重复调用exit无效果")
+		assert.Equal(t, int64(3), f.Peak())
+		exits[2]()
+		assert.Equal(t, int64(3), f.Latency().Count)
+	})
+
+	t.Run("峰值随窗口过期", func(t *testing.T) {
+		f := NewInFlight(WithInFlightWindow(2, 50*time.Millisecond))
+		exit1, exit2 := f.Enter(), f.Enter()
+		exit1()
+		assert.Equal(t, int64(2), f.Peak())
+		time.Sleep(150 * time.Millisecond)
+		// 窗口内没有新请求,取当前并发数
+		assert.Equal(t, int64(1), f.Peak())
+		exit2()
+		assert.Equal(t, int64(0), f.Peak())
+	})
+
+	t.Run("耗时分布", func(t *testing.T) {
+		f := NewInFlight()
+		f.Track(func() { time.Sleep(30 * time.Millisecond) })
+		stats := f.Latency()
+		assert.Equal(t, int64(1), stats.Count)
+		assert.GreaterOrEqual(t, stats.Max, 30.0)
+
+		data, err := json.Marshal(f)
+		assert.NoError(t, err)
+		var snapshot InFlightSnapshot
+		assert.NoError(t, json.Unmarshal(data, &snapshot))
+		assert.Equal(t, int64(1), snapshot.Peak)
+		assert.Equal(t, int64(1), snapshot.Latency.Count)
+	})
+
+	t.Run("HTTP中间件", func(t *testing.T) {
+		f := NewInFlight()
+		var current int64
+		h := f.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			current = f.Current()
+		}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, int64(1), current)
+		assert.Equal(t, int64(0), f.Current())
+	})
+
+	t.Run("包装函数", func(t *testing.T) {
+		f := NewInFlight()
+		release := make(chan struct{})
+		exec := InFlightFunc(f, func(n int) (int, error) {
+			<-release
+			return n * 2, nil
+		})
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				v, err := exec(i)
+				assert.NoError(t, err)
+				assert.Equal(t, i*2, v)
+			}(i)
+		}
+		assert.Eventually(t, func() bool { return f.Current() == 5 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int64(0), f.Current())
+		assert.Equal(t, int64(5), f.Peak())
+	})
+}
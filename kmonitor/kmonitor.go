@@ -1,30 +1,78 @@
 // kmonitor 提供用于监控和统计的工具函数
-// 采样: 对输入数据进行采样处理
-// 统计: 统计任务执行时间
+// 采样: 对输入数据进行采样处理或批量收集
+// 统计: 统计任务执行时间,支持嵌套的阶段
 // 超时: 监控超时
+// SLA: 统计多个窗口的可用率和错误预算消耗速率
+// 注册: 按名称和标签注册、查找和遍历指标
+// 暴露: 通过HTTP或expvar以JSON的形式输出指标
 package kmonitor
 
 import (
 	"fmt"
+	"math/rand/v2"
 	"time"
 )
 
+// SamplingStrategy 采样策略
+type SamplingStrategy int
+
+const (
+	SamplingTrigger     SamplingStrategy = iota // 每达到采样数量或时间间隔时采样当前数据,默认策略
+	SamplingReservoir                           // 蓄水池采样,在每个采样周期内等概率地保留固定数量的数据,周期结束时处理
+	SamplingProbability                         // 概率采样,每条数据按固定概率独立地被采样
+)
+
+// SamplingOptions 采样的配置项
+type SamplingOptions struct {
+	Strategy      SamplingStrategy // 采样策略
+	ReservoirSize int              // 蓄水池采样时每个周期保留的数据数量
+	Probability   float64          // 概率采样时每条数据被采样的概率,取值0~1
+}
+
+type SamplingOption func(*SamplingOptions)
+
+func NewSamplingOptions() *SamplingOptions {
+	return &SamplingOptions{
+		Strategy: SamplingTrigger,
+	}
+}
+
+// WithReservoir 使用蓄水池采样,每个采样周期内等概率地保留size条数据,size必须大于0
+// 采样周期由 Sampling 的duration和amount决定,周期结束时处理保留的数据
+func WithReservoir(size int) SamplingOption {
+	return func(o *SamplingOptions) {
+		o.Strategy = SamplingReservoir
+		o.ReservoirSize = size
+	}
+}
+
+// WithProbability 使用概率采样,每条数据以probability的概率被采样,如0.01表示采样1%的数据
+// 该策略忽略 Sampling 的duration和amount参数
+func WithProbability(probability float64) SamplingOption {
+	return func(o *SamplingOptions) {
+		o.Strategy = SamplingProbability
+		o.Probability = probability
+	}
+}
+
 // Sampling 对输入数据进行采样处理
 //
 // 参数说明:
 //   - duration: 采样时间间隔，如果为0则只根据数量触发
 //   - amount: 采样数量，如果为0则只根据时间触发
 //   - exec: 处理采样数据的函数
+//   - opts: 可选配置项,用于选择采样策略,默认每达到数量或时间间隔时采样当前数据
 //
 // 返回值说明:
 //   - rch: 用于接收数据的通道
 //   - clear: 用于关闭采样和清理资源的函数
 //
 // 注意事项:
-//   - duration和amount不能同时为0
+//   - 除概率采样外，duration和amount不能同时为0
+//   - 默认策略总是采样周期内最后一条数据，数据突发时会偏向突发的数据，需要均匀采样时使用 WithReservoir 或 WithProbability
 //   - 使用带缓冲的信号量控制并发，最大并发数为100
 //   - 当达到采样条件时，会重置计数器和时间
-//   - 需要调用clear函数来关闭通道和清理资源
+//   - 需要调用clear函数来关闭通道和清理资源，蓄水池中尚未处理的数据会在关闭时处理
 //
 // 示例:
 //
@@ -33,22 +81,75 @@ import (
 //	})
 //	defer clear()
 //	rch <- 1
-func Sampling[T any](duration time.Duration, amount int, exec func(T)) (rch chan<- T, clear func()) {
-	ch := make(chan T)
-	sem := make(chan struct{}, 100)
-	if duration <= 0 && amount <= 0 {
+//
+//	// 每秒随机保留5条数据
+//	rch, clear = Sampling(time.Second, 0, handle, WithReservoir(5))
+//
+//	// 采样1%的数据
+//	rch, clear = Sampling(0, 0, handle, WithProbability(0.01))
+func Sampling[T any](duration time.Duration, amount int, exec func(T), opts ...SamplingOption) (rch chan<- T, clear func()) {
+	o := NewSamplingOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	switch o.Strategy {
+	case SamplingReservoir:
+		if o.ReservoirSize <= 0 {
+			panic("reservoir size must be greater than 0")
+		}
+	case SamplingProbability:
+		if o.Probability < 0 || o.Probability > 1 {
+			panic("probability must be between 0 and 1")
+		}
+	}
+	if o.Strategy != SamplingProbability && duration <= 0 && amount <= 0 {
 		panic("至少需要设置 duration 或 amount 其中一个参数")
 	}
+
+	ch := make(chan T)
+	sem := make(chan struct{}, 100)
 	var (
 		counter      int
+		seen         int // 蓄水池采样时本周期收到的数据数量
+		reservoir    []T
 		startTime    = time.Now()
 		timeTrigger  = duration > 0
 		countTrigger = amount > 0
 	)
+	dispatch := func(item T) {
+		sem <- struct{}{}
+		go func(item T) {
+			defer func() { <-sem }()
+			exec(item)
+		}(item)
+	}
+	flush := func() {
+		for _, item := range reservoir {
+			dispatch(item)
+		}
+		reservoir = reservoir[:0]
+		seen = 0
+	}
 
 	go func() {
 		defer close(sem)
 		for item := range ch {
+			if o.Strategy == SamplingProbability {
+				if rand.Float64() < o.Probability {
+					dispatch(item)
+				}
+				continue
+			}
+
+			if o.Strategy == SamplingReservoir {
+				seen++
+				if len(reservoir) < o.ReservoirSize {
+					reservoir = append(reservoir, item)
+				} else if j := rand.IntN(seen); j < o.ReservoirSize {
+					reservoir[j] = item
+				}
+			}
+
 			counter++
 			triggered := false
 			if countTrigger && counter >= amount {
@@ -59,15 +160,89 @@ func Sampling[T any](duration time.Duration, amount int, exec func(T)) (rch chan
 			}
 
 			if triggered {
-				sem <- struct{}{}
-				go func(item T) {
-					defer func() { <-sem }()
-					exec(item)
-				}(item)
+				if o.Strategy == SamplingReservoir {
+					flush()
+				} else {
+					dispatch(item)
+				}
 				counter = 0
 				startTime = time.Now()
 			}
 		}
+		flush()
+	}()
+	return ch, func() {
+		close(ch)
+	}
+}
+
+// SamplingBatch 对输入数据进行批量收集,达到采样条件时处理自上次触发以来收集的所有数据
+//
+// 参数说明:
+//   - duration: 采样时间间隔，如果为0则只根据数量触发
+//   - amount: 采样数量，如果为0则只根据时间触发
+//   - exec: 处理一批数据的函数
+//
+// 返回值说明:
+//   - rch: 用于接收数据的通道
+//   - clear: 用于关闭采样和清理资源的函数
+//
+// 注意事项:
+//   - duration和amount不能同时为0
+//   - 与 Sampling 不同，设置了duration时即使没有新数据到达也会按时间间隔处理已收集的数据，避免数据长时间滞留
+//   - 每批数据都是新的切片，exec可以持有它
+//   - 使用带缓冲的信号量控制并发，最大并发数为100
+//   - 需要调用clear函数来关闭通道和清理资源，尚未处理的数据会在关闭时处理
+//
+// 示例:
+//
+//	rch, clear := SamplingBatch(time.Second, 100, func(events []Event) {
+//	    db.BatchInsert(events)
+//	})
+//	defer clear()
+//	rch <- event
+func SamplingBatch[T any](duration time.Duration, amount int, exec func([]T)) (rch chan<- T, clear func()) {
+	if duration <= 0 && amount <= 0 {
+		panic("至少需要设置 duration 或 amount 其中一个参数")
+	}
+	ch := make(chan T)
+	sem := make(chan struct{}, 100)
+	var batch []T
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		sem <- struct{}{}
+		go func(batch []T) {
+			defer func() { <-sem }()
+			exec(batch)
+		}(batch)
+		batch = nil
+	}
+
+	go func() {
+		defer close(sem)
+		var tick <-chan time.Time
+		if duration > 0 {
+			ticker := time.NewTicker(duration)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		for {
+			select {
+			case item, ok := <-ch:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, item)
+				if amount > 0 && len(batch) >= amount {
+					flush()
+				}
+			case <-tick:
+				flush()
+			}
+		}
 	}()
 	return ch, func() {
 		close(ch)
@@ -86,6 +261,8 @@ func Sampling[T any](duration time.Duration, amount int, exec func(T)) (rch chan
 //   - 统计从调用consumeTimeStatistic时开始
 //   - 每次调用返回的函数都会更新最后一次统计时间
 //   - 返回的字符串包含总时间和间隔时间
+//   - 只能记录平铺的阶段,需要嵌套的阶段时使用 StartSpan
+//   - 需要获取各阶段耗时的结构化结果时使用 ktime.Stopwatch
 //
 // 示例:
 //
@@ -0,0 +1,163 @@
+package kmonitor
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collectSampling 向采样函数依次发送items,关闭后返回所有被处理的数据
+func collectSampling(duration time.Duration, amount int, items []int, opts ...SamplingOption) []int {
+	var (
+		mu      sync.Mutex
+		sampled []int
+	)
+	rch, clear := Sampling(duration, amount, func(item int) {
+		mu.Lock()
+		defer mu.Unlock()
+		sampled = append(sampled, item)
+	}, opts...)
+	for _, item := range items {
+		rch <- item
+	}
+	clear()
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Ints(sampled)
+	return sampled
+}
+
+func TestSampling(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+
+	t.Run("默认策略", func(t *testing.T) {
+		sampled := collectSampling(0, 10, items)
+		assert.Equal(t, []int{9, 19, 29, 39, 49, 59, 69, 79, 89, 99}, sampled)
+	})
+
+	t.Run("蓄水池采样", func(t *testing.T) {
+		sampled := collectSampling(0, 50, items, WithReservoir(3))
+		assert.Len(t, sampled, 6)
+		// 每个周期各保留3条
+		var first, second int
+		for _, v := range sampled {
+			if v < 50 {
+				first++
+			} else {
+				second++
+			}
+		}
+		assert.Equal(t, 3, first)
+		assert.Equal(t, 3, second)
+	})
+
+	t.Run("蓄水池采样关闭时处理剩余数据", func(t *testing.T) {
+		sampled := collectSampling(time.Hour, 0, items[:2], WithReservoir(5))
+		assert.Equal(t, []int{0, 1}, sampled)
+	})
+
+	t.Run("蓄水池采样是均匀的", func(t *testing.T) {
+		// 每10条保留1条,统计每个位置被保留的次数
+		var hits [10]int
+		const rounds = 2000
+		var mu sync.Mutex
+		rch, clear := Sampling(0, 10, func(item int) {
+			mu.Lock()
+			hits[item]++
+			mu.Unlock()
+		}, WithReservoir(1))
+		for i := 0; i < rounds; i++ {
+			for j := 0; j < 10; j++ {
+				rch <- j
+			}
+		}
+		clear()
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, n := range hits {
+			assert.InDelta(t, rounds/10, n, rounds/10*0.4)
+		}
+	})
+
+	t.Run("概率采样", func(t *testing.T) {
+		assert.Empty(t, collectSampling(0, 0, items, WithProbability(0)))
+		assert.Equal(t, items, collectSampling(0, 0, items, WithProbability(1)))
+
+		many := make([]int, 10000)
+		for i := range many {
+			many[i] = i
+		}
+		sampled := collectSampling(0, 0, many, WithProbability(0.1))
+		assert.InDelta(t, 1000, len(sampled), 200)
+	})
+
+	t.Run("参数校验", func(t *testing.T) {
+		assert.Panics(t, func() { Sampling(0, 0, func(int) {}) })
+		assert.Panics(t, func() { Sampling(0, 0, func(int) {}, WithReservoir(1)) })
+		assert.Panics(t, func() { Sampling(0, 10, func(int) {}, WithReservoir(0)) })
+		assert.Panics(t, func() { Sampling(0, 0, func(int) {}, WithProbability(1.5)) })
+	})
+}
+
+func TestSamplingBatch(t *testing.T) {
+	collect := func() (func([]int), func() [][]int) {
+		var (
+			mu      sync.Mutex
+			batches [][]int
+		)
+		return func(batch []int) {
+				mu.Lock()
+				defer mu.Unlock()
+				batches = append(batches, batch)
+			}, func() [][]int {
+				mu.Lock()
+				defer mu.Unlock()
+				sort.Slice(batches, func(i, j int) bool { return batches[i][0] < batches[j][0] })
+				return batches
+			}
+	}
+
+	t.Run("按数量触发", func(t *testing.T) {
+		exec, result := collect()
+		rch, clear := SamplingBatch(0, 3, exec)
+		for i := 0; i < 7; i++ {
+			rch <- i
+		}
+		clear()
+		time.Sleep(50 * time.Millisecond)
+		// 最后不足一批的数据在关闭时处理
+		assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6}}, result())
+	})
+
+	t.Run("按时间触发", func(t *testing.T) {
+		exec, result := collect()
+		rch, clear := SamplingBatch(50*time.Millisecond, 0, exec)
+		defer clear()
+		rch <- 1
+		rch <- 2
+		// 没有新数据到达也会按时间处理
+		time.Sleep(120 * time.Millisecond)
+		assert.Equal(t, [][]int{{1, 2}}, result())
+	})
+
+	t.Run("没有数据不处理", func(t *testing.T) {
+		exec, result := collect()
+		_, clear := SamplingBatch(10*time.Millisecond, 5, exec)
+		time.Sleep(50 * time.Millisecond)
+		clear()
+		time.Sleep(10 * time.Millisecond)
+		assert.Empty(t, result())
+	})
+
+	t.Run("参数校验", func(t *testing.T) {
+		assert.Panics(t, func() { SamplingBatch(0, 0, func([]int) {}) })
+	})
+}
@@ -0,0 +1,315 @@
+package kmonitor
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LeakDetectorOptions 协程泄漏检测器的配置项
+type LeakDetectorOptions struct {
+	GracePeriod   time.Duration    // 检测时等待协程退出的最长时间
+	Tolerance     int              // 允许新增的协程数量
+	CaptureStacks bool             // 是否记录协程堆栈,开启后报告中包含新增协程的堆栈
+	Interval      time.Duration    // 作为运行时看门狗时的检测间隔
+	OnLeak        func(LeakReport) // 检测到泄漏时的回调
+}
+
+type LeakDetectorOption func(*LeakDetectorOptions)
+
+func NewLeakDetectorOptions() *LeakDetectorOptions {
+	return &LeakDetectorOptions{
+		GracePeriod: time.Second,
+		Interval:    time.Minute,
+	}
+}
+
+// WithGracePeriod 设置检测时等待协程退出的最长时间
+func WithGracePeriod(d time.Duration) LeakDetectorOption {
+	return func(o *LeakDetectorOptions) {
+		o.GracePeriod = d
+	}
+}
+
+// WithTolerance 设置允许新增的协程数量
+func WithTolerance(n int) LeakDetectorOption {
+	return func(o *LeakDetectorOptions) {
+		o.Tolerance = n
+	}
+}
+
+// WithCaptureStacks 设置是否记录协程堆栈
+func WithCaptureStacks(capture bool) LeakDetectorOption {
+	return func(o *LeakDetectorOptions) {
+		o.CaptureStacks = capture
+	}
+}
+
+// WithCheckInterval 设置作为运行时看门狗时的检测间隔
+func WithCheckInterval(d time.Duration) LeakDetectorOption {
+	return func(o *LeakDetectorOptions) {
+		o.Interval = d
+	}
+}
+
+// WithOnLeak 设置检测到泄漏时的回调
+func WithOnLeak(fn func(LeakReport)) LeakDetectorOption {
+	return func(o *LeakDetectorOptions) {
+		o.OnLeak = fn
+	}
+}
+
+// LeakReport 协程泄漏报告
+type LeakReport struct {
+	Baseline int      // 基线协程数量
+	Current  int      // 检测时的协程数量
+	Stacks   []string // 相比基线新增的协程堆栈,未开启 CaptureStacks 时为空
+}
+
+// Leaked 返回相比基线新增的协程数量
+func (r LeakReport) Leaked() int {
+	return r.Current - r.Baseline
+}
+
+// String 返回可读的报告内容
+func (r LeakReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "goroutine leak: baseline %d, current %d", r.Baseline, r.Current)
+	for _, s := range r.Stacks {
+		sb.WriteString("\n\n")
+		sb.WriteString(s)
+	}
+	return sb.String()
+}
+
+// LeakDetector 协程泄漏检测器
+// 创建时记录协程数量作为基线,检测时在宽限期内等待协程数量回落到基线,超出时视为泄漏
+type LeakDetector struct {
+	mu         sync.Mutex
+	opts       *LeakDetectorOptions
+	baseline   int
+	baseStacks map[string]struct{} // 基线协程ID,开启 CaptureStacks 时记录
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// NewLeakDetector 创建一个协程泄漏检测器,并以当前协程数量作为基线
+//
+// 参数说明:
+//   - opts: 可选配置项,参见 LeakDetectorOptions
+//
+// 返回值:
+//   - *LeakDetector: 新创建的检测器
+//
+// 注意事项:
+//   - 可以调用 Check 手动检测,也可以调用 Start 作为运行时看门狗定期检测
+//   - 看门狗自身的协程不计入泄漏
+//   - 在测试中使用 VerifyNoLeaks 更方便
+//
+// 示例:
+//
+//	d := NewLeakDetector(WithOnLeak(func(r LeakReport) {
+//	    log.Println(r)
+//	}), WithTolerance(10), WithCheckInterval(time.Minute))
+//	d.Start()
+//	defer d.Stop()
+func NewLeakDetector(opts ...LeakDetectorOption) *LeakDetector {
+	o := NewLeakDetectorOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	d := &LeakDetector{opts: o}
+	d.ResetBaseline()
+	return d
+}
+
+// ResetBaseline 以当前协程数量重新设置基线
+func (d *LeakDetector) ResetBaseline() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.baseline = runtime.NumGoroutine()
+	if d.stop != nil {
+		d.baseline-- // 看门狗协程不计入基线
+	}
+	d.baseStacks = nil
+	if d.opts.CaptureStacks {
+		d.baseStacks = make(map[string]struct{})
+		for id := range goroutineStacks() {
+			d.baseStacks[id] = struct{}{}
+		}
+	}
+}
+
+// Baseline 返回基线协程数量
+func (d *LeakDetector) Baseline() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.baseline
+}
+
+// Check 检测是否存在协程泄漏
+//
+// 返回值:
+//   - LeakReport: 检测报告
+//   - bool: 是否存在泄漏
+//
+// 注意事项:
+//   - 新增的协程数量超过 Tolerance 时,会在 GracePeriod 内等待协程退出
+//   - 开启 CaptureStacks 时按协程ID判断新增的协程,更准确;否则按协程数量判断
+//   - 存在泄漏时会调用 OnLeak 回调
+func (d *LeakDetector) Check() (LeakReport, bool) {
+	d.mu.Lock()
+	baseline := d.baseline
+	if d.stop != nil {
+		baseline++ // 看门狗协程
+	}
+	baseStacks := d.baseStacks
+	d.mu.Unlock()
+
+	// 记录了基线协程时按新增的协程判断,否则按协程数量判断
+	check := func() (LeakReport, bool) {
+		report := LeakReport{Baseline: baseline, Current: runtime.NumGoroutine()}
+		if baseStacks == nil {
+			return report, report.Current > baseline+d.opts.Tolerance
+		}
+		for id, stack := range goroutineStacks() {
+			if _, ok := baseStacks[id]; !ok && !strings.Contains(stack, "(*LeakDetector).watch") {
+				report.Stacks = append(report.Stacks, stack)
+			}
+		}
+		slices.Sort(report.Stacks)
+		return report, len(report.Stacks) > d.opts.Tolerance
+	}
+
+	deadline := time.Now().Add(d.opts.GracePeriod)
+	report, leaked := check()
+	for leaked && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		report, leaked = check()
+	}
+	if !leaked {
+		return report, false
+	}
+	if d.opts.OnLeak != nil {
+		d.opts.OnLeak(report)
+	}
+	return report, true
+}
+
+// Start 启动看门狗,按 Interval 定期检测,重复调用无效果
+func (d *LeakDetector) Start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stop != nil {
+		return
+	}
+	d.stop = make(chan struct{})
+	d.done = make(chan struct{})
+	go d.watch(d.stop, d.done)
+}
+
+// Stop 停止看门狗并等待其退出,未启动时无效果
+func (d *LeakDetector) Stop() {
+	d.mu.Lock()
+	stop, done := d.stop, d.done
+	d.stop, d.done = nil, nil
+	d.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (d *LeakDetector) watch(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.Check()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// TestingT VerifyNoLeaks 所需的测试接口,*testing.T 和 *testing.B 均已实现
+type TestingT interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...any)
+}
+
+// VerifyNoLeaks 在测试结束时检测测试期间是否有协程泄漏
+//
+// 参数说明:
+//   - t: 测试对象,通常为 *testing.T
+//   - opts: 可选配置项,默认记录协程堆栈
+//
+// 注意事项:
+//   - 应在测试开始时调用,以调用时的协程数量作为基线
+//   - 并行测试(t.Parallel)之间会相互影响,不适合一起使用
+//
+// 示例:
+//
+//	func TestLoop(t *testing.T) {
+//	    kmonitor.VerifyNoLeaks(t)
+//	    ...
+//	}
+func VerifyNoLeaks(t TestingT, opts ...LeakDetectorOption) {
+	t.Helper()
+	d := NewLeakDetector(append([]LeakDetectorOption{WithCaptureStacks(true)}, opts...)...)
+	t.Cleanup(func() {
+		if report, leaked := d.Check(); leaked {
+			t.Errorf("%s", report)
+		}
+	})
+}
+
+// goroutineDump 返回所有协程的堆栈
+func goroutineDump() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+}
+
+// goroutineStacks 返回所有协程的堆栈,key为协程ID
+func goroutineStacks() map[string]string {
+	return splitGoroutineStacks(goroutineDump())
+}
+
+// splitGoroutineStacks 将所有协程的堆栈按协程拆分,key为协程ID
+func splitGoroutineStacks(dump []byte) map[string]string {
+	stacks := make(map[string]string)
+	for _, block := range bytes.Split(dump, []byte("\n\n")) {
+		// 每个协程的堆栈以 "goroutine 12 [running]:" 开头
+		fields := bytes.Fields(block)
+		if len(fields) < 2 || string(fields[0]) != "goroutine" {
+			continue
+		}
+		stacks[string(fields[1])] = string(block)
+	}
+	return stacks
+}
+
+// currentGoroutineID 返回当前协程的ID
+func currentGoroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	fields := bytes.Fields(buf)
+	if len(fields) < 2 {
+		return ""
+	}
+	return string(fields[1])
+}
@@ -0,0 +1,111 @@
+package kmonitor
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeT 记录 VerifyNoLeaks 的调用结果
+type fakeT struct {
+	cleanups []func()
+	errors   []string
+}
+
+func (f *fakeT) Helper()           {}
+func (f *fakeT) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+func (f *fakeT) finish() {
+	for _, fn := range f.cleanups {
+		fn()
+	}
+}
+
+func leakedGoroutine(stop chan struct{}) {
+	<-stop
+}
+
+func TestLeakDetector(t *testing.T) {
+	t.Run("宽限期内协程退出", func(t *testing.T) {
+		d := NewLeakDetector(WithGracePeriod(time.Second))
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+		}()
+		_, leaked := d.Check()
+		assert.False(t, leaked)
+	})
+
+	t.Run("检测到泄漏", func(t *testing.T) {
+		var reports []LeakReport
+		d := NewLeakDetector(WithGracePeriod(50*time.Millisecond), WithCaptureStacks(true), WithOnLeak(func(r LeakReport) {
+			reports = append(reports, r)
+		}))
+		stop := make(chan struct{})
+		defer close(stop)
+		go leakedGoroutine(stop)
+
+		report, leaked := d.Check()
+		assert.True(t, leaked)
+		assert.Len(t, report.Stacks, 1)
+		assert.Contains(t, report.Stacks[0], "leakedGoroutine")
+		assert.Contains(t, report.String(), "goroutine leak")
+		assert.Len(t, reports, 1)
+	})
+
+	t.Run("容忍新增协程", func(t *testing.T) {
+		d := NewLeakDetector(WithGracePeriod(0), WithCaptureStacks(true), WithTolerance(1))
+		stop := make(chan struct{})
+		defer close(stop)
+		go leakedGoroutine(stop)
+		_, leaked := d.Check()
+		assert.False(t, leaked)
+	})
+
+	t.Run("看门狗", func(t *testing.T) {
+		var leaks atomic.Int32
+		d := NewLeakDetector(WithGracePeriod(0), WithCaptureStacks(true), WithCheckInterval(20*time.Millisecond), WithOnLeak(func(r LeakReport) {
+			leaks.Add(1)
+		}))
+		d.Start()
+		d.Start() // 重复调用无效果
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(t, int32(0), leaks.Load(), "看门狗自身不计入泄漏")
+
+		stop := make(chan struct{})
+		go leakedGoroutine(stop)
+		time.Sleep(60 * time.Millisecond)
+		d.Stop()
+		d.Stop()
+		close(stop)
+		assert.Greater(t, leaks.Load(), int32(0))
+	})
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	t.Run("没有泄漏", func(t *testing.T) {
+		ft := &fakeT{}
+		VerifyNoLeaks(ft)
+		done := make(chan struct{})
+		go func() { close(done) }()
+		<-done
+		ft.finish()
+		assert.Empty(t, ft.errors)
+	})
+
+	t.Run("存在泄漏", func(t *testing.T) {
+		ft := &fakeT{}
+		VerifyNoLeaks(ft, WithGracePeriod(50*time.Millisecond))
+		stop := make(chan struct{})
+		defer close(stop)
+		go leakedGoroutine(stop)
+		ft.finish()
+		assert.Len(t, ft.errors, 1)
+		assert.True(t, strings.Contains(ft.errors[0], "leakedGoroutine"))
+	})
+}
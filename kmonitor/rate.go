@@ -0,0 +1,123 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mtgnorton/k/kcollection"
+	"github.com/mtgnorton/k/kmath"
+)
+
+// RateCounter 速率计数器,基于滑动窗口统计最近一段时间内每秒的事件数(如QPS)
+type RateCounter[T kmath.Number] struct {
+	window *kcollection.RollingWindow[T, *kcollection.Bucket[T]]
+}
+
+// NewRateCounter 创建一个新的速率计数器
+// 参数:
+//   - opts: 可选配置项,包括窗口大小、时间间隔等,窗口大小*时间间隔即可查询的最长时间范围
+//
+// 返回:
+//   - *RateCounter[T]: 新创建的速率计数器
+//
+// 注意:
+//   - 当前桶尚未结束,计入当前桶时速率会偏低,需要更准确的结果时使用 WithIgnoreCurrent
+//
+// 示例:
+//
+//	qps := NewRateCounter[int64](
+//	    kcollection.WithSize[int64, *kcollection.Bucket[int64]](60),
+//	    kcollection.WithInterval[int64, *kcollection.Bucket[int64]](time.Second))
+//	qps.Incr()
+//	fmt.Println(qps.Rate(10 * time.Second)) // 最近10秒的每秒事件数
+func NewRateCounter[T kmath.Number](opts ...kcollection.RollingWindowOption[T, *kcollection.Bucket[T]]) *RateCounter[T] {
+	return &RateCounter[T]{
+		window: kcollection.NewRollingWindow(func() *kcollection.Bucket[T] {
+			return &kcollection.Bucket[T]{}
+		}, opts...),
+	}
+}
+
+// Incr 记录一次事件
+func (r *RateCounter[T]) Incr() {
+	r.window.Add(1)
+}
+
+// Add 记录v次事件
+func (r *RateCounter[T]) Add(v T) {
+	r.window.Add(v)
+}
+
+// Rate 返回最近一段时间内每秒的事件数
+// 参数:
+//   - last: 可选的时间范围,默认为整个窗口,会向上取整到时间间隔的整数倍,超过窗口时按整个窗口计算
+func (r *RateCounter[T]) Rate(last ...time.Duration) float64 {
+	return windowRate(r.window, func(b *kcollection.Bucket[T]) float64 {
+		return float64(b.Sum)
+	}, last...)
+}
+
+// Total 返回最近一段时间内的事件总数
+// 参数:
+//   - last: 可选的时间范围,默认为整个窗口,参见 Rate
+func (r *RateCounter[T]) Total(last ...time.Duration) float64 {
+	sum, _ := windowSum(r.window, func(b *kcollection.Bucket[T]) float64 {
+		return float64(b.Sum)
+	}, last...)
+	return sum
+}
+
+// MarshalJSON 以整个窗口内每秒的事件数编码为JSON
+func (r *RateCounter[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Rate())
+}
+
+// windowRate 计算窗口内最近一段时间每秒的值
+// 参数:
+//   - rw: 滑动窗口
+//   - value: 获取每个桶的值
+//   - last: 可选的时间范围,默认为整个窗口
+func windowRate[T kmath.Number, B kcollection.BucketInterface[T]](rw *kcollection.RollingWindow[T, B], value func(B) float64, last ...time.Duration) float64 {
+	sum, span := windowSum(rw, value, last...)
+	if span == 0 {
+		return 0
+	}
+	return sum / span.Seconds()
+}
+
+// windowSum 计算窗口内最近一段时间的值的和
+// 返回:
+//   - sum: 值的和
+//   - span: 参与计算的时长,参见 windowReduce
+func windowSum[T kmath.Number, B kcollection.BucketInterface[T]](rw *kcollection.RollingWindow[T, B], value func(B) float64, last ...time.Duration) (sum float64, span time.Duration) {
+	span = windowReduce(rw, func(b B) {
+		sum += value(b)
+	}, last...)
+	return sum, span
+}
+
+// windowReduce 遍历窗口内最近一段时间的桶,按桶的时间而不是位置选择,已过期的时间间隔视为空桶
+// 参数:
+//   - rw: 滑动窗口
+//   - fn: 处理每个桶的函数,只在一次 Reduce 中调用,多个值在同一个fn中计算时互相一致
+//   - last: 可选的时间范围,默认为整个窗口,会向上取整到时间间隔的整数倍,超过窗口时按整个窗口计算
+//
+// 返回:
+//   - time.Duration: 参与计算的时长,包括已过期的时间间隔,忽略当前桶时不包括当前桶
+func windowReduce[T kmath.Number, B kcollection.BucketInterface[T]](rw *kcollection.RollingWindow[T, B], fn func(B), last ...time.Duration) time.Duration {
+	interval, size := rw.Interval(), rw.Size()
+	first := 0
+	if rw.Opts.IgnoreCurrent {
+		first = 1
+	}
+	n := size - first
+	if len(last) > 0 && last[0] > 0 {
+		n = min(n, int((last[0]+interval-1)/interval))
+	}
+	rw.ReduceWithAge(func(b B, age int) {
+		if age >= first && age < first+n {
+			fn(b)
+		}
+	})
+	return time.Duration(n) * interval
+}
@@ -0,0 +1,104 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mtgnorton/k/kcollection"
+	"github.com/mtgnorton/k/ktime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateCounter(t *testing.T) {
+	const interval = 100 * time.Millisecond
+
+	t.Run("整个窗口与最近一段时间", func(t *testing.T) {
+		r := NewRateCounter(
+			kcollection.WithSize[int64, *kcollection.Bucket[int64]](5),
+			kcollection.WithInterval[int64, *kcollection.Bucket[int64]](interval))
+		r.Add(10)
+		time.Sleep(interval)
+		r.Incr()
+		r.Incr()
+
+		// 整个窗口5个桶共0.5秒,共12次事件
+		assert.InDelta(t, 24, r.Rate(), 1e-9)
+		// 最近一个桶0.1秒内2次事件
+		assert.InDelta(t, 20, r.Rate(interval), 1e-9)
+		// 向上取整为2个桶
+		assert.InDelta(t, 60, r.Rate(interval+time.Millisecond), 1e-9)
+		// 超过窗口按整个窗口计算
+		assert.InDelta(t, 24, r.Rate(time.Hour), 1e-9)
+		assert.Equal(t, 12.0, r.Total())
+		assert.Equal(t, 2.0, r.Total(interval))
+
+		data, err := json.Marshal(r)
+		assert.NoError(t, err)
+		assert.JSONEq(t, "24", string(data))
+	})
+
+	t.Run("忽略当前桶", func(t *testing.T) {
+		r := NewRateCounter(
+			kcollection.WithSize[int64, *kcollection.Bucket[int64]](3),
+			kcollection.WithInterval[int64, *kcollection.Bucket[int64]](interval),
+			kcollection.WithIgnoreCurrent[int64, *kcollection.Bucket[int64]](true))
+		r.Add(5)
+		assert.Equal(t, 0.0, r.Rate())
+		time.Sleep(interval)
+		// 只统计之前的2个桶
+		assert.InDelta(t, 25, r.Rate(), 1e-9)
+	})
+
+	t.Run("已过期的时间间隔视为空桶", func(t *testing.T) {
+		clock := ktime.NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+		r := NewRateCounter(
+			kcollection.WithSize[int64, *kcollection.Bucket[int64]](10),
+			kcollection.WithInterval[int64, *kcollection.Bucket[int64]](time.Second),
+			kcollection.WithClock[int64, *kcollection.Bucket[int64]](clock))
+		for i := 0; i < 100; i++ {
+			r.Incr()
+		}
+		clock.Advance(5 * time.Second)
+		assert.Equal(t, 0.0, r.Rate(2*time.Second))
+		assert.Equal(t, 0.0, r.Total(2*time.Second))
+		assert.InDelta(t, 10, r.Rate(), 1e-9)
+		assert.Equal(t, 100.0, r.Total(6*time.Second))
+	})
+}
+
+func TestRollingResultCounterRate(t *testing.T) {
+	counter := NewRollingResultCounter(
+		kcollection.WithSize[int64, *kcollection.Bucket[int64]](2),
+		kcollection.WithInterval[int64, *kcollection.Bucket[int64]](time.Second))
+	for i := 0; i < 4; i++ {
+		counter.AddSuccess(10)
+	}
+	counter.AddFail(10)
+
+	success, fail := counter.Rate()
+	assert.InDelta(t, 2, success, 1e-9)
+	assert.InDelta(t, 0.5, fail, 1e-9)
+
+	success, fail = counter.Rate(time.Second)
+	assert.InDelta(t, 4, success, 1e-9)
+	assert.InDelta(t, 1, fail, 1e-9)
+}
+
+func TestRollingResultCounterRateExpired(t *testing.T) {
+	clock := ktime.NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+	counter := NewRollingResultCounter(
+		kcollection.WithSize[int64, *kcollection.Bucket[int64]](10),
+		kcollection.WithInterval[int64, *kcollection.Bucket[int64]](time.Second),
+		kcollection.WithClock[int64, *kcollection.Bucket[int64]](clock))
+	for i := 0; i < 100; i++ {
+		counter.AddSuccess(10)
+	}
+	clock.Advance(5 * time.Second)
+
+	success, fail := counter.Rate(2 * time.Second)
+	assert.Equal(t, 0.0, success)
+	assert.Equal(t, 0.0, fail)
+	success, _ = counter.Rate()
+	assert.InDelta(t, 10, success, 1e-9)
+}
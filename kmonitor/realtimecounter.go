@@ -1,7 +1,9 @@
 package kmonitor
 
 import (
+	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/mtgnorton/k/kmath"
 )
@@ -9,8 +11,10 @@ import (
 // RealtimeCounter 实时计数器,用于统计一段时间内的计数值
 // 支持泛型,可以统计任意数字类型
 type RealtimeCounter[T kmath.Number] struct {
-	counter T
-	mu      sync.Mutex
+	counter  T
+	mu       sync.Mutex
+	stop     chan struct{} // 停止定时回调,未开启定时回调时为nil
+	stopOnce sync.Once
 }
 
 // NewRealtimeCounter 创建一个新的实时计数器
@@ -32,6 +36,55 @@ func NewRealtimeCounter[T kmath.Number]() *RealtimeCounter[T] {
 	return r
 }
 
+// NewRealtimeCounterWithReport 创建一个定时回调的实时计数器
+// 参数:
+//   - interval: 回调的时间间隔
+//   - fn: 回调函数,接收回调时的计数值
+//   - reset: 可选参数,回调后是否将计数值重置为0,默认为false
+//
+// 返回:
+//   - *RealtimeCounter[T]: 新创建的实时计数器
+//
+// 注意:
+//   - interval必须大于0,否则会panic
+//   - 回调在独立的goroutine中串行执行,不再使用时需要调用 Stop 释放goroutine
+//   - reset为true时,读取和重置在同一把锁内完成,不会丢失计数
+//
+// 示例:
+//
+//	// 每秒打印一次QPS
+//	counter := NewRealtimeCounterWithReport(time.Second, func(v int64) {
+//	    log.Printf("qps: %d", v)
+//	}, true)
+//	defer counter.Stop()
+//	counter.Add(1)
+func NewRealtimeCounterWithReport[T kmath.Number](interval time.Duration, fn func(value T), reset ...bool) *RealtimeCounter[T] {
+	if interval <= 0 {
+		panic("interval must be greater than 0")
+	}
+	r := &RealtimeCounter[T]{
+		stop: make(chan struct{}),
+	}
+	resetAfterReport := len(reset) > 0 && reset[0]
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if resetAfterReport {
+					fn(r.getAndReset())
+				} else {
+					fn(r.Get())
+				}
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+	return r
+}
+
 // Add 增加计数值
 // 参数:
 //   - v: 要增加的值
@@ -66,3 +119,28 @@ func (r *RealtimeCounter[T]) Reset() {
 	var v T
 	r.counter = v
 }
+
+// Stop 停止定时回调,可以重复调用,未开启定时回调时无效果
+func (r *RealtimeCounter[T]) Stop() {
+	if r.stop == nil {
+		return
+	}
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+}
+
+// getAndReset 返回当前计数值并重置为0
+func (r *RealtimeCounter[T]) getAndReset() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v := r.counter
+	var zero T
+	r.counter = zero
+	return v
+}
+
+// MarshalJSON 以当前计数值编码为JSON
+func (r *RealtimeCounter[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Get())
+}
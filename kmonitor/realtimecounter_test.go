@@ -0,0 +1,59 @@
+package kmonitor
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRealtimeCounterWithReport(t *testing.T) {
+	const interval = 50 * time.Millisecond
+
+	t.Run("回调后重置", func(t *testing.T) {
+		reports := make(chan int64, 10)
+		counter := NewRealtimeCounterWithReport(interval, func(v int64) {
+			reports <- v
+		}, true)
+		defer counter.Stop()
+
+		counter.Add(5)
+		assert.Equal(t, int64(5), <-reports)
+		assert.Equal(t, int64(0), counter.Get())
+		counter.Add(2)
+		assert.Equal(t, int64(2), <-reports)
+	})
+
+	t.Run("回调后不重置", func(t *testing.T) {
+		reports := make(chan int64, 10)
+		counter := NewRealtimeCounterWithReport(interval, func(v int64) {
+			reports <- v
+		})
+		defer counter.Stop()
+
+		counter.Add(5)
+		assert.Equal(t, int64(5), <-reports)
+		assert.Equal(t, int64(5), <-reports)
+		assert.Equal(t, int64(5), counter.Get())
+	})
+
+	t.Run("停止后不再回调", func(t *testing.T) {
+		var calls atomic.Int32
+		counter := NewRealtimeCounterWithReport(interval, func(v int) {
+			calls.Add(1)
+		})
+		counter.Stop()
+		counter.Stop() // 重复调用无影响
+		time.Sleep(interval * 3)
+		assert.Equal(t, int32(0), calls.Load())
+	})
+
+	t.Run("非法间隔", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewRealtimeCounterWithReport(0, func(v int) {})
+		})
+		// 未开启定时回调时Stop无效果
+		NewRealtimeCounter[int]().Stop()
+	})
+}
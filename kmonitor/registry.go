@@ -0,0 +1,239 @@
+package kmonitor
+
+import (
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultRegistry 默认的指标注册表,已注册默认超时检测器(名称为timeout)
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.Register("timeout", defaultTimeoutController)
+}
+
+// Labels 指标的标签集合,如 {"method": "GET", "path": "/api"}
+// 名称相同、标签不同的指标是相互独立的序列
+type Labels map[string]string
+
+// String 返回按key排序的标签字符串,如 {method="GET",path="/api"},没有标签时返回空字符串
+func (l Labels) String() string {
+	if len(l) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(l))
+	for k := range l {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(strconv.Quote(l[k]))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// MetricID 返回指标的唯一标识,由名称和标签组成,如 rpc{method="GET"},没有标签时即为名称
+func MetricID(name string, labels Labels) string {
+	return name + labels.String()
+}
+
+// Registry 指标注册表,按名称和标签汇总各类计数器,并以JSON的形式通过HTTP或expvar对外暴露
+//
+// 可以注册的指标:
+//   - 实现了 json.Marshaler 的指标,如 RealtimeCounter、RollingResultCounter、Histogram、TimeoutController、kcollection.LRUCache
+//   - func() any 类型的函数,每次输出时调用并输出其返回值,可用作gauge
+//   - 其他任意可以被json编码的值
+type Registry struct {
+	mu      sync.RWMutex
+	metrics map[string]*registryEntry // 指标标识 -> 指标
+}
+
+type registryEntry struct {
+	name   string
+	labels Labels
+	metric any
+}
+
+// NewRegistry 创建一个新的指标注册表
+//
+// 注意事项:
+//   - 线程安全
+//   - Registry 实现了 http.Handler,可直接挂载到路由上
+//
+// 示例:
+//
+//	qps := NewRealtimeCounter[int64]()
+//	r := NewRegistry()
+//	r.Register("qps", qps)
+//	r.Register("rpc", NewRollingResultCounter[int64](), Labels{"method": "GET"})
+//	r.Register("goroutines", func() any { return runtime.NumGoroutine() })
+//	http.Handle("/debug/kmonitor", r)
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: make(map[string]*registryEntry),
+	}
+}
+
+// Register 注册指标,名称和标签都相同的指标会被覆盖
+// 参数:
+//   - name: 指标名称
+//   - metric: 指标,参见 Registry 的说明
+//   - labels: 可选的标签集合,会被复制一份
+func (r *Registry) Register(name string, metric any, labels ...Labels) {
+	l := maps.Clone(firstLabels(labels))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics[MetricID(name, l)] = &registryEntry{name: name, labels: l, metric: metric}
+}
+
+// Unregister 注销指标
+// 参数:
+//   - name: 指标名称
+//   - labels: 可选的标签集合
+func (r *Registry) Unregister(name string, labels ...Labels) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.metrics, MetricID(name, firstLabels(labels)))
+}
+
+// Get 查找指标
+// 参数:
+//   - name: 指标名称
+//   - labels: 可选的标签集合
+//
+// 返回:
+//   - any: 注册的指标
+//   - bool: 指标是否存在
+func (r *Registry) Get(name string, labels ...Labels) (any, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.metrics[MetricID(name, firstLabels(labels))]
+	if !ok {
+		return nil, false
+	}
+	return e.metric, true
+}
+
+// Range 按指标标识的字母顺序遍历所有指标
+// 参数:
+//   - fn: 遍历函数,接收指标名称、标签和指标,返回false时停止遍历
+//
+// 注意:
+//   - 遍历的是调用时的快照,可以在fn中注册或注销指标
+//   - 不能修改fn接收的labels
+func (r *Registry) Range(fn func(name string, labels Labels, metric any) bool) {
+	for _, e := range r.entries() {
+		if !fn(e.name, e.labels, e.metric) {
+			return
+		}
+	}
+}
+
+// Names 按字母顺序返回所有已注册的指标标识,参见 MetricID
+func (r *Registry) Names() []string {
+	entries := r.entries()
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = MetricID(e.name, e.labels)
+	}
+	return names
+}
+
+// Values 返回所有指标的当前值,key为指标标识,func() any 类型的指标会被调用
+func (r *Registry) Values() map[string]any {
+	entries := r.entries()
+	// 在锁外求值,避免指标函数耗时或回调注册表导致阻塞
+	values := make(map[string]any, len(entries))
+	for _, e := range entries {
+		values[MetricID(e.name, e.labels)] = metricValue(e.metric)
+	}
+	return values
+}
+
+// entries 返回按指标标识排序的所有指标
+func (r *Registry) entries() []*registryEntry {
+	r.mu.RLock()
+	ids := make([]string, 0, len(r.metrics))
+	for id := range r.metrics {
+		ids = append(ids, id)
+	}
+	entries := make([]*registryEntry, 0, len(ids))
+	sort.Strings(ids)
+	for _, id := range ids {
+		entries = append(entries, r.metrics[id])
+	}
+	r.mu.RUnlock()
+	return entries
+}
+
+// GetOrRegister 查找指标,不存在时使用create创建并注册
+// 用于在服务的多个位置共享同一个指标,如按接口名称聚合请求统计
+//
+// 参数:
+//   - r: 注册表
+//   - name: 指标名称
+//   - labels: 标签集合,可以为nil
+//   - create: 指标不存在时用于创建指标
+//
+// 返回:
+//   - M: 已注册或新创建的指标
+//
+// 注意:
+//   - 已注册的指标类型与M不一致时会panic
+//
+// 示例:
+//
+//	counter := GetOrRegister(DefaultRegistry, "rpc", Labels{"method": method}, func() *RollingResultCounter[int64] {
+//	    return NewRollingResultCounter[int64]()
+//	})
+//	counter.AddSuccess(cost)
+func GetOrRegister[M any](r *Registry, name string, labels Labels, create func() M) M {
+	id := MetricID(name, labels)
+	r.mu.RLock()
+	e, ok := r.metrics[id]
+	r.mu.RUnlock()
+	if ok {
+		return e.metric.(M)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.metrics[id]; ok {
+		return e.metric.(M)
+	}
+	m := create()
+	r.metrics[id] = &registryEntry{name: name, labels: maps.Clone(labels), metric: m}
+	return m
+}
+
+// Register 向默认注册表注册指标,参见 Registry.Register
+func Register(name string, metric any, labels ...Labels) {
+	DefaultRegistry.Register(name, metric, labels...)
+}
+
+// firstLabels 返回可选的标签参数,未传入时返回nil
+func firstLabels(labels []Labels) Labels {
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels[0]
+}
+
+// metricValue 返回指标的当前值
+func metricValue(m any) any {
+	if fn, ok := m.(func() any); ok {
+		return fn()
+	}
+	return m
+}
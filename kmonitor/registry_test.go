@@ -0,0 +1,107 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabels(t *testing.T) {
+	assert.Equal(t, "", Labels(nil).String())
+	assert.Equal(t, `{a="1",b="x\"y"}`, Labels{"b": `x"y`, "a": "1"}.String())
+	assert.Equal(t, "qps", MetricID("qps", nil))
+	assert.Equal(t, `rpc{method="GET"}`, MetricID("rpc", Labels{"method": "GET"}))
+}
+
+func TestRegistryLabels(t *testing.T) {
+	r := NewRegistry()
+	get := NewRealtimeCounter[int]()
+	post := NewRealtimeCounter[int]()
+	labels := Labels{"method": "GET"}
+	r.Register("requests", get, labels)
+	r.Register("requests", post, Labels{"method": "POST"})
+	r.Register("requests", 0)
+	labels["method"] = "PUT" // 注册时复制了标签,修改不影响注册表
+
+	t.Run("查找", func(t *testing.T) {
+		m, ok := r.Get("requests", Labels{"method": "GET"})
+		assert.True(t, ok)
+		assert.Same(t, get, m)
+		_, ok = r.Get("requests", Labels{"method": "PUT"})
+		assert.False(t, ok)
+		m, ok = r.Get("requests")
+		assert.True(t, ok)
+		assert.Equal(t, 0, m)
+	})
+
+	t.Run("遍历", func(t *testing.T) {
+		var methods []string
+		r.Range(func(name string, labels Labels, metric any) bool {
+			assert.Equal(t, "requests", name)
+			methods = append(methods, labels["method"])
+			return true
+		})
+		assert.Equal(t, []string{"", "GET", "POST"}, methods)
+		assert.Equal(t, []string{"requests", `requests{method="GET"}`, `requests{method="POST"}`}, r.Names())
+
+		count := 0
+		r.Range(func(string, Labels, any) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("一起输出", func(t *testing.T) {
+		get.Add(2)
+		post.Add(3)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.JSONEq(t, `{"requests":0,"requests{method=\"GET\"}":2,"requests{method=\"POST\"}":3}`, rec.Body.String())
+
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?name="+url.QueryEscape(`requests{method="POST"}`), nil))
+		assert.JSONEq(t, "3", rec.Body.String())
+	})
+
+	t.Run("注销", func(t *testing.T) {
+		r.Unregister("requests", Labels{"method": "POST"})
+		_, ok := r.Get("requests", Labels{"method": "POST"})
+		assert.False(t, ok)
+		assert.Len(t, r.Names(), 2)
+	})
+}
+
+func TestGetOrRegister(t *testing.T) {
+	r := NewRegistry()
+	create := func() *RealtimeCounter[int64] {
+		return NewRealtimeCounter[int64]()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			GetOrRegister(r, "hits", Labels{"path": "/"}, create).Add(1)
+		}()
+	}
+	wg.Wait()
+
+	c := GetOrRegister(r, "hits", Labels{"path": "/"}, create)
+	assert.Equal(t, int64(100), c.Get())
+	assert.Len(t, r.Names(), 1)
+
+	data, err := json.Marshal(r.Values())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"hits{path=\"/\"}":100}`, string(data))
+
+	assert.Panics(t, func() {
+		GetOrRegister(r, "hits", Labels{"path": "/"}, func() int { return 0 })
+	})
+}
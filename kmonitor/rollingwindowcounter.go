@@ -1,7 +1,11 @@
 package kmonitor
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mtgnorton/k/kcollection"
@@ -10,9 +14,48 @@ import (
 
 // RollingResultCounter 滚动结果计数器,用于统计成功和失败的请求及其消耗时间
 // 支持泛型,可以统计任意数字类型
+// 每个桶内部使用直方图记录消耗时间的分布,可查询p50/p90/p99/max等延迟指标
 type RollingResultCounter[T kmath.Number] struct {
-	successWindow *kcollection.RollingWindow[T, *kcollection.Bucket[T]]
-	failWindow    *kcollection.RollingWindow[T, *kcollection.Bucket[T]]
+	successWindow *kcollection.RollingWindow[T, *kcollection.HistogramBucket[T]]
+	failWindow    *kcollection.RollingWindow[T, *kcollection.HistogramBucket[T]]
+
+	hookMu   sync.Mutex
+	hooks    []*errorRateHook
+	hookN    atomic.Int32 // 已注册的错误率钩子数量
+	exceeded atomic.Int32 // 处于超限状态的错误率钩子数量
+}
+
+// errorRateHook 错误率超限钩子
+type errorRateHook struct {
+	threshold  float64
+	minSamples int64
+	fn         func(rate float64)
+	exceeded   bool
+}
+
+// LatencyStats 消耗时间的分布统计
+type LatencyStats struct {
+	Count int64   `json:"count"` // 请求数量
+	Avg   float64 `json:"avg"`   // 平均消耗时间
+	P50   float64 `json:"p50"`   // 50分位消耗时间
+	P90   float64 `json:"p90"`   // 90分位消耗时间
+	P99   float64 `json:"p99"`   // 99分位消耗时间
+	Max   float64 `json:"max"`   // 最大消耗时间
+}
+
+// defaultLatencyBounds 默认的消耗时间直方图桶边界,适用于以毫秒为单位的延迟
+// T的范围较小时(如int8、uint8、int16)只保留T能表示的边界
+func defaultLatencyBounds[T kmath.Number]() []T {
+	raw := []int{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000, 30000, 60000}
+	bounds := make([]T, 0, len(raw))
+	for _, v := range raw {
+		b := T(v)
+		if float64(b) != float64(v) {
+			break // 边界递增,之后的边界也会溢出
+		}
+		bounds = append(bounds, b)
+	}
+	return bounds
 }
 
 // NewRollingResultCounter 创建一个新的滚动结果计数器
@@ -24,25 +67,52 @@ type RollingResultCounter[T kmath.Number] struct {
 //
 // 注意:
 //   - T 必须是数字类型
+//   - 使用默认的直方图桶边界(1ms~60s,超出T的范围的边界会被忽略),百分位数的精度取决于桶边界,
+//     消耗时间不是毫秒或需要更高精度时使用 NewRollingResultCounterWithBounds
 //
 // 示例:
 //
 //	counter := NewRollingResultCounter[int64]()
 //	counter.AddSuccess(100)
 func NewRollingResultCounter[T kmath.Number](opts ...kcollection.RollingWindowOption[T, *kcollection.Bucket[T]]) *RollingResultCounter[T] {
+	return NewRollingResultCounterWithBounds(defaultLatencyBounds[T](), opts...)
+}
+
+// NewRollingResultCounterWithBounds 使用指定的直方图桶边界创建滚动结果计数器
+// 参数:
+//   - bounds: 消耗时间直方图的桶边界,必须严格递增,参见 kmath.NewHistogram
+//   - opts: 可选配置项,包括窗口大小、时间间隔等
+//
+// 示例:
+//
+//	counter := NewRollingResultCounterWithBounds([]float64{0.5, 1, 5, 10, 50})
+//	counter.AddSuccess(3.2)
+//	p99 := counter.SuccessLatency().P99
+func NewRollingResultCounterWithBounds[T kmath.Number](bounds []T, opts ...kcollection.RollingWindowOption[T, *kcollection.Bucket[T]]) *RollingResultCounter[T] {
+	hOpts := histogramWindowOptions(opts...)
+	newBucket := func() *kcollection.HistogramBucket[T] {
+		return kcollection.NewHistogramBucket(bounds)
+	}
+	r := &RollingResultCounter[T]{
+		successWindow: kcollection.NewRollingWindow(newBucket, hOpts...),
+		failWindow:    kcollection.NewRollingWindow(newBucket, hOpts...),
+	}
+	return r
+}
+
+// histogramWindowOptions 将 Bucket 窗口的配置项转换为 HistogramBucket 窗口的配置项
+func histogramWindowOptions[T kmath.Number](opts ...kcollection.RollingWindowOption[T, *kcollection.Bucket[T]]) []kcollection.RollingWindowOption[T, *kcollection.HistogramBucket[T]] {
 	opt := kcollection.NewRollingWindowOptions[T, *kcollection.Bucket[T]]()
 	for _, o := range opts {
 		o(opt)
 	}
-	r := &RollingResultCounter[T]{
-		successWindow: kcollection.NewRollingWindow(func() *kcollection.Bucket[T] {
-			return &kcollection.Bucket[T]{}
-		}, opts...),
-		failWindow: kcollection.NewRollingWindow(func() *kcollection.Bucket[T] {
-			return &kcollection.Bucket[T]{}
-		}, opts...),
+	return []kcollection.RollingWindowOption[T, *kcollection.HistogramBucket[T]]{
+		kcollection.WithSize[T, *kcollection.HistogramBucket[T]](opt.Size),
+		kcollection.WithInterval[T, *kcollection.HistogramBucket[T]](opt.Interval),
+		kcollection.WithIgnoreCurrent[T, *kcollection.HistogramBucket[T]](opt.IgnoreCurrent),
+		kcollection.WithAlignWallClock[T, *kcollection.HistogramBucket[T]](opt.AlignWallClock),
+		kcollection.WithClock[T, *kcollection.HistogramBucket[T]](opt.Clock),
 	}
-	return r
 }
 
 // AddSuccess 添加一个成功请求的记录
@@ -50,6 +120,10 @@ func NewRollingResultCounter[T kmath.Number](opts ...kcollection.RollingWindowOp
 //   - consumeTime: 请求消耗的时间
 func (r *RollingResultCounter[T]) AddSuccess(consumeTime T) {
 	r.successWindow.Add(consumeTime)
+	// 成功请求只会降低错误率,仅在有钩子处于超限状态时检查是否恢复
+	if r.exceeded.Load() > 0 {
+		r.checkErrorRate()
+	}
 }
 
 // AddFail 添加一个失败请求的记录
@@ -57,6 +131,87 @@ func (r *RollingResultCounter[T]) AddSuccess(consumeTime T) {
 //   - consumeTime: 请求消耗的时间
 func (r *RollingResultCounter[T]) AddFail(consumeTime T) {
 	r.failWindow.Add(consumeTime)
+	if r.hookN.Load() > 0 {
+		r.checkErrorRate()
+	}
+}
+
+// ErrorRate 返回窗口内的错误率
+// 返回:
+//   - rate: 失败请求数/总请求数,没有请求时为0
+//   - samples: 窗口内的总请求数
+func (r *RollingResultCounter[T]) ErrorRate() (rate float64, samples int64) {
+	var success, fail int64
+	r.Reduce(func(count int64, _ T) {
+		success += count
+	}, func(count int64, _ T) {
+		fail += count
+	})
+	samples = success + fail
+	if samples == 0 {
+		return 0, 0
+	}
+	return float64(fail) / float64(samples), samples
+}
+
+// OnErrorRateExceeds 注册错误率超限钩子,窗口内错误率超过阈值时调用fn,可用于告警或熔断
+// 参数:
+//   - threshold: 错误率阈值,范围为0到1
+//   - minSamples: 最少请求数,窗口内请求数不足时不触发,避免少量请求导致误判
+//   - fn: 回调函数,接收触发时的错误率
+//
+// 返回:
+//   - cancel: 注销钩子的函数
+//
+// 注意:
+//   - 只在错误率由未超限变为超限时回调一次,错误率回落到阈值及以下后才会再次触发
+//   - 在 AddFail/AddSuccess 时检查,回调在调用方的goroutine中同步执行,耗时的操作应自行异步处理
+//
+// 示例:
+//
+//	cancel := counter.OnErrorRateExceeds(0.5, 20, func(rate float64) {
+//	    breaker.Open()
+//	})
+//	defer cancel()
+func (r *RollingResultCounter[T]) OnErrorRateExceeds(threshold float64, minSamples int64, fn func(rate float64)) (cancel func()) {
+	h := &errorRateHook{threshold: threshold, minSamples: minSamples, fn: fn}
+	r.hookMu.Lock()
+	r.hooks = append(r.hooks, h)
+	r.hookN.Store(int32(len(r.hooks)))
+	r.hookMu.Unlock()
+	return func() {
+		r.hookMu.Lock()
+		defer r.hookMu.Unlock()
+		if i := slices.Index(r.hooks, h); i >= 0 {
+			r.hooks = slices.Delete(r.hooks, i, i+1)
+			if h.exceeded {
+				r.exceeded.Add(-1)
+			}
+		}
+		r.hookN.Store(int32(len(r.hooks)))
+	}
+}
+
+// checkErrorRate 检查所有错误率钩子,并回调新进入超限状态的钩子
+func (r *RollingResultCounter[T]) checkErrorRate() {
+	rate, samples := r.ErrorRate()
+	var fired []func(float64)
+	r.hookMu.Lock()
+	for _, h := range r.hooks {
+		exceeded := samples >= h.minSamples && rate > h.threshold
+		switch {
+		case exceeded && !h.exceeded:
+			fired = append(fired, h.fn)
+			r.exceeded.Add(1)
+		case !exceeded && h.exceeded:
+			r.exceeded.Add(-1)
+		}
+		h.exceeded = exceeded
+	}
+	r.hookMu.Unlock()
+	for _, fn := range fired {
+		fn(rate)
+	}
 }
 
 // Reduce 遍历所有有效的桶并执行回调函数
@@ -74,70 +229,158 @@ func (r *RollingResultCounter[T]) AddFail(consumeTime T) {
 //	  func(fc int64, ft int64) { fmt.Println("失败:", fc, ft) },
 //	)
 func (r *RollingResultCounter[T]) Reduce(successFn func(successCount int64, successConsumeTime T), failFn func(failCount int64, failConsumeTime T)) {
-	r.successWindow.Reduce(func(b *kcollection.Bucket[T]) {
-		successFn(b.Count, b.Sum)
+	r.successWindow.Reduce(func(b *kcollection.HistogramBucket[T]) {
+		successFn(b.Count(), b.Sum())
 	})
-	r.failWindow.Reduce(func(b *kcollection.Bucket[T]) {
-		failFn(b.Count, b.Sum)
+	r.failWindow.Reduce(func(b *kcollection.HistogramBucket[T]) {
+		failFn(b.Count(), b.Sum())
 	})
 }
 
+// ReduceLatency 遍历所有有效的桶,获取每个桶内消耗时间的分布
+// 参数:
+//   - successFn: 处理成功请求桶的函数,接收该桶的消耗时间统计
+//   - failFn: 处理失败请求桶的函数,接收该桶的消耗时间统计
+//
+// 注意:
+//   - 按时间从旧到新遍历,如果设置了ignoreCurrent为true,则不会处理当前桶
+func (r *RollingResultCounter[T]) ReduceLatency(successFn func(stats LatencyStats), failFn func(stats LatencyStats)) {
+	r.successWindow.Reduce(func(b *kcollection.HistogramBucket[T]) {
+		successFn(latencyStats(b.Histogram))
+	})
+	r.failWindow.Reduce(func(b *kcollection.HistogramBucket[T]) {
+		failFn(latencyStats(b.Histogram))
+	})
+}
+
+// SuccessLatency 返回整个窗口内成功请求消耗时间的分布
+//
+// 示例:
+//
+//	stats := counter.SuccessLatency()
+//	fmt.Println(stats.P50, stats.P99, stats.Max)
+func (r *RollingResultCounter[T]) SuccessLatency() LatencyStats {
+	return latencyStats(kcollection.ReduceHistogram(r.successWindow))
+}
+
+// FailLatency 返回整个窗口内失败请求消耗时间的分布
+func (r *RollingResultCounter[T]) FailLatency() LatencyStats {
+	return latencyStats(kcollection.ReduceHistogram(r.failWindow))
+}
+
+// latencyStats 根据直方图计算消耗时间的分布,h为nil时返回零值
+func latencyStats[T kmath.Number](h *kmath.Histogram[T]) LatencyStats {
+	if h == nil || h.Count() == 0 {
+		return LatencyStats{}
+	}
+	return LatencyStats{
+		Count: h.Count(),
+		Avg:   h.Mean(),
+		P50:   h.Percentile(50),
+		P90:   h.Percentile(90),
+		P99:   h.Percentile(99),
+		Max:   float64(h.Max()),
+	}
+}
+
+// Rate 返回最近一段时间内每秒的成功和失败请求数
+// 参数:
+//   - last: 可选的时间范围,默认为整个窗口,参见 RateCounter.Rate
+//
+// 返回:
+//   - success: 每秒成功请求数
+//   - fail: 每秒失败请求数
+func (r *RollingResultCounter[T]) Rate(last ...time.Duration) (success, fail float64) {
+	count := func(b *kcollection.HistogramBucket[T]) float64 {
+		return float64(b.Count())
+	}
+	return windowRate(r.successWindow, count, last...), windowRate(r.failWindow, count, last...)
+}
+
+// RollingResultSnapshot 滚动结果计数器的快照
+type RollingResultSnapshot struct {
+	Interval time.Duration    `json:"interval"` // 每个桶的时间间隔
+	Buckets  []BucketSnapshot `json:"buckets"`  // 每个桶的统计,按时间从新到旧排列
+	Success  LatencyStats     `json:"success"`  // 整个窗口内成功请求的统计
+	Fail     LatencyStats     `json:"fail"`     // 整个窗口内失败请求的统计
+}
+
+// BucketSnapshot 单个桶的统计
+// 桶覆盖的时间范围为距今 [From, To) 的区间,如 From=0,To=interval 表示当前桶
+type BucketSnapshot struct {
+	From    time.Duration `json:"from"`    // 距今的起始时间
+	To      time.Duration `json:"to"`      // 距今的结束时间
+	Success LatencyStats  `json:"success"` // 成功请求的统计
+	Fail    LatencyStats  `json:"fail"`    // 失败请求的统计
+}
+
+// Snapshot 获取计数器的结构化快照,便于看板和测试以编程的方式使用
+// 返回:
+//   - RollingResultSnapshot: 每个桶及整个窗口的请求数量、平均消耗时间和延迟分布
+//
+// 注意:
+//   - 如果设置了ignoreCurrent为true,当前桶(Buckets[0])的统计为零值
+//
+// 示例:
+//
+//	s := counter.Snapshot()
+//	for _, b := range s.Buckets {
+//	    fmt.Println(b.From, b.To, b.Success.Count, b.Success.Avg)
+//	}
+func (r *RollingResultCounter[T]) Snapshot() RollingResultSnapshot {
+	size := r.successWindow.Size()
+	interval := r.successWindow.Interval()
+	buckets := make([]BucketSnapshot, size)
+	for i := range buckets {
+		buckets[i].From = time.Duration(i) * interval
+		buckets[i].To = time.Duration(i+1) * interval
+	}
+	// Reduce 按时间从旧到新遍历,快照中按从新到旧排列
+	i := size
+	r.successWindow.Reduce(func(b *kcollection.HistogramBucket[T]) {
+		i--
+		buckets[i].Success = latencyStats(b.Histogram)
+	})
+	i = size
+	r.failWindow.Reduce(func(b *kcollection.HistogramBucket[T]) {
+		i--
+		buckets[i].Fail = latencyStats(b.Histogram)
+	})
+	return RollingResultSnapshot{
+		Interval: interval,
+		Buckets:  buckets,
+		Success:  r.SuccessLatency(),
+		Fail:     r.FailLatency(),
+	}
+}
+
+// MarshalJSON 将计数器的快照编码为JSON,参见 Snapshot
+func (r *RollingResultCounter[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Snapshot())
+}
+
 // Info 获取计数器的详细信息
 // 返回:
 //   - string: 包含成功和失败请求的详细统计信息
+//
+// 注意:
+//   - 需要以编程的方式使用统计数据时,使用 Snapshot
 func (r *RollingResultCounter[T]) Info(timeUnit ...string) string {
 	if len(timeUnit) == 0 {
 		timeUnit = []string{"ms"}
 	}
-	size := r.successWindow.Opts.Size
-	interval := r.successWindow.Opts.Interval
-	// size = 5  (5-1)*interval -> 5*interval
-	// ...
-	// size = 1  (1-1)*interval -> 1 *interval
-	temp := make([]struct {
-		successCount          int64
-		avgSuccessConsumeTime float64
-		failCount             int64
-		avgFailConsumeTime    float64
-	}, size)
-
-	r.successWindow.Reduce(func(b *kcollection.Bucket[T]) {
-		d := 0.0
-		if b.Count > 0 {
-			d = float64(b.Sum) / float64(b.Count)
-		}
-		size--
-		temp[size] = struct {
-			successCount          int64
-			avgSuccessConsumeTime float64
-			failCount             int64
-			avgFailConsumeTime    float64
-		}{
-			successCount:          b.Count,
-			avgSuccessConsumeTime: float64(d),
-		}
-	})
-	size = r.failWindow.Opts.Size
-	r.failWindow.Reduce(func(b *kcollection.Bucket[T]) {
-		size--
-		d := 0.0
-		if b.Count > 0 {
-			d = float64(b.Sum) / float64(b.Count)
-		}
-		temp[size].failCount = b.Count
-		temp[size].avgFailConsumeTime = d
-	})
+	snapshot := r.Snapshot()
 	var info string
 	totalSuccessCount := int64(0)
 	totalFailCount := int64(0)
 	totalSuccessAvgConsumeTime := float64(0)
 	totalFailAvgConsumeTime := float64(0)
-	for i := 0; i < len(temp); i++ {
-		info += fmt.Sprintf(" [time:%v-%v,successCount: %v, successAvgConsumeTime: %v%s,failCount: %v, failAvgConsumeTime: %v%s] ", time.Duration(i)*interval, time.Duration(i+1)*interval, temp[i].successCount, temp[i].avgSuccessConsumeTime, timeUnit[0], temp[i].failCount, temp[i].avgFailConsumeTime, timeUnit[0])
-		totalSuccessCount += temp[i].successCount
-		totalFailCount += temp[i].failCount
-		totalSuccessAvgConsumeTime += temp[i].avgSuccessConsumeTime
-		totalFailAvgConsumeTime += temp[i].avgFailConsumeTime
+	for _, b := range snapshot.Buckets {
+		info += fmt.Sprintf(" [time:%v-%v,successCount: %v, successAvgConsumeTime: %v%s,failCount: %v, failAvgConsumeTime: %v%s] ", b.From, b.To, b.Success.Count, b.Success.Avg, timeUnit[0], b.Fail.Count, b.Fail.Avg, timeUnit[0])
+		totalSuccessCount += b.Success.Count
+		totalFailCount += b.Fail.Count
+		totalSuccessAvgConsumeTime += b.Success.Avg
+		totalFailAvgConsumeTime += b.Fail.Avg
 	}
 	info += fmt.Sprintf(" [totalSuccessCount: %v, totalSuccessAvgConsumeTime: %v%s, totalFailCount: %v, totalFailAvgConsumeTime: %v%s] ", totalSuccessCount, totalSuccessAvgConsumeTime, timeUnit[0], totalFailCount, totalFailAvgConsumeTime, timeUnit[0])
 	return info
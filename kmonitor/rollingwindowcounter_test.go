@@ -1,6 +1,7 @@
 package kmonitor
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -48,3 +49,155 @@ func TestNewRollingResultCounter(t *testing.T) {
 	assert.Equal(t, int64(1), failCount)
 	assert.Equal(t, int64(50), failTime)
 }
+
+func TestRollingResultCounterLatency(t *testing.T) {
+	t.Run("窗口内的延迟分布", func(t *testing.T) {
+		counter := NewRollingResultCounter[int64]()
+		for i := int64(1); i <= 100; i++ {
+			counter.AddSuccess(i)
+		}
+		counter.AddFail(3000)
+
+		stats := counter.SuccessLatency()
+		assert.Equal(t, int64(100), stats.Count)
+		assert.Equal(t, 50.5, stats.Avg)
+		assert.InDelta(t, 50, stats.P50, 5)
+		assert.InDelta(t, 90, stats.P90, 10)
+		assert.InDelta(t, 99, stats.P99, 2)
+		assert.Equal(t, 100.0, stats.Max)
+
+		fail := counter.FailLatency()
+		assert.Equal(t, int64(1), fail.Count)
+		assert.Equal(t, 3000.0, fail.P99)
+		assert.Equal(t, 3000.0, fail.Max)
+	})
+
+	t.Run("范围较小的整数类型", func(t *testing.T) {
+		assert.Equal(t, []int8{1, 2, 5, 10, 20, 50, 100}, defaultLatencyBounds[int8]())
+		assert.Equal(t, []uint8{1, 2, 5, 10, 20, 50, 100, 200}, defaultLatencyBounds[uint8]())
+
+		counter := NewRollingResultCounter[int16]()
+		counter.AddSuccess(30)
+		counter.AddSuccess(20000)
+		stats := counter.SuccessLatency()
+		assert.Equal(t, int64(2), stats.Count)
+		assert.Equal(t, 20000.0, stats.Max)
+		assert.NotPanics(t, func() {
+			NewRollingResultCounter[int8]().AddSuccess(120)
+			NewRollingResultCounter[uint8]().AddFail(250)
+		})
+	})
+
+	t.Run("空窗口", func(t *testing.T) {
+		counter := NewRollingResultCounter[int64]()
+		assert.Equal(t, LatencyStats{}, counter.SuccessLatency())
+		assert.Equal(t, LatencyStats{}, counter.FailLatency())
+	})
+
+	t.Run("自定义桶边界与按桶遍历", func(t *testing.T) {
+		counter := NewRollingResultCounterWithBounds([]float64{0.5, 1, 2},
+			kcollection.WithSize[float64, *kcollection.Bucket[float64]](2),
+			kcollection.WithInterval[float64, *kcollection.Bucket[float64]](time.Hour))
+		counter.AddSuccess(0.3)
+		counter.AddSuccess(1.5)
+
+		var buckets []LatencyStats
+		counter.ReduceLatency(func(stats LatencyStats) {
+			buckets = append(buckets, stats)
+		}, func(stats LatencyStats) {})
+		assert.Len(t, buckets, 2)
+		assert.Equal(t, LatencyStats{}, buckets[0])
+		assert.Equal(t, int64(2), buckets[1].Count)
+		assert.Equal(t, 1.5, buckets[1].Max)
+	})
+}
+
+func TestRollingResultCounterSnapshot(t *testing.T) {
+	const interval = 50 * time.Millisecond
+	counter := NewRollingResultCounter(
+		kcollection.WithSize[int64, *kcollection.Bucket[int64]](3),
+		kcollection.WithInterval[int64, *kcollection.Bucket[int64]](interval))
+	counter.AddSuccess(10)
+	counter.AddSuccess(30)
+	time.Sleep(interval)
+	counter.AddFail(5)
+
+	s := counter.Snapshot()
+	assert.Equal(t, interval, s.Interval)
+	assert.Len(t, s.Buckets, 3)
+	// 从新到旧排列
+	assert.Equal(t, time.Duration(0), s.Buckets[0].From)
+	assert.Equal(t, interval, s.Buckets[0].To)
+	assert.Equal(t, int64(1), s.Buckets[0].Fail.Count)
+	assert.Equal(t, int64(0), s.Buckets[0].Success.Count)
+	assert.Equal(t, int64(2), s.Buckets[1].Success.Count)
+	assert.Equal(t, 20.0, s.Buckets[1].Success.Avg)
+	assert.Equal(t, LatencyStats{}, s.Buckets[2].Success)
+	assert.Equal(t, int64(2), s.Success.Count)
+	assert.Equal(t, int64(1), s.Fail.Count)
+
+	data, err := json.Marshal(counter)
+	assert.NoError(t, err)
+	var decoded RollingResultSnapshot
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, interval, decoded.Interval)
+	assert.Len(t, decoded.Buckets, 3)
+	assert.Equal(t, s.Success, decoded.Success)
+}
+
+func TestRollingResultCounterErrorRate(t *testing.T) {
+	t.Run("错误率", func(t *testing.T) {
+		counter := NewRollingResultCounter[int64]()
+		rate, samples := counter.ErrorRate()
+		assert.Equal(t, 0.0, rate)
+		assert.Equal(t, int64(0), samples)
+
+		counter.AddSuccess(1)
+		counter.AddSuccess(1)
+		counter.AddSuccess(1)
+		counter.AddFail(1)
+		rate, samples = counter.ErrorRate()
+		assert.Equal(t, 0.25, rate)
+		assert.Equal(t, int64(4), samples)
+	})
+
+	t.Run("超限钩子", func(t *testing.T) {
+		counter := NewRollingResultCounter[int64]()
+		var rates []float64
+		cancel := counter.OnErrorRateExceeds(0.5, 4, func(rate float64) {
+			rates = append(rates, rate)
+		})
+
+		counter.AddFail(1)
+		counter.AddFail(1)
+		counter.AddFail(1)
+		assert.Empty(t, rates, "请求数不足时不触发")
+
+		counter.AddSuccess(1) // 3/4
+		counter.AddFail(1)    // 4/5
+		assert.Equal(t, []float64{0.8}, rates, "进入超限状态时只触发一次")
+		counter.AddFail(1)
+		assert.Len(t, rates, 1)
+
+		// 错误率回落后再次超限会再次触发
+		for i := 0; i < 6; i++ {
+			counter.AddSuccess(1) // 5/12
+		}
+		counter.AddFail(1) // 6/13
+		counter.AddFail(1) // 7/14
+		assert.Len(t, rates, 1, "等于阈值不触发")
+		counter.AddFail(1) // 8/15
+		assert.Len(t, rates, 2)
+
+		cancel()
+		cancel()
+		assert.Equal(t, int32(0), counter.exceeded.Load())
+		for i := 0; i < 20; i++ {
+			counter.AddSuccess(1)
+		}
+		for i := 0; i < 40; i++ {
+			counter.AddFail(1)
+		}
+		assert.Len(t, rates, 2, "注销后不再触发")
+	})
+}
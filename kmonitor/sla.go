@@ -0,0 +1,268 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mtgnorton/k/kcollection"
+	"github.com/mtgnorton/k/ktime"
+)
+
+// SLAWindow SLA统计窗口
+type SLAWindow struct {
+	Name     string        // 窗口名称,如"1h"
+	Duration time.Duration // 窗口时长
+	Buckets  int           // 桶的数量,决定窗口滚动的粒度
+}
+
+// SLATrackerOptions SLA跟踪器的配置项
+type SLATrackerOptions struct {
+	Windows []SLAWindow // 统计窗口
+	Clock   ktime.Clock // 窗口滚动和定期探测使用的时钟
+}
+
+type SLATrackerOption func(*SLATrackerOptions)
+
+// NewSLATrackerOptions 默认统计1小时、24小时、30天三个窗口,粒度分别为1分钟、15分钟、1小时
+func NewSLATrackerOptions() *SLATrackerOptions {
+	return &SLATrackerOptions{
+		Windows: []SLAWindow{
+			{Name: "1h", Duration: time.Hour, Buckets: 60},
+			{Name: "24h", Duration: 24 * time.Hour, Buckets: 96},
+			{Name: "30d", Duration: 30 * 24 * time.Hour, Buckets: 720},
+		},
+		Clock: ktime.RealClock,
+	}
+}
+
+// WithSLAWindows 设置统计窗口,替换默认的窗口
+func WithSLAWindows(windows ...SLAWindow) SLATrackerOption {
+	return func(o *SLATrackerOptions) {
+		o.Windows = windows
+	}
+}
+
+// WithSLAClock 设置窗口滚动和定期探测使用的时钟,默认为 ktime.RealClock
+// 使用 ktime.FakeClock 时可以不用真正等待就测试1小时、30天等窗口的滚动
+func WithSLAClock(clock ktime.Clock) SLATrackerOption {
+	return func(o *SLATrackerOptions) {
+		o.Clock = clock
+	}
+}
+
+// SLAWindowReport 单个窗口的SLA统计
+type SLAWindowReport struct {
+	Name         string        `json:"name"`         // 窗口名称
+	Duration     time.Duration `json:"duration"`     // 窗口时长
+	Success      int64         `json:"success"`      // 成功的探测次数
+	Total        int64         `json:"total"`        // 总的探测次数
+	Availability float64       `json:"availability"` // 可用率,取值0~1
+	BurnRate     float64       `json:"burn_rate"`    // 错误预算的消耗速率
+}
+
+// SLAReport SLA报告
+type SLAReport struct {
+	Objective float64           `json:"objective"` // 可用率目标
+	Windows   []SLAWindowReport `json:"windows"`   // 按窗口时长从短到长排列
+}
+
+type slaWindow struct {
+	SLAWindow
+	rw *usageWindow
+}
+
+// SLATracker SLA跟踪器,记录每次探测的成功或失败,计算多个窗口的可用率和错误预算消耗速率
+//
+// 消耗速率 = (1 - 可用率) / (1 - 目标可用率)
+//   - 等于1表示错误预算恰好在SLO周期结束时耗尽
+//   - 大于1表示消耗过快,如1小时窗口的消耗速率超过14.4时,30天的错误预算会在2天内耗尽
+type SLATracker struct {
+	mu        sync.Mutex
+	objective float64
+	clock     ktime.Clock
+	windows   []*slaWindow // 按窗口时长从短到长排列
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewSLATracker 创建一个新的SLA跟踪器
+//
+// 参数说明:
+//   - objective: 可用率目标,如0.999,取值必须在(0,1)之间,否则会panic
+//   - opts: 可选配置项,参见 SLATrackerOptions
+//
+// 注意事项:
+//   - 线程安全
+//   - 窗口的时长和桶的数量必须大于0,否则会panic
+//   - 没有探测数据时可用率为1,消耗速率为0
+//
+// 示例:
+//
+//	sla := NewSLATracker(0.999)
+//	sla.Start(10*time.Second, func() bool {
+//	    return http.Get("http://localhost/healthz") == nil
+//	})
+//	defer sla.Stop()
+//	if sla.BurnRate(time.Hour) > 14.4 {
+//	    // 错误预算消耗过快,告警
+//	}
+func NewSLATracker(objective float64, opts ...SLATrackerOption) *SLATracker {
+	if objective <= 0 || objective >= 1 {
+		panic("objective must be between 0 and 1")
+	}
+	o := NewSLATrackerOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	if len(o.Windows) == 0 {
+		panic("at least one window is required")
+	}
+	t := &SLATracker{objective: objective, clock: o.Clock}
+	for _, w := range o.Windows {
+		if w.Duration <= 0 || w.Buckets <= 0 {
+			panic("window duration and buckets must be greater than 0")
+		}
+		t.windows = append(t.windows, &slaWindow{
+			SLAWindow: w,
+			rw:        newUsageWindow(w.Buckets, max(w.Duration/time.Duration(w.Buckets), 1), o.Clock),
+		})
+	}
+	sort.SliceStable(t.windows, func(i, j int) bool {
+		return t.windows[i].Duration < t.windows[j].Duration
+	})
+	return t
+}
+
+// Objective 返回可用率目标
+func (t *SLATracker) Objective() float64 {
+	return t.objective
+}
+
+// Record 记录一次探测的结果
+func (t *SLATracker) Record(success bool) {
+	var v float64
+	if success {
+		v = 1
+	}
+	for _, w := range t.windows {
+		w.rw.Add(v)
+	}
+}
+
+// Availability 返回最近一段时间的可用率
+// 参数:
+//   - last: 时间范围,使用时长不小于last的最短窗口计算,超过所有窗口时按最长的窗口计算
+//
+// 返回:
+//   - rate: 可用率,取值0~1,没有探测数据时为1
+//   - total: 参与计算的探测次数
+func (t *SLATracker) Availability(last time.Duration) (rate float64, total int64) {
+	w := t.windowFor(last)
+	success, total := w.count(last)
+	if total == 0 {
+		return 1, 0
+	}
+	return float64(success) / float64(total), total
+}
+
+// BurnRate 返回最近一段时间错误预算的消耗速率
+// 参数:
+//   - last: 时间范围,参见 Availability
+func (t *SLATracker) BurnRate(last time.Duration) float64 {
+	availability, _ := t.Availability(last)
+	return t.burnRate(availability)
+}
+
+// Report 返回所有窗口的SLA统计
+func (t *SLATracker) Report() SLAReport {
+	report := SLAReport{Objective: t.objective, Windows: make([]SLAWindowReport, 0, len(t.windows))}
+	for _, w := range t.windows {
+		success, total := w.count(w.Duration)
+		availability := 1.0
+		if total > 0 {
+			availability = float64(success) / float64(total)
+		}
+		report.Windows = append(report.Windows, SLAWindowReport{
+			Name:         w.Name,
+			Duration:     w.Duration,
+			Success:      success,
+			Total:        total,
+			Availability: availability,
+			BurnRate:     t.burnRate(availability),
+		})
+	}
+	return report
+}
+
+// MarshalJSON 以SLA报告编码为JSON
+func (t *SLATracker) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Report())
+}
+
+// Start 按固定间隔执行探测并记录结果,重复调用无效果
+// 参数:
+//   - interval: 探测间隔,必须大于0,否则会panic
+//   - probe: 探测函数,返回true表示成功
+func (t *SLATracker) Start(interval time.Duration, probe func() bool) {
+	if interval <= 0 {
+		panic("interval must be greater than 0")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stop != nil {
+		return
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	t.stop, t.done = stop, done
+	go func() {
+		defer close(done)
+		ticker := t.clock.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C():
+				t.Record(probe())
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止探测并等待其退出,未启动时无效果
+func (t *SLATracker) Stop() {
+	t.mu.Lock()
+	stop, done := t.stop, t.done
+	t.stop, t.done = nil, nil
+	t.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (t *SLATracker) burnRate(availability float64) float64 {
+	return (1 - availability) / (1 - t.objective)
+}
+
+// windowFor 返回时长不小于last的最短窗口,不存在时返回最长的窗口
+func (t *SLATracker) windowFor(last time.Duration) *slaWindow {
+	for _, w := range t.windows {
+		if w.Duration >= last {
+			return w
+		}
+	}
+	return t.windows[len(t.windows)-1]
+}
+
+// count 返回窗口内最近一段时间成功的和总的探测次数,两者在同一次遍历中计算
+func (w *slaWindow) count(last time.Duration) (success, total int64) {
+	windowReduce(w.rw, func(b *kcollection.Bucket[float64]) {
+		success += int64(b.Sum)
+		total += b.Count
+	}, last)
+	return success, total
+}
@@ -0,0 +1,137 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mtgnorton/k/ktime"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLATracker(t *testing.T) {
+	t.Run("可用率与消耗速率", func(t *testing.T) {
+		sla := NewSLATracker(0.99)
+		rate, total := sla.Availability(time.Hour)
+		assert.Equal(t, 1.0, rate, "没有数据时可用率为1")
+		assert.Equal(t, int64(0), total)
+		assert.Equal(t, 0.0, sla.BurnRate(time.Hour))
+
+		for i := 0; i < 98; i++ {
+			sla.Record(true)
+		}
+		sla.Record(false)
+		sla.Record(false)
+
+		rate, total = sla.Availability(time.Hour)
+		assert.InDelta(t, 0.98, rate, 1e-9)
+		assert.Equal(t, int64(100), total)
+		// 错误率2%,预算1%,消耗速率为2
+		assert.InDelta(t, 2, sla.BurnRate(time.Hour), 1e-9)
+		assert.InDelta(t, 2, sla.BurnRate(30*24*time.Hour), 1e-9)
+		assert.InDelta(t, 2, sla.BurnRate(365*24*time.Hour), 1e-9)
+	})
+
+	t.Run("窗口滚动", func(t *testing.T) {
+		sla := NewSLATracker(0.9, WithSLAWindows(
+			SLAWindow{Name: "long", Duration: time.Second, Buckets: 10},
+			SLAWindow{Name: "short", Duration: 200 * time.Millisecond, Buckets: 2},
+		))
+		sla.Record(false)
+		time.Sleep(250 * time.Millisecond)
+		sla.Record(true)
+
+		// 短窗口中失败的探测已经过期
+		rate, total := sla.Availability(200 * time.Millisecond)
+		assert.Equal(t, 1.0, rate)
+		assert.Equal(t, int64(1), total)
+		rate, total = sla.Availability(time.Second)
+		assert.InDelta(t, 0.5, rate, 1e-9)
+		assert.Equal(t, int64(2), total)
+
+		report := sla.Report()
+		assert.Equal(t, 0.9, report.Objective)
+		assert.Len(t, report.Windows, 2)
+		assert.Equal(t, "short", report.Windows[0].Name, "按窗口时长从短到长排列")
+		assert.Equal(t, "long", report.Windows[1].Name)
+		assert.InDelta(t, 5, report.Windows[1].BurnRate, 1e-9)
+		assert.Equal(t, int64(1), report.Windows[1].Success)
+
+		data, err := json.Marshal(sla)
+		assert.NoError(t, err)
+		var decoded SLAReport
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, report, decoded)
+	})
+
+	t.Run("使用FakeClock滚动默认窗口", func(t *testing.T) {
+		clock := ktime.NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+		sla := NewSLATracker(0.99, WithSLAClock(clock))
+		sla.Record(false)
+		sla.Record(true)
+
+		// 30分钟后最近10分钟没有探测,已过期的时间间隔不计入
+		clock.Advance(30 * time.Minute)
+		rate, total := sla.Availability(10 * time.Minute)
+		assert.Equal(t, 1.0, rate)
+		assert.Equal(t, int64(0), total)
+		rate, total = sla.Availability(time.Hour)
+		assert.InDelta(t, 0.5, rate, 1e-9)
+		assert.Equal(t, int64(2), total)
+
+		// 超过1小时后只有更长的窗口保留数据
+		clock.Advance(time.Hour)
+		report := sla.Report()
+		assert.Equal(t, int64(0), report.Windows[0].Total)
+		assert.Equal(t, int64(2), report.Windows[1].Total)
+		assert.Equal(t, int64(2), report.Windows[2].Total)
+
+		clock.Advance(30 * 24 * time.Hour)
+		_, total = sla.Availability(30 * 24 * time.Hour)
+		assert.Equal(t, int64(0), total)
+	})
+
+	t.Run("使用FakeClock定期探测", func(t *testing.T) {
+		clock := ktime.NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+		sla := NewSLATracker(0.99, WithSLAClock(clock))
+		probed := make(chan struct{})
+		sla.Start(time.Minute, func() bool {
+			probed <- struct{}{}
+			return true
+		})
+		defer sla.Stop()
+		for i := 0; i < 3; i++ {
+			clock.BlockUntil(1)
+			clock.Advance(time.Minute)
+			<-probed
+		}
+		assert.Eventually(t, func() bool {
+			_, total := sla.Availability(time.Hour)
+			return total == 3
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("定期探测", func(t *testing.T) {
+		sla := NewSLATracker(0.999)
+		var n atomic.Int32
+		sla.Start(10*time.Millisecond, func() bool {
+			return n.Add(1)%2 == 0
+		})
+		sla.Start(10*time.Millisecond, func() bool { return true })
+		time.Sleep(55 * time.Millisecond)
+		sla.Stop()
+		sla.Stop()
+
+		rate, total := sla.Availability(time.Hour)
+		assert.Equal(t, int64(n.Load()), total)
+		assert.InDelta(t, float64(n.Load()/2)/float64(n.Load()), rate, 1e-9)
+	})
+
+	t.Run("参数校验", func(t *testing.T) {
+		assert.Panics(t, func() { NewSLATracker(1) })
+		assert.Panics(t, func() { NewSLATracker(0) })
+		assert.Panics(t, func() { NewSLATracker(0.99, WithSLAWindows()) })
+		assert.Panics(t, func() { NewSLATracker(0.99, WithSLAWindows(SLAWindow{Duration: time.Hour})) })
+	})
+}
@@ -0,0 +1,244 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpanReport 时间段的报告
+type SpanReport struct {
+	Name     string        `json:"name"`               // 名称
+	Start    time.Time     `json:"start"`              // 开始时间
+	End      time.Time     `json:"end"`                // 结束时间,尚未结束时为生成报告的时间
+	Duration time.Duration `json:"duration"`           // 耗时
+	Finished bool          `json:"finished"`           // 是否已结束
+	Children []SpanReport  `json:"children,omitempty"` // 子时间段,按开始时间排列
+}
+
+// String 以树的形式输出报告,参见 Span.String
+func (r SpanReport) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.line())
+	r.writeChildren(&sb, "")
+	return sb.String()
+}
+
+func (r SpanReport) line() string {
+	if r.Finished {
+		return fmt.Sprintf("%s %s", r.Name, r.Duration)
+	}
+	return fmt.Sprintf("%s %s (running)", r.Name, r.Duration)
+}
+
+func (r SpanReport) writeChildren(sb *strings.Builder, prefix string) {
+	for i, child := range r.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(r.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		sb.WriteString("\n" + prefix + branch + child.line())
+		child.writeChildren(sb, prefix+indent)
+	}
+}
+
+// SpanExporter 时间段的导出器,根时间段结束时调用
+// 可以实现该接口将报告转换为 OpenTelemetry 的span,参见 StartSpan 的示例
+type SpanExporter interface {
+	ExportSpan(report SpanReport)
+}
+
+// SpanExporterFunc 函数形式的 SpanExporter
+type SpanExporterFunc func(report SpanReport)
+
+// ExportSpan 调用f(report)
+func (f SpanExporterFunc) ExportSpan(report SpanReport) {
+	f(report)
+}
+
+// SpanOptions 时间段的配置项
+type SpanOptions struct {
+	Exporter SpanExporter // 根时间段结束时的导出器
+}
+
+type SpanOption func(*SpanOptions)
+
+func NewSpanOptions() *SpanOptions {
+	return &SpanOptions{}
+}
+
+// WithSpanExporter 设置根时间段结束时的导出器
+func WithSpanExporter(exporter SpanExporter) SpanOption {
+	return func(o *SpanOptions) {
+		o.Exporter = exporter
+	}
+}
+
+// Span 可嵌套的时间段,用于统计任务中各个阶段的耗时
+type Span struct {
+	mu       sync.Mutex
+	name     string
+	start    time.Time
+	end      time.Time // 为零值时表示尚未结束
+	parent   *Span
+	children []*Span
+	opts     *SpanOptions
+}
+
+// StartSpan 开始一个根时间段
+//
+// 参数说明:
+//   - name: 名称
+//   - opts: 可选配置项,参见 SpanOptions
+//
+// 注意事项:
+//   - 线程安全,可以在多个goroutine中开始子时间段
+//   - 结束时间段会同时结束其所有尚未结束的子时间段
+//   - 相比 ConsumeTimeStatistics,可以表达嵌套的阶段
+//
+// 示例:
+//
+//	span := StartSpan("request", WithSpanExporter(SpanExporterFunc(func(r SpanReport) {
+//	    log.Println(r)
+//	})))
+//	db := span.StartChild("db")
+//	db.StartChild("query").Finish()
+//	db.Finish()
+//	span.StartChild("render").Finish()
+//	span.Finish()
+//	// request 120ms
+//	// ├── db 80ms
+//	// │   └── query 70ms
+//	// └── render 30ms
+//
+//	// 导出到 OpenTelemetry
+//	var export func(ctx context.Context, r SpanReport)
+//	export = func(ctx context.Context, r SpanReport) {
+//	    ctx, s := tracer.Start(ctx, r.Name, trace.WithTimestamp(r.Start))
+//	    for _, child := range r.Children {
+//	        export(ctx, child)
+//	    }
+//	    s.End(trace.WithTimestamp(r.End))
+//	}
+func StartSpan(name string, opts ...SpanOption) *Span {
+	o := NewSpanOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Span{
+		name:  name,
+		start: time.Now(),
+		opts:  o,
+	}
+}
+
+// StartChild 开始一个子时间段
+// 当前时间段已经结束时,子时间段立即结束,耗时为0
+func (s *Span) StartChild(name string) *Span {
+	child := &Span{
+		name:   name,
+		start:  time.Now(),
+		parent: s,
+		opts:   s.opts,
+	}
+	s.mu.Lock()
+	if !s.end.IsZero() {
+		child.end = child.start
+	}
+	s.children = append(s.children, child)
+	s.mu.Unlock()
+	return child
+}
+
+// Name 返回名称
+func (s *Span) Name() string {
+	return s.name
+}
+
+// Finish 结束时间段,重复调用无效果
+// 根时间段结束时,如果设置了导出器,会将报告传递给导出器
+func (s *Span) Finish() {
+	if !s.finish(time.Now()) {
+		return
+	}
+	if s.parent == nil && s.opts.Exporter != nil {
+		s.opts.Exporter.ExportSpan(s.Report())
+	}
+}
+
+// finish 以end为结束时间结束时间段及其尚未结束的子时间段
+// 返回:
+//   - bool: 是否是本次调用结束的
+func (s *Span) finish(end time.Time) bool {
+	s.mu.Lock()
+	if !s.end.IsZero() {
+		s.mu.Unlock()
+		return false
+	}
+	s.end = end
+	children := s.children
+	s.mu.Unlock()
+	for _, child := range children {
+		child.finish(end)
+	}
+	return true
+}
+
+// Duration 返回耗时,尚未结束时返回到现在的耗时
+func (s *Span) Duration() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.end.IsZero() {
+		return time.Since(s.start)
+	}
+	return s.end.Sub(s.start)
+}
+
+// Report 返回时间段及其所有子时间段的报告
+func (s *Span) Report() SpanReport {
+	return s.report(time.Now())
+}
+
+func (s *Span) report(now time.Time) SpanReport {
+	s.mu.Lock()
+	r := SpanReport{
+		Name:     s.name,
+		Start:    s.start,
+		End:      s.end,
+		Finished: !s.end.IsZero(),
+	}
+	children := s.children
+	s.mu.Unlock()
+	if !r.Finished {
+		r.End = now
+	}
+	r.Duration = r.End.Sub(r.Start)
+	for _, child := range children {
+		r.Children = append(r.Children, child.report(now))
+	}
+	// 多个goroutine同时开始子时间段时添加的顺序可能与开始时间不一致
+	sort.SliceStable(r.Children, func(i, j int) bool {
+		return r.Children[i].Start.Before(r.Children[j].Start)
+	})
+	return r
+}
+
+// String 以树的形式输出报告
+//
+// 示例:
+//
+//	request 120ms
+//	├── db 80ms
+//	│   └── query 70ms
+//	└── render 30ms (running)
+func (s *Span) String() string {
+	return s.Report().String()
+}
+
+// MarshalJSON 将报告编码为JSON,参见 Report
+func (s *Span) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Report())
+}
@@ -0,0 +1,95 @@
+package kmonitor
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpan(t *testing.T) {
+	t.Run("嵌套与导出", func(t *testing.T) {
+		var exported []SpanReport
+		root := StartSpan("request", WithSpanExporter(SpanExporterFunc(func(r SpanReport) {
+			exported = append(exported, r)
+		})))
+		db := root.StartChild("db")
+		query := db.StartChild("query")
+		time.Sleep(10 * time.Millisecond)
+		query.Finish()
+		db.Finish()
+		db.Finish()
+		assert.Empty(t, exported, "子时间段结束时不导出")
+		render := root.StartChild("render")
+		root.Finish()
+		root.Finish()
+
+		assert.Len(t, exported, 1, "重复结束只导出一次")
+		r := exported[0]
+		assert.Equal(t, "request", r.Name)
+		assert.True(t, r.Finished)
+		assert.Len(t, r.Children, 2)
+		assert.Equal(t, "db", r.Children[0].Name)
+		assert.Equal(t, "query", r.Children[0].Children[0].Name)
+		assert.GreaterOrEqual(t, r.Children[0].Children[0].Duration, 10*time.Millisecond)
+		assert.GreaterOrEqual(t, r.Duration, r.Children[0].Duration)
+
+		// 父时间段结束时同时结束子时间段
+		assert.True(t, r.Children[1].Finished)
+		assert.Equal(t, r.End, r.Children[1].End)
+		assert.Equal(t, render.Duration(), r.Children[1].Duration)
+	})
+
+	t.Run("文本报告", func(t *testing.T) {
+		r := SpanReport{Name: "request", Duration: 120 * time.Millisecond, Finished: true, Children: []SpanReport{
+			{Name: "db", Duration: 80 * time.Millisecond, Finished: true, Children: []SpanReport{
+				{Name: "query", Duration: 70 * time.Millisecond, Finished: true},
+			}},
+			{Name: "render", Duration: 30 * time.Millisecond},
+		}}
+		assert.Equal(t, "request 120ms\n"+
+			"├── db 80ms\n"+
+			"│   └── query 70ms\n"+
+			"└── render 30ms (running)", r.String())
+	})
+
+	t.Run("JSON报告", func(t *testing.T) {
+		root := StartSpan("job")
+		root.StartChild("step1").Finish()
+		root.StartChild("step2")
+
+		data, err := json.Marshal(root)
+		assert.NoError(t, err)
+		var r SpanReport
+		assert.NoError(t, json.Unmarshal(data, &r))
+		assert.Equal(t, "job", r.Name)
+		assert.False(t, r.Finished)
+		assert.Len(t, r.Children, 2)
+		assert.True(t, r.Children[0].Finished)
+		assert.False(t, r.Children[1].Finished)
+		assert.Contains(t, root.String(), "step2")
+	})
+
+	t.Run("已结束的时间段开始子时间段", func(t *testing.T) {
+		root := StartSpan("job")
+		root.Finish()
+		late := root.StartChild("late")
+		assert.Equal(t, time.Duration(0), late.Duration())
+		r := root.Report()
+		assert.Len(t, r.Children, 1)
+		assert.True(t, r.Children[0].Finished)
+		assert.NotContains(t, root.String(), "(running)")
+	})
+
+	t.Run("子时间段按开始时间排列", func(t *testing.T) {
+		root := StartSpan("job")
+		a := root.StartChild("a")
+		b := root.StartChild("b")
+		// 模拟并发开始时添加的顺序与开始时间不一致
+		a.start = b.start.Add(time.Millisecond)
+		r := root.Report()
+		assert.Equal(t, "b", r.Children[0].Name)
+		assert.Equal(t, "a", r.Children[1].Name)
+	})
+}
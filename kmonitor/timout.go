@@ -1,27 +1,71 @@
 package kmonitor
 
 import (
+	"context"
+	"encoding/json"
+	"maps"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/mtgnorton/k/ktime"
 	"github.com/mtgnorton/k/kunique"
 )
 
 // defaultTimeoutController 默认的超时检测器实例
-var defaultTimeoutController = &TimeoutController{
-	callIDs: make(map[int64]struct{}),
+var defaultTimeoutController = NewTimeoutController()
+
+// TimeoutControllerOptions 超时检测器的配置项
+type TimeoutControllerOptions struct {
+	Clock ktime.Clock // 记录开始时间和创建定时器使用的时钟
+}
+
+type TimeoutControllerOption func(*TimeoutControllerOptions)
+
+func NewTimeoutControllerOptions() *TimeoutControllerOptions {
+	return &TimeoutControllerOptions{
+		Clock: ktime.RealClock,
+	}
+}
+
+// WithTimeoutClock 设置记录开始时间和创建定时器使用的时钟,默认为 ktime.RealClock
+// 使用 ktime.FakeClock 时超时处理函数在调用 Advance 的协程中同步执行
+func WithTimeoutClock(clock ktime.Clock) TimeoutControllerOption {
+	return func(o *TimeoutControllerOptions) {
+		o.Clock = clock
+	}
 }
 
 // TimeoutController 超时检测器
 type TimeoutController struct {
-	callIDs      map[int64]struct{} // 记录活跃的调用ID
-	sync.RWMutex                    // 使用读写锁提升性能
+	opts         *TimeoutControllerOptions
+	callIDs      map[int64]*CallInfo // 记录活跃的调用
+	sync.RWMutex                     // 使用读写锁提升性能
+}
+
+// CallMeta 任务的元数据,超时时会传递给超时处理函数
+type CallMeta struct {
+	Name string            // 任务名称
+	Tags map[string]string // 任务标签
+}
+
+// CallInfo 活跃任务的信息
+type CallInfo struct {
+	ID    int64             `json:"id"`             // 任务的唯一标识
+	Name  string            `json:"name,omitempty"` // 任务名称
+	Tags  map[string]string `json:"tags,omitempty"` // 任务标签
+	Start time.Time         `json:"start"`          // 任务开始的时间
 }
 
 // NewTimeoutController 创建一个新的超时检测器
-func NewTimeoutController() *TimeoutController {
+func NewTimeoutController(opts ...TimeoutControllerOption) *TimeoutController {
+	o := NewTimeoutControllerOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
 	return &TimeoutController{
-		callIDs: make(map[int64]struct{}),
+		callIDs: make(map[int64]*CallInfo),
+		opts:    o,
 	}
 }
 
@@ -39,6 +83,7 @@ func NewTimeoutController() *TimeoutController {
 //   - 超时后会自动清理资源
 //   - 调用end函数会停止定时器并清理资源
 //   - 每个任务都有唯一的callID标识
+//   - 需要上下文或元数据时使用 DoContext
 //
 // 示例:
 //
@@ -47,27 +92,109 @@ func NewTimeoutController() *TimeoutController {
 //	})
 //	defer end()
 func (t *TimeoutController) Do(duration time.Duration, timeoutHandler func()) (end func()) {
-	callID := kunique.GenerateUniqueID()
+	return t.DoContext(context.Background(), duration, func(CallInfo) {
+		timeoutHandler()
+	})
+}
+
+// DoContext 执行一个带超时检测的任务,ctx结束时自动结束任务
+//
+// 参数说明:
+//   - ctx: 上下文,ctx被取消或到期时任务自动结束,不会再触发超时
+//   - duration: 超时时间
+//   - timeoutHandler: 超时处理函数,接收任务的信息
+//   - meta: 可选的任务元数据
+//
+// 返回值说明:
+//   - end: 用于提前结束任务的函数,可以重复调用
+//
+// 注意事项:
+//   - 超时处理函数在独立的goroutine中执行,执行前任务已结束,可以在其中调用 ActiveCalls
+//
+// 示例:
+//
+//	end := monitor.DoContext(ctx, 5*time.Second, func(info CallInfo) {
+//	    log.Printf("%s timeout, tags: %v, start: %s", info.Name, info.Tags, info.Start)
+//	}, CallMeta{Name: "query", Tags: map[string]string{"table": "user"}})
+//	defer end()
+func (t *TimeoutController) DoContext(ctx context.Context, duration time.Duration, timeoutHandler func(info CallInfo), meta ...CallMeta) (end func()) {
+	info := &CallInfo{
+		ID:    kunique.GenerateUniqueID(),
+		Start: t.opts.Clock.Now(),
+	}
+	if len(meta) > 0 {
+		info.Name = meta[0].Name
+		info.Tags = maps.Clone(meta[0].Tags)
+	}
 
 	t.Lock()
-	t.callIDs[callID] = struct{}{}
+	t.callIDs[info.ID] = info
 	t.Unlock()
 
-	timer := time.AfterFunc(duration, func() {
-		t.Lock()
-		defer t.Unlock()
-		if _, ok := t.callIDs[callID]; ok {
-			timeoutHandler()
-			delete(t.callIDs, callID)
+	timer := t.opts.Clock.AfterFunc(duration, func() {
+		if t.remove(info.ID) {
+			timeoutHandler(*info)
 		}
 	})
+	stopCtx := context.AfterFunc(ctx, func() {
+		timer.Stop()
+		t.remove(info.ID)
+	})
 
 	return func() {
 		timer.Stop() // 停止定时器
-		t.Lock()
-		delete(t.callIDs, callID)
-		t.Unlock()
+		stopCtx()
+		t.remove(info.ID)
+	}
+}
+
+// remove 移除活跃的任务
+// 返回:
+//   - bool: 任务是否仍处于活跃状态
+func (t *TimeoutController) remove(callID int64) bool {
+	t.Lock()
+	defer t.Unlock()
+	if _, ok := t.callIDs[callID]; !ok {
+		return false
+	}
+	delete(t.callIDs, callID)
+	return true
+}
+
+// ActiveCalls 按开始时间从早到晚返回所有尚未结束且未超时的任务,用于排查卡住的操作
+func (t *TimeoutController) ActiveCalls() []CallInfo {
+	t.RLock()
+	calls := make([]CallInfo, 0, len(t.callIDs))
+	for _, info := range t.callIDs {
+		calls = append(calls, *info)
 	}
+	t.RUnlock()
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].Start.Equal(calls[j].Start) {
+			return calls[i].ID < calls[j].ID
+		}
+		return calls[i].Start.Before(calls[j].Start)
+	})
+	return calls
+}
+
+// Active 返回尚未结束且未超时的任务数量
+func (t *TimeoutController) Active() int {
+	t.RLock()
+	defer t.RUnlock()
+	return len(t.callIDs)
+}
+
+// MarshalJSON 将超时检测器的状态编码为JSON
+func (t *TimeoutController) MarshalJSON() ([]byte, error) {
+	calls := t.ActiveCalls()
+	return json.Marshal(struct {
+		Active int        `json:"active"`
+		Calls  []CallInfo `json:"calls"`
+	}{
+		Active: len(calls),
+		Calls:  calls,
+	})
 }
 
 // MonitorTimeout 监控超时,参见 TimeoutController.Do
@@ -1,8 +1,12 @@
 package kmonitor
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/mtgnorton/k/ktime"
 )
 
 func TestMonitorTimeout(t *testing.T) {
@@ -77,3 +81,95 @@ func TestTimeoutController(t *testing.T) {
 	}
 	end()
 }
+
+func TestTimeoutControllerClock(t *testing.T) {
+	start := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+	clock := ktime.NewFakeClock(start)
+	controller := NewTimeoutController(WithTimeoutClock(clock))
+
+	var infos []CallInfo
+	controller.DoContext(context.Background(), time.Minute, func(info CallInfo) {
+		infos = append(infos, info)
+	}, CallMeta{Name: "query"})
+	clock.Advance(59 * time.Second)
+	if len(infos) != 0 || controller.Active() != 1 {
+		t.Fatal("未到超时时间不应该触发超时")
+	}
+	// FakeClock在Advance中同步执行超时处理函数
+	clock.Advance(time.Second)
+	if len(infos) != 1 || infos[0].Name != "query" || !infos[0].Start.Equal(start) {
+		t.Fatalf("应该触发超时处理器: %+v", infos)
+	}
+	if controller.Active() != 0 {
+		t.Error("超时后应该清理任务")
+	}
+}
+
+func TestTimeoutControllerDoContext(t *testing.T) {
+	controller := NewTimeoutController()
+
+	// 测试超时处理函数可以拿到元数据
+	infos := make(chan CallInfo, 1)
+	controller.DoContext(context.Background(), 20*time.Millisecond, func(info CallInfo) {
+		infos <- info
+	}, CallMeta{Name: "query", Tags: map[string]string{"table": "user"}})
+	select {
+	case info := <-infos:
+		if info.Name != "query" || info.Tags["table"] != "user" || info.Start.IsZero() {
+			t.Errorf("元数据不正确: %+v", info)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("应该触发超时处理器")
+	}
+	if len(controller.ActiveCalls()) != 0 {
+		t.Error("超时后应该清理任务")
+	}
+
+	// 测试ctx取消后自动结束,不会触发超时
+	var triggered atomic.Bool
+	ctx, cancel := context.WithCancel(context.Background())
+	end := controller.DoContext(ctx, 50*time.Millisecond, func(CallInfo) {
+		triggered.Store(true)
+	})
+	if controller.Active() != 1 {
+		t.Error("应该有1个活跃任务")
+	}
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	if triggered.Load() {
+		t.Error("ctx取消后不应该触发超时")
+	}
+	if controller.Active() != 0 {
+		t.Error("ctx取消后应该清理任务")
+	}
+	end()
+}
+
+func TestTimeoutControllerActiveCalls(t *testing.T) {
+	controller := NewTimeoutController()
+	end1 := controller.DoContext(context.Background(), time.Minute, func(CallInfo) {}, CallMeta{Name: "first"})
+	time.Sleep(time.Millisecond)
+	end2 := controller.DoContext(context.Background(), time.Minute, func(CallInfo) {}, CallMeta{Name: "second"})
+
+	calls := controller.ActiveCalls()
+	if len(calls) != 2 {
+		t.Fatalf("应该有2个活跃任务, 实际为%d", len(calls))
+	}
+	if calls[0].Name != "first" || calls[1].Name != "second" {
+		t.Error("应该按开始时间排序")
+	}
+	if calls[0].ID == calls[1].ID {
+		t.Error("任务ID应该唯一")
+	}
+
+	end1()
+	end1()
+	calls = controller.ActiveCalls()
+	if len(calls) != 1 || calls[0].Name != "second" {
+		t.Error("结束后应该移除任务")
+	}
+	end2()
+	if len(controller.ActiveCalls()) != 0 {
+		t.Error("应该没有活跃任务")
+	}
+}
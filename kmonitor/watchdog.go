@@ -0,0 +1,157 @@
+package kmonitor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mtgnorton/k/ktime"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrTaskStuck = errors.New("task stuck: exceeded watchdog deadline")
+)
+
+// WatchdogOptions 卡住任务看门狗的配置项
+type WatchdogOptions struct {
+	FullDump bool        // 是否在报告中附带所有协程的堆栈
+	Cancel   bool        // 超过期限后是否取消任务的ctx,否则任务继续执行
+	Clock    ktime.Clock // 记录开始时间和创建定时器使用的时钟
+}
+
+type WatchdogOption func(*WatchdogOptions)
+
+func NewWatchdogOptions() *WatchdogOptions {
+	return &WatchdogOptions{
+		Clock: ktime.RealClock,
+	}
+}
+
+// WithFullDump 设置是否在报告中附带所有协程的堆栈
+func WithFullDump(full bool) WatchdogOption {
+	return func(o *WatchdogOptions) {
+		o.FullDump = full
+	}
+}
+
+// WithCancelOnStuck 设置超过期限后是否取消任务的ctx,取消的原因为 ErrTaskStuck
+func WithCancelOnStuck(cancel bool) WatchdogOption {
+	return func(o *WatchdogOptions) {
+		o.Cancel = cancel
+	}
+}
+
+// WithWatchdogClock 设置记录开始时间和创建定时器使用的时钟,默认为 ktime.RealClock
+// 使用 ktime.FakeClock 时处理函数在调用 Advance 的协程中同步执行
+func WithWatchdogClock(clock ktime.Clock) WatchdogOption {
+	return func(o *WatchdogOptions) {
+		o.Clock = clock
+	}
+}
+
+// StuckReport 卡住任务的报告
+type StuckReport struct {
+	Name     string        // 任务名称
+	Start    time.Time     // 任务开始的时间
+	Deadline time.Duration // 任务的期限
+	Elapsed  time.Duration // 报告时任务已执行的时间
+	Stack    string        // 任务所在协程的堆栈,任务恰好结束时可能为空
+	Dump     string        // 所有协程的堆栈,仅在开启 FullDump 时有值
+	Canceled bool          // 是否取消了任务的ctx
+}
+
+// String 返回可读的报告内容
+func (r StuckReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "task %q stuck: running for %s, deadline %s", r.Name, r.Elapsed, r.Deadline)
+	if r.Canceled {
+		sb.WriteString(", canceled")
+	}
+	if r.Stack != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(r.Stack)
+	}
+	return sb.String()
+}
+
+// Watchdog 卡住任务的看门狗,任务超过期限时记录其所在协程的堆栈并交给处理函数
+// 相比 MonitorTimeout 只能知道任务超时,可以知道任务卡在了哪里
+type Watchdog struct {
+	handler func(StuckReport)
+	opts    *WatchdogOptions
+}
+
+// NewWatchdog 创建一个卡住任务的看门狗
+//
+// 参数说明:
+//   - handler: 任务超过期限时的处理函数,在独立的协程中执行
+//   - opts: 可选配置项,参见 WatchdogOptions
+//
+// 注意事项:
+//   - 线程安全,可以同时监控多个任务
+//   - 获取堆栈需要短暂地暂停所有协程,期限不宜过短
+//
+// 示例:
+//
+//	wd := NewWatchdog(func(r StuckReport) {
+//	    log.Println(r)
+//	}, WithCancelOnStuck(true))
+//	err := wd.Run(ctx, "sync-orders", time.Minute, func(ctx context.Context) error {
+//	    return syncOrders(ctx)
+//	})
+//	// syncOrders 在ctx结束时返回 context.Cause(ctx),即 ErrTaskStuck
+//	if errors.Is(err, ErrTaskStuck) { ... }
+func NewWatchdog(handler func(StuckReport), opts ...WatchdogOption) *Watchdog {
+	o := NewWatchdogOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Watchdog{handler: handler, opts: o}
+}
+
+// Run 在当前协程中执行fn,超过期限时生成报告并调用处理函数
+// 参数:
+//   - ctx: 上下文,传递给fn
+//   - name: 任务名称
+//   - deadline: 任务的期限,必须大于0,否则会panic
+//   - fn: 任务函数,开启 Cancel 时需要在ctx结束后尽快返回
+//
+// 返回:
+//   - error: fn返回的错误
+//
+// 注意:
+//   - 处理函数执行完后才会取消ctx
+//   - 每个任务最多报告一次
+func (w *Watchdog) Run(ctx context.Context, name string, deadline time.Duration, fn func(ctx context.Context) error) error {
+	if deadline <= 0 {
+		panic("deadline must be greater than 0")
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	clock := w.opts.Clock
+	start := clock.Now()
+	gid := currentGoroutineID()
+	timer := clock.AfterFunc(deadline, func() {
+		dump := goroutineDump()
+		report := StuckReport{
+			Name:     name,
+			Start:    start,
+			Deadline: deadline,
+			Elapsed:  clock.Since(start),
+			Stack:    splitGoroutineStacks(dump)[gid],
+			Canceled: w.opts.Cancel,
+		}
+		if w.opts.FullDump {
+			report.Dump = string(dump)
+		}
+		w.handler(report)
+		if w.opts.Cancel {
+			cancel(ErrTaskStuck)
+		}
+	})
+	defer timer.Stop()
+	return fn(ctx)
+}
@@ -0,0 +1,79 @@
+package kmonitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func stuckInWatchdogTest(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-time.After(d):
+		return nil
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	t.Run("记录卡住的位置并继续执行", func(t *testing.T) {
+		reports := make(chan StuckReport, 1)
+		wd := NewWatchdog(func(r StuckReport) {
+			reports <- r
+		})
+		err := wd.Run(context.Background(), "sync", 20*time.Millisecond, func(ctx context.Context) error {
+			return stuckInWatchdogTest(ctx, 100*time.Millisecond)
+		})
+		assert.NoError(t, err, "未开启取消时任务继续执行")
+
+		r := <-reports
+		assert.Equal(t, "sync", r.Name)
+		assert.Equal(t, 20*time.Millisecond, r.Deadline)
+		assert.GreaterOrEqual(t, r.Elapsed, 20*time.Millisecond)
+		assert.Contains(t, r.Stack, "stuckInWatchdogTest")
+		assert.Empty(t, r.Dump)
+		assert.False(t, r.Canceled)
+		assert.Contains(t, r.String(), `task "sync" stuck`)
+	})
+
+	t.Run("取消任务并附带所有协程的堆栈", func(t *testing.T) {
+		reports := make(chan StuckReport, 1)
+		wd := NewWatchdog(func(r StuckReport) {
+			reports <- r
+		}, WithCancelOnStuck(true), WithFullDump(true))
+		start := time.Now()
+		err := wd.Run(context.Background(), "sync", 20*time.Millisecond, func(ctx context.Context) error {
+			return stuckInWatchdogTest(ctx, time.Minute)
+		})
+		assert.True(t, errors.Is(err, ErrTaskStuck))
+		assert.Less(t, time.Since(start), time.Second)
+
+		r := <-reports
+		assert.True(t, r.Canceled)
+		assert.Contains(t, r.Dump, "stuckInWatchdogTest")
+		assert.Contains(t, r.Dump, r.Stack)
+	})
+
+	t.Run("按时完成不报告", func(t *testing.T) {
+		called := make(chan struct{}, 1)
+		wd := NewWatchdog(func(r StuckReport) {
+			called <- struct{}{}
+		})
+		err := wd.Run(context.Background(), "fast", 50*time.Millisecond, func(ctx context.Context) error {
+			return errors.New("failed")
+		})
+		assert.EqualError(t, err, "failed")
+		time.Sleep(80 * time.Millisecond)
+		assert.Empty(t, called)
+	})
+
+	t.Run("参数校验", func(t *testing.T) {
+		wd := NewWatchdog(func(StuckReport) {})
+		assert.Panics(t, func() {
+			wd.Run(context.Background(), "bad", 0, func(ctx context.Context) error { return nil })
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package kreflect
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotFunc   = errors.New("kreflect: not a function")
+	ErrArgCount  = errors.New("kreflect: wrong number of arguments")
+	ErrCallPanic = errors.New("kreflect: function panicked")
+)
+
+// Call 使用任意类型的参数调用函数,参数会转换为函数需要的类型
+//
+// 参数说明:
+//   - fn: 任意函数,包括方法值,如 obj.Method
+//   - args: 参数,使用 ConvertTo 转换为对应参数的类型
+//
+// 返回值说明:
+//   - []any: 函数的所有返回值,与函数声明的顺序一致,函数返回的error也在其中
+//   - error: fn不是函数或为nil时返回 ErrNotFunc,参数数量不对时返回 ErrArgCount,
+//     参数无法转换时返回 ErrConvert并带有参数的下标,函数panic时返回 ErrCallPanic并带有panic的值
+//
+// 注意事项:
+//   - 可变参数函数的多余参数逐个转换为可变参数的元素类型
+//   - 可变参数函数的最后一个参数已经是可变参数对应的切片类型时,作为整个切片传入,同 fn(args...)
+//   - nil参数转换为对应类型的零值
+//
+// 示例:
+//
+//	handlers := map[string]any{
+//	    "add": func(a, b int) int { return a + b },
+//	}
+//	// 命令行参数都是字符串
+//	results, err := Call(handlers["add"], "1", "2") // [3], nil
+func Call(fn any, args ...any) (results []any, err error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return nil, errors.Wrapf(ErrNotFunc, "%T", fn)
+	}
+	ft := fv.Type()
+	in, spread, err := callArgs(ft, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			results, err = nil, errors.Wrapf(ErrCallPanic, "%v", r)
+		}
+	}()
+	var out []reflect.Value
+	if spread {
+		out = fv.CallSlice(in)
+	} else {
+		out = fv.Call(in)
+	}
+	results = make([]any, len(out))
+	for i, v := range out {
+		results[i] = v.Interface()
+	}
+	return results, nil
+}
+
+// callArgs 将参数转换为函数需要的类型,spread为true时最后一个参数是可变参数的切片,需要使用CallSlice
+func callArgs(ft reflect.Type, args []any) (in []reflect.Value, spread bool, err error) {
+	n := ft.NumIn()
+	if ft.IsVariadic() {
+		if len(args) < n-1 {
+			return nil, false, errors.Wrapf(ErrArgCount, "want at least %d, got %d", n-1, len(args))
+		}
+		last := ft.In(n - 1)
+		if len(args) == n && args[n-1] != nil && reflect.TypeOf(args[n-1]).AssignableTo(last) {
+			spread = true
+		}
+	} else if len(args) != n {
+		return nil, false, errors.Wrapf(ErrArgCount, "want %d, got %d", n, len(args))
+	}
+
+	in = make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var t reflect.Type
+		switch {
+		case i < n-1 || !ft.IsVariadic() || spread:
+			t = ft.In(i)
+		default:
+			t = ft.In(n - 1).Elem()
+		}
+		v, err := ConvertTo(arg, t)
+		if err != nil {
+			return nil, false, errors.WithMessagef(err, "arg %d", i)
+		}
+		in[i] = v
+	}
+	return in, spread, nil
+}
@@ -0,0 +1,74 @@
+package kreflect
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type callGreeter struct {
+	prefix string
+}
+
+func (g callGreeter) Greet(name string) string {
+	return g.prefix + name
+}
+
+func TestCall(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	join := func(sep string, parts ...string) string { return strings.Join(parts, sep) }
+	sleep := func(d time.Duration, p *int) (time.Duration, bool) { return d, p == nil }
+	fail := func() error { return errors.New("failed") }
+	noop := func() {}
+
+	tests := []struct {
+		name     string
+		fn       any
+		args     []any
+		expected []any
+		err      error
+	}{
+		{"convert args", add, []any{"1", 2.0}, []any{3}, nil},
+		{"variadic", join, []any{",", "a", 1, true}, []any{"a,1,true"}, nil},
+		{"variadic empty", join, []any{","}, []any{""}, nil},
+		{"variadic slice", join, []any{"-", []string{"a", "b"}}, []any{"a-b"}, nil},
+		{"nil and duration", sleep, []any{"1s", nil}, []any{time.Second, true}, nil},
+		{"method value", callGreeter{prefix: "hi "}.Greet, []any{"k"}, []any{"hi k"}, nil},
+		{"no results", noop, nil, []any{}, nil},
+		{"error result", fail, nil, []any{errors.New("failed")}, nil},
+		{"not func", 1, nil, nil, ErrNotFunc},
+		{"nil func", (func())(nil), nil, nil, ErrNotFunc},
+		{"too few", add, []any{1}, nil, ErrArgCount},
+		{"too many", add, []any{1, 2, 3}, nil, ErrArgCount},
+		{"variadic too few", join, nil, nil, ErrArgCount},
+		{"bad arg", add, []any{1, "x"}, nil, ErrConvert},
+		{"panic", func(a []int) int { return a[1] }, []any{[]int{1}}, nil, ErrCallPanic},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Call(test.fn, test.args...)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Call() error = %v, want %v", err, test.err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Call() = %#v, want %#v", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestCallPanicMessage(t *testing.T) {
+	_, err := Call(func() { panic("boom") })
+	if !errors.Is(err, ErrCallPanic) || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Call() error = %v, want panic message", err)
+	}
+}
+
+func TestCallArgIndex(t *testing.T) {
+	_, err := Call(func(a, b int) {}, 1, "x")
+	if err == nil || !strings.Contains(err.Error(), "arg 1") {
+		t.Errorf("Call() error = %v, want arg index", err)
+	}
+}
@@ -0,0 +1,531 @@
+package kreflect
+
+import (
+	"encoding"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrConvert = errors.New("kreflect: cannot convert")
+)
+
+// ToInt64 将任意类型尽量转换为int64类型
+//
+// 参数说明:
+//   - a: 任意类型的值(any),支持整数、浮点数、布尔值、字符串、[]byte、json.Number及它们的指针和自定义类型
+//
+// 返回值说明:
+//   - int64: 转换后的值
+//   - error: 无法转换或溢出时返回 ErrConvert
+//
+// 注意事项:
+//   - nil、nil指针和空字符串转换为0
+//   - 浮点数和小数形式的字符串会截断小数部分
+//   - 布尔值true转换为1,false转换为0
+//
+// 示例:
+//
+//	ToInt64("42")               // 42, nil
+//	ToInt64(json.Number("1e3")) // 1000, nil
+//	ToInt64("abc")              // 0, ErrConvert
+func ToInt64(a any) (int64, error) {
+	rv, ok := indirect(a)
+	if !ok {
+		return 0, nil
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := rv.Uint(); u <= math.MaxInt64 {
+			return int64(u), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f), nil
+		}
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return stringToInt64(a, rv.String())
+	case reflect.Slice:
+		if b, ok := bytesOf(rv); ok {
+			return stringToInt64(a, string(b))
+		}
+	}
+	return 0, convertError(a, "int64")
+}
+
+// ToFloat64 将任意类型尽量转换为float64类型
+//
+// 参数说明:
+//   - a: 任意类型的值(any),支持的类型同 ToInt64
+//
+// 返回值说明:
+//   - float64: 转换后的值
+//   - error: 无法转换时返回 ErrConvert
+//
+// 注意事项:
+//   - nil、nil指针和空字符串转换为0
+func ToFloat64(a any) (float64, error) {
+	rv, ok := indirect(a)
+	if !ok {
+		return 0, nil
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.String:
+		return stringToFloat64(a, rv.String())
+	case reflect.Slice:
+		if b, ok := bytesOf(rv); ok {
+			return stringToFloat64(a, string(b))
+		}
+	}
+	return 0, convertError(a, "float64")
+}
+
+// ToBool 将任意类型尽量转换为bool类型
+//
+// 参数说明:
+//   - a: 任意类型的值(any),支持的类型同 ToInt64
+//
+// 返回值说明:
+//   - bool: 转换后的值
+//   - error: 无法转换时返回 ErrConvert
+//
+// 注意事项:
+//   - nil、nil指针和空字符串转换为false
+//   - 数字不等于0时为true
+//   - 字符串支持 1/0、t/f、true/false、y/n、yes/no、on/off,不区分大小写
+func ToBool(a any) (bool, error) {
+	rv, ok := indirect(a)
+	if !ok {
+		return false, nil
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() != 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() != 0, nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() != 0, nil
+	case reflect.String:
+		return stringToBool(a, rv.String())
+	case reflect.Slice:
+		if b, ok := bytesOf(rv); ok {
+			return stringToBool(a, string(b))
+		}
+	}
+	return false, convertError(a, "bool")
+}
+
+// ToDuration 将任意类型尽量转换为time.Duration类型
+//
+// 参数说明:
+//   - a: 任意类型的值(any),支持的类型同 ToInt64
+//
+// 返回值说明:
+//   - time.Duration: 转换后的值
+//   - error: 无法转换时返回 ErrConvert
+//
+// 注意事项:
+//   - 数字和纯数字的字符串按纳秒处理,与time.Duration一致
+//   - 其他字符串使用time.ParseDuration解析,如 "1h30m"、"500ms"
+//
+// 示例:
+//
+//	ToDuration("1.5s") // 1500ms, nil
+//	ToDuration(1000)   // 1µs, nil
+func ToDuration(a any) (time.Duration, error) {
+	rv, ok := indirect(a)
+	if !ok {
+		return 0, nil
+	}
+	var s string
+	switch rv.Kind() {
+	case reflect.String:
+		s = rv.String()
+	case reflect.Slice:
+		b, ok := bytesOf(rv)
+		if !ok {
+			return 0, convertError(a, "time.Duration")
+		}
+		s = string(b)
+	case reflect.Float32, reflect.Float64:
+		f, err := ToFloat64(a)
+		if err != nil || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, convertError(a, "time.Duration")
+		}
+		return time.Duration(f), nil
+	case reflect.Bool:
+		return 0, convertError(a, "time.Duration")
+	default:
+		n, err := ToInt64(a)
+		if err != nil {
+			return 0, convertError(a, "time.Duration")
+		}
+		return time.Duration(n), nil
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, convertError(a, "time.Duration")
+	}
+	return d, nil
+}
+
+// MustToInt64 同 ToInt64,无法转换时panic
+func MustToInt64(a any) int64 {
+	v, err := ToInt64(a)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustToFloat64 同 ToFloat64,无法转换时panic
+func MustToFloat64(a any) float64 {
+	v, err := ToFloat64(a)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustToBool 同 ToBool,无法转换时panic
+func MustToBool(a any) bool {
+	v, err := ToBool(a)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustToDuration 同 ToDuration,无法转换时panic
+func MustToDuration(a any) time.Duration {
+	v, err := ToDuration(a)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// indirect 解引用指针和接口,返回值是否有效,nil和nil指针返回false
+// a可以是reflect.Value,此时不会调用Interface(),可以处理未导出字段的值
+func indirect(a any) (reflect.Value, bool) {
+	rv, ok := a.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(a)
+	}
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return rv, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.IsValid()
+}
+
+// bytesOf 返回[]byte类型(包括自定义类型)的值
+func bytesOf(rv reflect.Value) ([]byte, bool) {
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	return rv.Bytes(), true
+}
+
+func stringToInt64(a any, s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && f >= math.MinInt64 && f < math.MaxInt64 {
+		return int64(f), nil
+	}
+	return 0, convertError(a, "int64")
+}
+
+func stringToFloat64(a any, s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, convertError(a, "float64")
+	}
+	return f, nil
+}
+
+func stringToBool(a any, s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "0", "f", "false", "n", "no", "off":
+		return false, nil
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	}
+	return false, convertError(a, "bool")
+}
+
+func convertError(a any, to string) error {
+	return errors.Wrapf(ErrConvert, "%T(%v) to %s", a, a, to)
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	durationType    = reflect.TypeOf(time.Duration(0))
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// timeLayouts 字符串转换为time.Time时尝试的格式
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	time.DateOnly,
+	"2006-01-02T15:04:05",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+}
+
+// ConvertTo 将任意类型的值尽量转换为指定的类型
+//
+// 参数说明:
+//   - a: 任意类型的值(any),也可以是reflect.Value
+//   - t: 目标类型
+//
+// 返回值说明:
+//   - reflect.Value: 转换后的值,类型为t
+//   - error: 无法转换时返回 ErrConvert
+//
+// 注意事项:
+//   - nil转换为t的零值
+//   - 数字、布尔值和time.Duration使用 ToInt64、ToFloat64、ToBool、ToDuration 转换,并检查溢出
+//   - 字符串转换为time.Time时依次尝试RFC3339、"2006-01-02 15:04:05"、"2006-01-02"等格式,数字按秒级时间戳处理
+//   - 切片、数组和map按元素转换,map[string]any转换为结构体时参见 MapToStruct
+//   - 目标类型实现了encoding.TextUnmarshaler时,字符串使用UnmarshalText转换
+//   - 目标类型为指针时,转换为指针指向的类型后取地址
+//
+// 示例:
+//
+//	v, err := ConvertTo("42", reflect.TypeOf(int32(0))) // int32(42)
+func ConvertTo(a any, t reflect.Type) (reflect.Value, error) {
+	rv, ok := a.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(a)
+	}
+	v := reflect.New(t).Elem()
+	if err := convertValue(rv, v); err != nil {
+		return reflect.Value{}, err
+	}
+	return v, nil
+}
+
+// convertValue 将src转换后赋值给dst,dst必须是可以赋值的
+func convertValue(src, dst reflect.Value) error {
+	t := dst.Type()
+	if src.IsValid() && src.Type().AssignableTo(t) && src.CanInterface() {
+		dst.Set(src)
+		return nil
+	}
+	src, ok := indirect(src)
+	if !ok {
+		dst.Set(reflect.Zero(t))
+		return nil
+	}
+	if src.Type().AssignableTo(t) && src.CanInterface() {
+		dst.Set(src)
+		return nil
+	}
+	if t.Kind() == reflect.Ptr {
+		v, err := ConvertTo(src, t.Elem())
+		if err != nil {
+			return err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(v)
+		dst.Set(p)
+		return nil
+	}
+	switch {
+	case t == durationType:
+		d, err := ToDuration(src)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+		return nil
+	case t == timeType:
+		return convertTime(src, dst)
+	}
+	if src.Kind() == reflect.String && reflect.PointerTo(t).Implements(textUnmarshaler) {
+		p := reflect.New(t)
+		if err := p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(src.String())); err != nil {
+			return errors.Wrapf(ErrConvert, "%s to %s: %v", src.Type(), t, err)
+		}
+		dst.Set(p.Elem())
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := ToInt64(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(n) {
+			return errors.Wrapf(ErrConvert, "%d overflows %s", n, t)
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		switch src.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			n = src.Uint()
+		default:
+			i, err := ToInt64(src)
+			if err != nil {
+				return err
+			}
+			if i < 0 {
+				return errors.Wrapf(ErrConvert, "%d overflows %s", i, t)
+			}
+			n = uint64(i)
+		}
+		if dst.OverflowUint(n) {
+			return errors.Wrapf(ErrConvert, "%d overflows %s", n, t)
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := ToFloat64(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowFloat(f) {
+			return errors.Wrapf(ErrConvert, "%v overflows %s", f, t)
+		}
+		dst.SetFloat(f)
+	case reflect.Bool:
+		b, err := ToBool(src)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.String:
+		if !src.CanInterface() {
+			return errors.Wrapf(ErrConvert, "%s to %s", src.Type(), t)
+		}
+		dst.SetString(ToString(src.Interface()))
+	case reflect.Slice:
+		if src.Kind() == reflect.String && t.Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes([]byte(src.String()))
+			return nil
+		}
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			return errors.Wrapf(ErrConvert, "%s to %s", src.Type(), t)
+		}
+		s := reflect.MakeSlice(t, src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := convertValue(src.Index(i), s.Index(i)); err != nil {
+				return errors.WithMessagef(err, "[%d]", i)
+			}
+		}
+		dst.Set(s)
+	case reflect.Array:
+		if (src.Kind() != reflect.Slice && src.Kind() != reflect.Array) || src.Len() > t.Len() {
+			return errors.Wrapf(ErrConvert, "%s to %s", src.Type(), t)
+		}
+		for i := 0; i < src.Len(); i++ {
+			if err := convertValue(src.Index(i), dst.Index(i)); err != nil {
+				return errors.WithMessagef(err, "[%d]", i)
+			}
+		}
+	case reflect.Map:
+		if src.Kind() != reflect.Map {
+			return errors.Wrapf(ErrConvert, "%s to %s", src.Type(), t)
+		}
+		m := reflect.MakeMapWithSize(t, src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			k, err := ConvertTo(iter.Key(), t.Key())
+			if err != nil {
+				return errors.WithMessagef(err, "key %v", iter.Key())
+			}
+			v := reflect.New(t.Elem()).Elem()
+			if err := convertValue(iter.Value(), v); err != nil {
+				return errors.WithMessagef(err, "[%v]", iter.Key())
+			}
+			m.SetMapIndex(k, v)
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		if src.Kind() != reflect.Map || src.Type().Key().Kind() != reflect.String {
+			return errors.Wrapf(ErrConvert, "%s to %s", src.Type(), t)
+		}
+		return mapToStruct(src, dst, NewStructOptions(), "")
+	case reflect.Interface:
+		if !src.Type().Implements(t) {
+			return errors.Wrapf(ErrConvert, "%s to %s", src.Type(), t)
+		}
+		dst.Set(src)
+	default:
+		return errors.Wrapf(ErrConvert, "%s to %s", src.Type(), t)
+	}
+	return nil
+}
+
+// convertTime 将字符串或秒级时间戳转换为time.Time
+func convertTime(src, dst reflect.Value) error {
+	if src.Kind() != reflect.String {
+		n, err := ToInt64(src)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(time.Unix(n, 0)))
+		return nil
+	}
+	s := strings.TrimSpace(src.String())
+	if s == "" {
+		dst.Set(reflect.Zero(timeType))
+		return nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			dst.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		dst.Set(reflect.ValueOf(time.Unix(n, 0)))
+		return nil
+	}
+	return errors.Wrapf(ErrConvert, "%q to time.Time", s)
+}
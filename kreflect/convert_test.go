@@ -0,0 +1,195 @@
+package kreflect
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type myInt int
+
+func TestToInt64(t *testing.T) {
+	n := 7
+	var nilPtr *int
+	tests := []struct {
+		input    any
+		expected int64
+		err      bool
+	}{
+		{nil, 0, false},
+		{nilPtr, 0, false},
+		{&n, 7, false},
+		{myInt(3), 3, false},
+		{uint64(math.MaxUint64), 0, true},
+		{1.9, 1, false},
+		{math.NaN(), 0, true},
+		{true, 1, false},
+		{" 42 ", 42, false},
+		{"1.5", 1, false},
+		{"", 0, false},
+		{"abc", 0, true},
+		{[]byte("12"), 12, false},
+		{json.Number("1e3"), 1000, false},
+		{time.Second, int64(time.Second), false},
+		{struct{}{}, 0, true},
+	}
+	for _, test := range tests {
+		got, err := ToInt64(test.input)
+		if got != test.expected || (err != nil) != test.err {
+			t.Errorf("ToInt64(%v) = %v, %v, want %v, err %v", test.input, got, err, test.expected, test.err)
+		}
+		if err != nil && !errors.Is(err, ErrConvert) {
+			t.Errorf("ToInt64(%v) error %v is not ErrConvert", test.input, err)
+		}
+	}
+}
+
+func TestToFloat64(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected float64
+		err      bool
+	}{
+		{nil, 0, false},
+		{3, 3, false},
+		{uint8(2), 2, false},
+		{float32(1.5), 1.5, false},
+		{false, 0, false},
+		{"3.25", 3.25, false},
+		{json.Number("2.5"), 2.5, false},
+		{"x", 0, true},
+		{[]int{1}, 0, true},
+	}
+	for _, test := range tests {
+		got, err := ToFloat64(test.input)
+		if got != test.expected || (err != nil) != test.err {
+			t.Errorf("ToFloat64(%v) = %v, %v, want %v, err %v", test.input, got, err, test.expected, test.err)
+		}
+	}
+}
+
+func TestToBool(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected bool
+		err      bool
+	}{
+		{nil, false, false},
+		{true, true, false},
+		{0, false, false},
+		{-1, true, false},
+		{0.1, true, false},
+		{"YES", true, false},
+		{"off", false, false},
+		{" 1 ", true, false},
+		{"", false, false},
+		{"maybe", false, true},
+		{map[string]int{}, false, true},
+	}
+	for _, test := range tests {
+		got, err := ToBool(test.input)
+		if got != test.expected || (err != nil) != test.err {
+			t.Errorf("ToBool(%v) = %v, %v, want %v, err %v", test.input, got, err, test.expected, test.err)
+		}
+	}
+}
+
+func TestToDuration(t *testing.T) {
+	tests := []struct {
+		input    any
+		expected time.Duration
+		err      bool
+	}{
+		{nil, 0, false},
+		{time.Minute, time.Minute, false},
+		{1000, time.Microsecond, false},
+		{"1.5s", 1500 * time.Millisecond, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"100", 100, false},
+		{json.Number("5"), 5, false},
+		{2.0, 2, false},
+		{"soon", 0, true},
+		{true, 0, true},
+	}
+	for _, test := range tests {
+		got, err := ToDuration(test.input)
+		if got != test.expected || (err != nil) != test.err {
+			t.Errorf("ToDuration(%v) = %v, %v, want %v, err %v", test.input, got, err, test.expected, test.err)
+		}
+	}
+}
+
+func TestMustTo(t *testing.T) {
+	if MustToInt64("5") != 5 || MustToFloat64("0.5") != 0.5 || !MustToBool("on") || MustToDuration("1s") != time.Second {
+		t.Error("Must variants returned wrong values")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustToInt64(\"abc\") did not panic")
+		}
+	}()
+	MustToInt64("abc")
+}
+
+type testLevel int
+
+func (l *testLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+func TestConvertTo(t *testing.T) {
+	n, seven := 5, 7
+	tests := []struct {
+		input    any
+		expected any
+		err      bool
+	}{
+		{"42", int32(42), false},
+		{300, int8(0), true},
+		{-1, uint(0), true},
+		{"2.5", float32(2.5), false},
+		{"yes", true, false},
+		{12, "12", false},
+		{nil, 0, false},
+		{&n, &n, false},
+		{"7", &seven, false},
+		{"1m", time.Minute, false},
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local), false},
+		{int64(0), time.Unix(0, 0), false},
+		{"yesterday", time.Time{}, true},
+		{"hello", []byte("hello"), false},
+		{[]any{"1", 2.0}, []int{1, 2}, false},
+		{[]string{"1"}, [2]int{1, 0}, false},
+		{map[string]any{"a": "1"}, map[string]int{"a": 1}, false},
+		{"high", testLevel(2), false},
+		{"medium", testLevel(0), true},
+		{1, errors.New(""), true},
+	}
+	for _, test := range tests {
+		got, err := ConvertTo(test.input, reflect.TypeOf(test.expected))
+		if (err != nil) != test.err {
+			t.Errorf("ConvertTo(%v, %T) error = %v, want err %v", test.input, test.expected, err, test.err)
+			continue
+		}
+		if err != nil {
+			if !errors.Is(err, ErrConvert) {
+				t.Errorf("ConvertTo(%v, %T) error %v is not ErrConvert", test.input, test.expected, err)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got.Interface(), test.expected) {
+			t.Errorf("ConvertTo(%v, %T) = %v, want %v", test.input, test.expected, got, test.expected)
+		}
+	}
+}
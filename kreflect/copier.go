@@ -0,0 +1,194 @@
+package kreflect
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// converterKey 自定义转换函数的源类型和目标类型
+type converterKey struct {
+	from, to reflect.Type
+}
+
+// CopyOptions 在不同类型的结构体之间复制字段的配置项
+type CopyOptions struct {
+	Tag        string // 按标签中的名称匹配字段,没有标签时使用字段名称,默认为空,即按字段名称匹配
+	converters map[converterKey]func(reflect.Value) (reflect.Value, error)
+}
+
+type CopyOption func(*CopyOptions)
+
+func NewCopyOptions() *CopyOptions {
+	return &CopyOptions{
+		converters: make(map[converterKey]func(reflect.Value) (reflect.Value, error)),
+	}
+}
+
+// WithCopyTag 按标签中的名称匹配字段,如 "json",两个结构体使用同一个标签
+func WithCopyTag(tag string) CopyOption {
+	return func(o *CopyOptions) {
+		o.Tag = tag
+	}
+}
+
+// WithConverter 注册从S类型到D类型的自定义转换函数,优先于默认的类型转换
+//
+// 示例:
+//
+//	WithConverter(func(t time.Time) (int64, error) { return t.Unix(), nil })
+//	WithConverter(func(s Status) (string, error) { return s.String(), nil })
+func WithConverter[S, D any](fn func(S) (D, error)) CopyOption {
+	from, to := reflect.TypeOf((*S)(nil)).Elem(), reflect.TypeOf((*D)(nil)).Elem()
+	return func(o *CopyOptions) {
+		o.converters[converterKey{from: from, to: to}] = func(v reflect.Value) (reflect.Value, error) {
+			d, err := fn(v.Interface().(S))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(&d).Elem(), nil
+		}
+	}
+}
+
+// CopyFields 在不同类型的结构体之间复制名称相同的字段
+//
+// 参数说明:
+//   - dst: 结构体指针
+//   - src: 结构体或结构体指针
+//   - opts: 可选配置项,参见 CopyOptions
+//
+// 返回值说明:
+//   - error: dst不是结构体指针时返回 ErrNotStructPointer,src不是结构体时返回 ErrNotStruct,
+//     字段无法转换时返回 ErrConvert或自定义转换函数的错误,并带有字段名称
+//
+// 注意事项:
+//   - 忽略未导出的字段和另一方不存在的字段,匿名嵌入结构体的字段会提升后再匹配
+//   - 字段的转换顺序:自定义转换函数、直接赋值、嵌套结构体递归复制、切片按元素复制、ConvertTo
+//   - 直接赋值的指针、切片和map与src共享,需要时先使用 DeepCopy
+//
+// 示例:
+//
+//	type User struct {
+//	    ID        int64
+//	    Status    Status
+//	    CreatedAt time.Time
+//	}
+//	type UserDTO struct {
+//	    ID        string
+//	    Status    string
+//	    CreatedAt int64
+//	}
+//	var dto UserDTO
+//	err := CopyFields(&dto, user,
+//	    WithConverter(func(s Status) (string, error) { return s.String(), nil }),
+//	    WithConverter(func(t time.Time) (int64, error) { return t.Unix(), nil }))
+func CopyFields(dst, src any, opts ...CopyOption) error {
+	o := NewCopyOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+	sv, ok := indirect(src)
+	if !ok || sv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	return copyStruct(dv.Elem(), sv, o)
+}
+
+func copyStruct(dst, src reflect.Value, o *CopyOptions) error {
+	dstFields := make(map[string]reflect.StructField)
+	for _, field := range reflect.VisibleFields(dst.Type()) {
+		if name, ok := copyFieldName(field, o.Tag); ok {
+			if _, exists := dstFields[name]; !exists {
+				dstFields[name] = field
+			}
+		}
+	}
+	for _, field := range reflect.VisibleFields(src.Type()) {
+		name, ok := copyFieldName(field, o.Tag)
+		if !ok {
+			continue
+		}
+		df, ok := dstFields[name]
+		if !ok {
+			continue
+		}
+		sv, err := src.FieldByIndexErr(field.Index)
+		if err != nil {
+			continue
+		}
+		dv, ok := fieldByIndexAlloc(dst, df.Index)
+		if !ok {
+			continue
+		}
+		if err := copyValue(dv, sv, o); err != nil {
+			return errors.WithMessagef(err, "field %s", name)
+		}
+	}
+	return nil
+}
+
+// copyFieldName 返回用于匹配的字段名称,未导出的字段和匿名嵌入结构体返回false
+func copyFieldName(field reflect.StructField, tag string) (string, bool) {
+	if field.Anonymous && derefType(field.Type).Kind() == reflect.Struct {
+		return "", false
+	}
+	name, _, ok := fieldName(field, tag)
+	return name, ok
+}
+
+func copyValue(dst, src reflect.Value, o *CopyOptions) error {
+	if v, ok, err := o.convert(src, dst.Type()); ok {
+		if err != nil {
+			return err
+		}
+		dst.Set(v)
+		return nil
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	s, ok := indirect(src)
+	if !ok {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if isNestedStruct(s.Type()) && isNestedStruct(derefType(dst.Type())) {
+		target, _ := structTarget(dst)
+		return copyStruct(target, s, o)
+	}
+	if s.Kind() == reflect.Slice && dst.Kind() == reflect.Slice {
+		if s.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		d := reflect.MakeSlice(dst.Type(), s.Len(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			if err := copyValue(d.Index(i), s.Index(i), o); err != nil {
+				return errors.WithMessagef(err, "[%d]", i)
+			}
+		}
+		dst.Set(d)
+		return nil
+	}
+	return convertValue(src, dst)
+}
+
+// convert 查找并调用自定义转换函数,src为指针时也会查找指向的类型
+func (o *CopyOptions) convert(src reflect.Value, to reflect.Type) (reflect.Value, bool, error) {
+	for {
+		if fn, ok := o.converters[converterKey{from: src.Type(), to: to}]; ok {
+			v, err := fn(src)
+			return v, true, err
+		}
+		if src.Kind() != reflect.Ptr || src.IsNil() {
+			return reflect.Value{}, false, nil
+		}
+		src = src.Elem()
+	}
+}
@@ -0,0 +1,111 @@
+package kreflect
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type copyStatus int
+
+func (s copyStatus) String() string {
+	return [...]string{"inactive", "active"}[s]
+}
+
+type copyEntity struct {
+	testBase
+	Name      string
+	Status    copyStatus
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	Address   testAddress
+	Items     []pathItem
+	Password  string
+}
+
+type copyItemDTO struct {
+	Name  string
+	Price string
+}
+
+type copyEntityDTO struct {
+	ID        string
+	Name      string `json:"name"`
+	Status    string
+	CreatedAt int64
+	UpdatedAt int64
+	Address   *testAddress
+	Items     []copyItemDTO
+	Extra     string
+}
+
+func TestCopyFields(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+	updated := created.Add(time.Hour)
+	entity := copyEntity{
+		testBase:  testBase{ID: 7},
+		Name:      "tom",
+		Status:    1,
+		CreatedAt: created,
+		UpdatedAt: &updated,
+		Address:   testAddress{City: "sh"},
+		Items:     []pathItem{{"a", 1.5}},
+		Password:  "secret",
+	}
+	toUnix := WithConverter(func(t time.Time) (int64, error) { return t.Unix(), nil })
+	var dto copyEntityDTO
+	err := CopyFields(&dto, &entity, toUnix,
+		WithConverter(func(s copyStatus) (string, error) { return s.String(), nil }))
+	if err != nil {
+		t.Fatalf("CopyFields() error = %v", err)
+	}
+	expected := copyEntityDTO{
+		ID:        "7",
+		Name:      "tom",
+		Status:    "active",
+		CreatedAt: 1700000000,
+		UpdatedAt: 1700003600,
+		Address:   &testAddress{City: "sh"},
+		Items:     []copyItemDTO{{"a", "1.5"}},
+	}
+	if !reflect.DeepEqual(dto, expected) {
+		t.Errorf("CopyFields() = %+v, want %+v", dto, expected)
+	}
+
+	var back copyEntity
+	err = CopyFields(&back, dto,
+		WithConverter(func(n int64) (time.Time, error) { return time.Unix(n, 0), nil }),
+		WithConverter(func(s string) (copyStatus, error) {
+			if s == "active" {
+				return 1, nil
+			}
+			return 0, errors.New("unknown status " + s)
+		}))
+	if err != nil {
+		t.Fatalf("CopyFields() back error = %v", err)
+	}
+	if back.ID != 7 || back.Status != 1 || !back.CreatedAt.Equal(created) || back.UpdatedAt == nil || !back.UpdatedAt.Equal(updated) || back.Items[0].Price != 1.5 {
+		t.Errorf("CopyFields() back = %+v", back)
+	}
+
+	type tagged struct {
+		Nick string `json:"name"`
+	}
+	var tg tagged
+	if err := CopyFields(&tg, dto, WithCopyTag("json")); err != nil || tg.Nick != "tom" {
+		t.Errorf("CopyFields() tag = %+v, %v", tg, err)
+	}
+
+	dto.Status = "unknown"
+	err = CopyFields(&back, dto, WithConverter(func(s string) (copyStatus, error) {
+		return 0, errors.New("unknown status " + s)
+	}))
+	if err == nil || !strings.Contains(err.Error(), "field Status") {
+		t.Errorf("CopyFields() error = %v, want converter error with field name", err)
+	}
+	if err := CopyFields(&back, dto); !errors.Is(err, ErrConvert) {
+		t.Errorf("CopyFields() error = %v, want ErrConvert", err)
+	}
+}
@@ -0,0 +1,133 @@
+package kreflect
+
+import (
+	"reflect"
+)
+
+// Cloner 自定义深拷贝的接口,DeepCopy 遇到实现了该接口的值时调用Clone,不再使用反射拷贝
+// Clone返回值的类型必须与原值一致,否则会被忽略
+type Cloner interface {
+	Clone() any
+}
+
+var clonerType = reflect.TypeOf((*Cloner)(nil)).Elem()
+
+// DeepCopy 深拷贝任意类型的值
+//
+// 参数说明:
+//   - src: 需要拷贝的值
+//
+// 返回值说明:
+//   - T: 拷贝后的值,与src不共享指针、切片和map
+//
+// 注意事项:
+//   - 支持嵌套的指针、切片、数组、map、接口和结构体,循环引用的指针拷贝后保持相同的引用关系
+//   - 结构体的未导出字段无法通过反射赋值,只做浅拷贝
+//   - chan、func和unsafe.Pointer不拷贝,与原值共享
+//   - 实现了 Cloner 接口的值使用Clone拷贝
+//
+// 示例:
+//
+//	type Config struct {
+//	    Hosts []string
+//	    Meta  map[string]*Item
+//	}
+//	c := DeepCopy(config)
+//	c.Hosts[0] = "x" // 不影响config
+func DeepCopy[T any](src T) T {
+	var dst T
+	v := deepCopy(reflect.ValueOf(&src).Elem(), make(map[visitKey]reflect.Value))
+	reflect.ValueOf(&dst).Elem().Set(v)
+	return dst
+}
+
+// visitKey 已拷贝的指针,同一地址不同类型的指针(如结构体和它的第一个字段)视为不同的指针
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func deepCopy(src reflect.Value, visited map[visitKey]reflect.Value) reflect.Value {
+	t := src.Type()
+	if v, ok := cloneValue(src); ok {
+		return v
+	}
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return reflect.Zero(t)
+		}
+		key := visitKey{ptr: src.Pointer(), typ: t}
+		if v, ok := visited[key]; ok {
+			return v
+		}
+		dst := reflect.New(t.Elem())
+		visited[key] = dst
+		dst.Elem().Set(deepCopy(src.Elem(), visited))
+		return dst
+	case reflect.Interface:
+		if src.IsNil() {
+			return reflect.Zero(t)
+		}
+		dst := reflect.New(t).Elem()
+		dst.Set(deepCopy(src.Elem(), visited))
+		return dst
+	case reflect.Slice:
+		if src.IsNil() {
+			return reflect.Zero(t)
+		}
+		dst := reflect.MakeSlice(t, src.Len(), src.Cap())
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(deepCopy(src.Index(i), visited))
+		}
+		return dst
+	case reflect.Array:
+		dst := reflect.New(t).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(deepCopy(src.Index(i), visited))
+		}
+		return dst
+	case reflect.Map:
+		if src.IsNil() {
+			return reflect.Zero(t)
+		}
+		dst := reflect.MakeMapWithSize(t, src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(deepCopy(iter.Key(), visited), deepCopy(iter.Value(), visited))
+		}
+		return dst
+	case reflect.Struct:
+		dst := reflect.New(t).Elem()
+		// 先浅拷贝整个结构体,保留未导出字段的值,再深拷贝导出的字段
+		dst.Set(src)
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				dst.Field(i).Set(deepCopy(src.Field(i), visited))
+			}
+		}
+		return dst
+	default:
+		// 基础类型、chan、func和unsafe.Pointer直接使用原值
+		return src
+	}
+}
+
+// cloneValue 值实现了 Cloner 接口时调用Clone拷贝
+func cloneValue(src reflect.Value) (reflect.Value, bool) {
+	if !src.Type().Implements(clonerType) || !src.CanInterface() {
+		return reflect.Value{}, false
+	}
+	if (src.Kind() == reflect.Ptr || src.Kind() == reflect.Interface) && src.IsNil() {
+		return reflect.Value{}, false
+	}
+	c := src.Interface().(Cloner).Clone()
+	if c == nil {
+		return reflect.Zero(src.Type()), true
+	}
+	v := reflect.ValueOf(c)
+	if !v.Type().AssignableTo(src.Type()) {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
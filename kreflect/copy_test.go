@@ -0,0 +1,80 @@
+package kreflect
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type copyNode struct {
+	Name     string
+	Next     *copyNode
+	Children []*copyNode
+	Meta     map[string]any
+	Created  time.Time
+	counter  *int
+}
+
+type copyCloner struct {
+	Value  []int
+	cloned bool
+}
+
+func (c *copyCloner) Clone() any {
+	return &copyCloner{Value: []int{len(c.Value)}, cloned: true}
+}
+
+func TestDeepCopy(t *testing.T) {
+	counter := 1
+	child := &copyNode{Name: "child"}
+	root := &copyNode{
+		Name:     "root",
+		Children: []*copyNode{child, child},
+		Meta:     map[string]any{"tags": []string{"a"}, "n": 1},
+		Created:  time.Now(),
+		counter:  &counter,
+	}
+	root.Next = root
+
+	c := DeepCopy(root)
+	if c == root || c.Children[0] == child {
+		t.Fatal("DeepCopy() shares pointers with src")
+	}
+	if c.Next != c {
+		t.Error("DeepCopy() breaks the cycle")
+	}
+	if c.Children[0] != c.Children[1] {
+		t.Error("DeepCopy() breaks shared pointers")
+	}
+	c.Meta["tags"].([]string)[0] = "b"
+	c.Children[0].Name = "changed"
+	if root.Meta["tags"].([]string)[0] != "a" || child.Name != "child" {
+		t.Error("DeepCopy() modifies src")
+	}
+	if c.counter != &counter || !c.Created.Equal(root.Created) {
+		t.Error("DeepCopy() unexported fields are not shallow copied")
+	}
+
+	if got := DeepCopy(&copyCloner{Value: []int{1, 2}}); !got.cloned || !reflect.DeepEqual(got.Value, []int{2}) {
+		t.Errorf("DeepCopy() does not use Cloner, got %+v", got)
+	}
+
+	var nilMap map[string]int
+	if DeepCopy(nilMap) != nil {
+		t.Error("DeepCopy(nil map) != nil")
+	}
+	var nilAny any
+	if DeepCopy(nilAny) != nil {
+		t.Error("DeepCopy(nil any) != nil")
+	}
+	arr := [2][]int{{1}, {2}}
+	arrCopy := DeepCopy(arr)
+	arrCopy[0][0] = 9
+	if arr[0][0] != 1 {
+		t.Error("DeepCopy() array shares slices with src")
+	}
+	s := make([]int, 1, 4)
+	if cap(DeepCopy(s)) != 4 {
+		t.Error("DeepCopy() does not keep the slice capacity")
+	}
+}
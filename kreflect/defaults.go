@@ -0,0 +1,84 @@
+package kreflect
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// defaultTag 默认值使用的标签
+const defaultTag = "default"
+
+// SetDefaults 根据default标签为零值字段设置默认值
+//
+// 参数说明:
+//   - ptr: 结构体指针
+//
+// 返回值说明:
+//   - error: ptr不是结构体指针时返回 ErrNotStructPointer,默认值无法转换为字段类型时返回 ErrConvert,并带有字段路径
+//
+// 注意事项:
+//   - 只设置零值字段,已经有值的字段保持不变,因此bool字段的默认值为true时无法显式设置为false,需要时使用*bool
+//   - 默认值使用 ConvertTo 转换,time.Duration可以写为 "5s",time.Time可以写为 "2006-01-02" 等格式
+//   - 切片字段的默认值以","分隔,如 `default:"a,b"`
+//   - 指针字段为nil时创建并指向默认值
+//   - 嵌套的结构体和非nil的结构体指针会递归设置,包括匿名嵌入的结构体
+//
+// 示例:
+//
+//	type ServerOptions struct {
+//	    Addr    string        `default:":8080"`
+//	    Timeout time.Duration `default:"5s"`
+//	    Methods []string      `default:"GET,POST"`
+//	}
+//	opts := ServerOptions{Addr: ":9090"}
+//	err := SetDefaults(&opts) // Addr保持":9090",Timeout为5s,Methods为[GET POST]
+func SetDefaults(ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+	return setDefaults(rv.Elem(), "")
+}
+
+func setDefaults(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		fv := rv.Field(i)
+		path := prefix + field.Name
+		if tag, ok := field.Tag.Lookup(defaultTag); ok && fv.IsZero() && fv.CanSet() {
+			if err := setDefault(fv, tag); err != nil {
+				return errors.WithMessagef(err, "field %s", path)
+			}
+		}
+		if fv.Kind() == reflect.Ptr && fv.IsNil() {
+			continue
+		}
+		if target, ok := structTarget(fv); ok && isNestedStruct(target.Type()) {
+			if err := setDefaults(target, path+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setDefault 将标签中的默认值转换后赋给字段
+func setDefault(fv reflect.Value, tag string) error {
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+		if tag == "" {
+			return nil
+		}
+		parts := strings.Split(tag, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return convertValue(reflect.ValueOf(parts), fv)
+	}
+	return convertValue(reflect.ValueOf(tag), fv)
+}
@@ -0,0 +1,98 @@
+package kreflect
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type defaultsRetry struct {
+	Times    int           `default:"3"`
+	Interval time.Duration `default:"100ms"`
+}
+
+type defaultsBase struct {
+	Env string `default:"dev"`
+}
+
+type defaultsOptions struct {
+	defaultsBase
+	Addr     string        `default:":8080"`
+	Timeout  time.Duration `default:"5s"`
+	Ratio    float64       `default:"0.5"`
+	Enabled  bool          `default:"true"`
+	Methods  []string      `default:"GET, POST"`
+	Ports    []int         `default:"80,443"`
+	MaxConns *int          `default:"10"`
+	Since    time.Time     `default:"2024-01-02"`
+	Retry    defaultsRetry
+	Backup   *defaultsRetry
+	Missing  *defaultsRetry
+	Labels   map[string]string
+	NoTag    string
+	internal string `default:"x"`
+}
+
+func TestSetDefaults(t *testing.T) {
+	opts := defaultsOptions{
+		Addr:   ":9090",
+		Retry:  defaultsRetry{Times: 5},
+		Backup: &defaultsRetry{},
+	}
+	if err := SetDefaults(&opts); err != nil {
+		t.Fatalf("SetDefaults() error = %v", err)
+	}
+	ten := 10
+	expected := defaultsOptions{
+		defaultsBase: defaultsBase{Env: "dev"},
+		Addr:         ":9090",
+		Timeout:      5 * time.Second,
+		Ratio:        0.5,
+		Enabled:      true,
+		Methods:      []string{"GET", "POST"},
+		Ports:        []int{80, 443},
+		MaxConns:     &ten,
+		Retry:        defaultsRetry{Times: 5, Interval: 100 * time.Millisecond},
+		Backup:       &defaultsRetry{Times: 3, Interval: 100 * time.Millisecond},
+	}
+	if got := opts.Since.Format(time.DateOnly); got != "2024-01-02" {
+		t.Errorf("Since = %v, want 2024-01-02", got)
+	}
+	opts.Since = time.Time{}
+	if !reflect.DeepEqual(opts, expected) {
+		t.Errorf("SetDefaults() = %+v, want %+v", opts, expected)
+	}
+}
+
+func TestSetDefaultsKeepsValues(t *testing.T) {
+	maxConns := 0
+	opts := defaultsOptions{Timeout: time.Minute, Methods: []string{"PUT"}, MaxConns: &maxConns}
+	if err := SetDefaults(&opts); err != nil {
+		t.Fatalf("SetDefaults() error = %v", err)
+	}
+	if opts.Timeout != time.Minute || !reflect.DeepEqual(opts.Methods, []string{"PUT"}) || *opts.MaxConns != 0 {
+		t.Errorf("SetDefaults() overwrote values: %+v", opts)
+	}
+}
+
+func TestSetDefaultsErrors(t *testing.T) {
+	if err := SetDefaults(defaultsOptions{}); !errors.Is(err, ErrNotStructPointer) {
+		t.Errorf("SetDefaults(struct) error = %v, want ErrNotStructPointer", err)
+	}
+	var nilPtr *defaultsOptions
+	if err := SetDefaults(nilPtr); !errors.Is(err, ErrNotStructPointer) {
+		t.Errorf("SetDefaults(nil) error = %v, want ErrNotStructPointer", err)
+	}
+
+	var bad struct {
+		Nested struct {
+			Count int `default:"many"`
+		}
+	}
+	err := SetDefaults(&bad)
+	if !errors.Is(err, ErrConvert) || !strings.Contains(err.Error(), "Nested.Count") {
+		t.Errorf("SetDefaults() error = %v, want ErrConvert with field path", err)
+	}
+}
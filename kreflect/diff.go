@@ -0,0 +1,260 @@
+package kreflect
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"unsafe"
+)
+
+// Difference 两个值之间的一处不同
+type Difference struct {
+	Path string // 不同之处的路径,如 "Address.City"、"Items[2].Name"、"Meta[key]",根节点为空字符串
+	Old  any    // a中的值,不存在时为nil
+	New  any    // b中的值,不存在时为nil
+}
+
+// String 返回 "路径: 旧值 => 新值" 格式的字符串
+func (d Difference) String() string {
+	path := d.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("%s: %v => %v", path, d.Old, d.New)
+}
+
+// DeepEqual 判断两个值是否深度相等,比较规则参见 Diff
+//
+// 示例:
+//
+//	DeepEqual([]int{}, []int(nil))                      // true
+//	DeepEqual(map[string]int{"a": 1}, map[string]int{}) // false
+func DeepEqual(a, b any) bool {
+	d := &differ{stop: true, visited: make(map[visitPair]bool)}
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return len(d.diffs) == 0
+}
+
+// Diff 比较两个值,返回所有不同之处
+//
+// 参数说明:
+//   - a: 旧值
+//   - b: 新值
+//
+// 返回值说明:
+//   - []Difference: 所有不同之处,按路径的遍历顺序排列,相等时返回nil
+//
+// 注意事项:
+//   - 类型不同时视为整体不同
+//   - 递归比较指针指向的值、切片和数组的元素、map的值和结构体的导出字段,map的key按字符串顺序遍历
+//   - 长度不同的切片,多出的元素在另一方记为nil;nil切片和空切片、nil map和空map视为相等
+//   - 带有 Equal(T) bool 方法的类型(如time.Time)使用Equal比较
+//   - 结构体的未导出字段使用相同的规则逐个比较,导出字段都相等但未导出字段不同时,整个结构体视为不同
+//   - 函数只有都为nil时才相等
+//
+// 示例:
+//
+//	for _, d := range Diff(oldUser, newUser) {
+//	    log.Println(d) // Address.City: sh => bj
+//	}
+func Diff(a, b any) []Difference {
+	d := &differ{visited: make(map[visitPair]bool)}
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return d.diffs
+}
+
+// visitPair 已比较的指针对,避免循环引用导致无限递归
+type visitPair struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+type differ struct {
+	diffs   []Difference
+	visited map[visitPair]bool
+	stop    bool // 找到第一处不同时停止
+}
+
+func (d *differ) add(path string, a, b reflect.Value) {
+	d.diffs = append(d.diffs, Difference{Path: path, Old: valueInterface(a), New: valueInterface(b)})
+}
+
+func (d *differ) done() bool {
+	return d.stop && len(d.diffs) > 0
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	if d.done() {
+		return
+	}
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.add(path, a, b)
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		d.add(path, a, b)
+		return
+	}
+	if eq, ok := callEqual(a, b); ok {
+		if !eq {
+			d.add(path, a, b)
+		}
+		return
+	}
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.add(path, a, b)
+			}
+			return
+		}
+		if a.Kind() == reflect.Ptr {
+			key := visitPair{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+			if d.visited[key] {
+				return
+			}
+			d.visited[key] = true
+		}
+		d.diff(path, a.Elem(), b.Elem())
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.Len() == b.Len() && (a.Len() == 0 || a.Pointer() == b.Pointer()) {
+			return
+		}
+		for i := 0; i < max(a.Len(), b.Len()) && !d.done(); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				d.add(p, reflect.Value{}, b.Index(i))
+			case i >= b.Len():
+				d.add(p, a.Index(i), reflect.Value{})
+			default:
+				d.diff(p, a.Index(i), b.Index(i))
+			}
+		}
+	case reflect.Map:
+		if a.Len() == 0 && b.Len() == 0 || a.Pointer() == b.Pointer() {
+			return
+		}
+		for _, k := range sortedKeys(a, b) {
+			if d.done() {
+				return
+			}
+			p := fmt.Sprintf("%s[%v]", path, k)
+			av, bv := a.MapIndex(k), b.MapIndex(k)
+			if !av.IsValid() || !bv.IsValid() {
+				d.add(p, av, bv)
+				continue
+			}
+			d.diff(p, av, bv)
+		}
+	case reflect.Struct:
+		n := len(d.diffs)
+		var unexported []int
+		for i := 0; i < a.NumField() && !d.done(); i++ {
+			field := a.Type().Field(i)
+			if !field.IsExported() {
+				unexported = append(unexported, i)
+				continue
+			}
+			p := field.Name
+			if path != "" {
+				p = path + "." + field.Name
+			}
+			d.diff(p, a.Field(i), b.Field(i))
+		}
+		if len(d.diffs) == n && len(unexported) > 0 && d.unexportedDiffer(a, b, unexported) {
+			d.add(path, a, b)
+		}
+	case reflect.Func:
+		if !a.IsNil() || !b.IsNil() {
+			d.add(path, a, b)
+		}
+	case reflect.Float32, reflect.Float64:
+		if a.Float() != b.Float() {
+			d.add(path, a, b)
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if a.Complex() != b.Complex() {
+			d.add(path, a, b)
+		}
+	default:
+		if a.CanInterface() && b.CanInterface() && a.Interface() != b.Interface() {
+			d.add(path, a, b)
+		}
+	}
+}
+
+// unexportedDiffer 按相同的规则比较结构体的未导出字段,无法输出字段的值,只返回是否不同
+func (d *differ) unexportedDiffer(a, b reflect.Value, fields []int) bool {
+	a, b = addressable(a), addressable(b)
+	if !a.IsValid() || !b.IsValid() {
+		return false
+	}
+	sub := &differ{stop: true, visited: d.visited}
+	for _, i := range fields {
+		sub.diff("", readable(a.Field(i)), readable(b.Field(i)))
+		if sub.done() {
+			return true
+		}
+	}
+	return false
+}
+
+// addressable 返回可以取地址的v,v不能取地址时复制一份,无法复制时返回无效的值
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	if !v.CanInterface() {
+		return reflect.Value{}
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// readable 返回可以读取的未导出字段,v必须可以取地址
+func readable(v reflect.Value) reflect.Value {
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// callEqual 类型带有 Equal(T) bool 方法时调用Equal比较
+func callEqual(a, b reflect.Value) (equal bool, ok bool) {
+	if !a.CanInterface() || !b.CanInterface() {
+		return false, false
+	}
+	t := a.Type()
+	m, ok := t.MethodByName("Equal")
+	if !ok || m.Type.NumIn() != 2 || m.Type.In(1) != t || m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+	if t.Kind() == reflect.Ptr && (a.IsNil() || b.IsNil()) {
+		return a.IsNil() == b.IsNil(), true
+	}
+	return a.Method(m.Index).Call([]reflect.Value{b})[0].Bool(), true
+}
+
+// sortedKeys 返回两个map所有的key,按字符串顺序排列
+func sortedKeys(a, b reflect.Value) []reflect.Value {
+	keys := a.MapKeys()
+	for _, k := range b.MapKeys() {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
+// valueInterface 返回值的interface,无效或无法读取时返回nil
+func valueInterface(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,106 @@
+package kreflect
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type diffItem struct {
+	Name  string
+	Price float64
+}
+
+type diffOrder struct {
+	ID      int
+	Items   []diffItem
+	Meta    map[string]any
+	Owner   *testAddress
+	Created time.Time
+	note    string
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Now()
+	a := diffOrder{
+		ID:      1,
+		Items:   []diffItem{{"a", 1}, {"b", 2}},
+		Meta:    map[string]any{"x": 1, "y": []int{1}},
+		Owner:   &testAddress{City: "sh"},
+		Created: now,
+	}
+	b := diffOrder{
+		ID:      1,
+		Items:   []diffItem{{"a", 1}, {"b", 3}, {"c", 4}},
+		Meta:    map[string]any{"y": []int{2}, "z": "new"},
+		Owner:   &testAddress{City: "bj"},
+		Created: now.In(time.UTC),
+	}
+	expected := []Difference{
+		{Path: "Items[1].Price", Old: 2.0, New: 3.0},
+		{Path: "Items[2]", Old: nil, New: diffItem{"c", 4}},
+		{Path: "Meta[x]", Old: 1, New: nil},
+		{Path: "Meta[y][0]", Old: 1, New: 2},
+		{Path: "Meta[z]", Old: nil, New: "new"},
+		{Path: "Owner.City", Old: "sh", New: "bj"},
+	}
+	if got := Diff(a, b); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Diff() = %v, want %v", got, expected)
+	}
+	if DeepEqual(a, b) {
+		t.Error("DeepEqual() = true, want false")
+	}
+	if got := Diff(&a, &a); got != nil {
+		t.Errorf("Diff(&a, &a) = %v, want nil", got)
+	}
+
+	c := a
+	c.note = "changed"
+	if got := Diff(a, c); len(got) != 1 || got[0].Path != "" {
+		t.Errorf("Diff() unexported field = %v", got)
+	}
+	// 未导出字段使用相同的规则比较
+	type withUnexported struct {
+		Items []int
+		at    time.Time
+		items []int
+	}
+	if got := Diff(withUnexported{}, withUnexported{Items: []int{}, items: []int{}}); got != nil {
+		t.Errorf("Diff() nil and empty slice = %v, want nil", got)
+	}
+	if got := Diff(withUnexported{at: now}, withUnexported{at: now.Round(0)}); got != nil {
+		t.Errorf("Diff() unexported time = %v, want nil", got)
+	}
+	if got := Diff(&withUnexported{items: []int{1}}, &withUnexported{items: []int{2}}); len(got) != 1 || got[0].Path != "" {
+		t.Errorf("Diff() unexported slice = %v", got)
+	}
+
+	if got := Diff(1, "1"); len(got) != 1 || got[0].String() != "(root): 1 => 1" {
+		t.Errorf("Diff() different types = %v", got)
+	}
+
+	tests := []struct {
+		a, b     any
+		expected bool
+	}{
+		{nil, nil, true},
+		{nil, 0, false},
+		{[]int{}, []int(nil), true},
+		{map[string]int{}, map[string]int(nil), true},
+		{[2]int{1, 2}, [2]int{1, 2}, true},
+		{now, now.Add(0).In(time.UTC), true},
+		{func() {}, func() {}, false},
+	}
+	for _, test := range tests {
+		if got := DeepEqual(test.a, test.b); got != test.expected {
+			t.Errorf("DeepEqual(%v, %v) = %v, want %v", test.a, test.b, got, test.expected)
+		}
+	}
+
+	type cyclic struct{ Next *cyclic }
+	x, y := &cyclic{}, &cyclic{}
+	x.Next, y.Next = x, y
+	if !DeepEqual(x, y) {
+		t.Error("DeepEqual() cyclic = false, want true")
+	}
+}
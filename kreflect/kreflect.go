@@ -2,7 +2,22 @@
 //
 // 主要功能:
 //   - IsNil: 判断任意类型是否为nil
+//   - IsZero/IsEmpty: 判断任意类型是否为零值或空值
 //   - ToString: 将任意类型转换为string类型
+//   - ToInt64/ToFloat64/ToBool/ToDuration: 将任意类型尽量转换为对应类型
+//   - ToStringSlice/ToMapStringAny: 将切片、数组、map等松散类型的数据统一为[]string和map[string]any
+//   - StructToMap: 根据标签将结构体转换为map
+//   - MapToStruct: 根据标签将map中的值转换后赋给结构体
+//   - ConvertTo: 将任意类型的值尽量转换为指定的类型
+//   - DeepCopy: 深拷贝任意类型的值
+//   - DeepEqual/Diff: 深度比较两个值,并返回所有不同之处的路径
+//   - GetField/SetField: 根据路径读取和设置字段的值,如 "Items[2].Name"
+//   - MergeStructs: 将结构体的非零值字段合并到另一个结构体
+//   - Pluck/PluckFunc: 提取结构体切片中每个元素的某个字段
+//   - CopyFields: 在不同类型的结构体之间复制名称相同的字段,支持自定义转换
+//   - Validate: 根据结构体标签中的规则校验字段
+//   - Call: 转换参数后调用任意函数,并捕获panic
+//   - SetDefaults: 根据default标签为结构体的零值字段设置默认值
 package kreflect
 
 import (
@@ -49,6 +64,65 @@ func IsNil(a any) bool {
 	}
 }
 
+// IsZero 判断一个值是否为其类型的零值
+//
+// 参数说明:
+//   - a: 任意类型的值(any)
+//
+// 返回值说明:
+//   - bool: nil或零值返回true,如0、""、false、nil指针、所有字段都为零值的结构体
+//
+// 注意事项:
+//   - 可以处理reflect.Value类型的输入
+//   - 空切片和空map不是零值,需要时使用 IsEmpty
+func IsZero(a any) bool {
+	if a == nil {
+		return true
+	}
+	var rv reflect.Value
+	if v, ok := a.(reflect.Value); ok {
+		rv = v
+	} else {
+		rv = reflect.ValueOf(a)
+	}
+	return !rv.IsValid() || rv.IsZero()
+}
+
+// IsEmpty 判断一个值是否为空
+//
+// 参数说明:
+//   - a: 任意类型的值(any)
+//
+// 返回值说明:
+//   - bool: 零值(参见 IsZero)或长度为0的切片、map、数组、chan和字符串返回true
+//
+// 注意事项:
+//   - 可以处理reflect.Value类型的输入
+//   - 不会解引用指针,指向空值的非nil指针不为空
+//
+// 示例:
+//
+//	IsEmpty([]int{})           // true
+//	IsEmpty(struct{ A int }{}) // true
+//	kslice.Filter(s, func(_ int, v string) bool { return !IsEmpty(v) }) // 过滤空字符串
+func IsEmpty(a any) bool {
+	if IsZero(a) {
+		return true
+	}
+	var rv reflect.Value
+	if v, ok := a.(reflect.Value); ok {
+		rv = v
+	} else {
+		rv = reflect.ValueOf(a)
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Chan, reflect.String:
+		return rv.Len() == 0
+	default:
+		return false
+	}
+}
+
 // ToString 将任意类型转换为string类型
 //
 // 参数说明:
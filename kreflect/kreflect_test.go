@@ -30,3 +30,36 @@ func TestToString(t *testing.T) {
 		}
 	}
 }
+
+func TestIsZero(t *testing.T) {
+	var nilPtr *int
+	tests := []struct {
+		input any
+		zero  bool
+		empty bool
+	}{
+		{nil, true, true},
+		{0, true, true},
+		{1, false, false},
+		{"", true, true},
+		{" ", false, false},
+		{nilPtr, true, true},
+		{new(int), false, false},
+		{[]int{}, false, true},
+		{[]int{0}, false, false},
+		{map[string]int{}, false, true},
+		{[0]int{}, true, true},
+		{[1]int{}, true, true},
+		{struct{ A int }{}, true, true},
+		{struct{ A []int }{A: []int{}}, false, false},
+		{make(chan int, 1), false, true},
+	}
+	for _, test := range tests {
+		if got := IsZero(test.input); got != test.zero {
+			t.Errorf("IsZero(%v) = %v, want %v", test.input, got, test.zero)
+		}
+		if got := IsEmpty(test.input); got != test.empty {
+			t.Errorf("IsEmpty(%v) = %v, want %v", test.input, got, test.empty)
+		}
+	}
+}
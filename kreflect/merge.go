@@ -0,0 +1,129 @@
+package kreflect
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotStruct = errors.New("kreflect: src must be a struct or pointer to struct")
+)
+
+// MergeOptions 合并结构体的配置项
+type MergeOptions struct {
+	PointerFields bool // src的指针字段不为nil即视为需要合并,即使指向零值
+}
+
+type MergeOption func(*MergeOptions)
+
+func NewMergeOptions() *MergeOptions {
+	return &MergeOptions{}
+}
+
+// WithMergePointerFields 使用指针字段的语义合并:src的指针字段为nil表示未设置,不为nil时整体覆盖dst对应的字段,即使指向零值
+// 适用于PATCH请求中使用指针字段区分"未传"和"传了零值"
+func WithMergePointerFields() MergeOption {
+	return func(o *MergeOptions) {
+		o.PointerFields = true
+	}
+}
+
+// MergeStructs 将src中的非零值字段合并到dst
+//
+// 参数说明:
+//   - dst: 结构体指针
+//   - src: 结构体或结构体指针,可以与dst类型不同
+//   - opts: 可选配置项,参见 MergeOptions
+//
+// 返回值说明:
+//   - error: dst不是结构体指针时返回 ErrNotStructPointer,src不是结构体时返回 ErrNotStruct,
+//     字段类型无法转换时返回 ErrConvert,并带有字段名称
+//
+// 注意事项:
+//   - 按字段名称匹配,忽略未导出的字段和dst中不存在的字段,类型不同时使用 ConvertTo 转换
+//   - 默认情况下,指针字段按指向的值判断是否为零值;嵌套的结构体递归合并,只覆盖其中的非零值字段
+//   - 使用 WithMergePointerFields 时,非nil的指针字段整体覆盖,不再递归合并
+//   - 无法将字段重置为零值,需要时使用 WithMergePointerFields
+//
+// 示例:
+//
+//	type UserPatch struct {
+//	    Name *string
+//	    Age  *int
+//	}
+//	// 只更新请求中传了的字段,Age传0时也会更新
+//	err := MergeStructs(&user, patch, WithMergePointerFields())
+func MergeStructs(dst, src any, opts ...MergeOption) error {
+	o := NewMergeOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+	sv, ok := indirect(src)
+	if !ok || sv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	return mergeStruct(dv.Elem(), sv, o)
+}
+
+func mergeStruct(dst, src reflect.Value, o *MergeOptions) error {
+	for _, field := range reflect.VisibleFields(src.Type()) {
+		if !field.IsExported() || field.Anonymous && derefType(field.Type).Kind() == reflect.Struct {
+			// 匿名嵌入结构体的字段已经提升,逐个合并
+			continue
+		}
+		sv, err := src.FieldByIndexErr(field.Index)
+		if err != nil {
+			continue
+		}
+		df, ok := dst.Type().FieldByName(field.Name)
+		if !ok || !df.IsExported() {
+			continue
+		}
+		dv, ok := fieldByIndexAlloc(dst, df.Index)
+		if !ok {
+			continue
+		}
+		if err := mergeValue(dv, sv, o); err != nil {
+			return errors.WithMessagef(err, "field %s", field.Name)
+		}
+	}
+	return nil
+}
+
+func mergeValue(dst, src reflect.Value, o *MergeOptions) error {
+	if o.PointerFields && src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			return nil
+		}
+		return convertValue(src, dst)
+	}
+	s, ok := indirect(src)
+	if !ok || s.IsZero() {
+		return nil
+	}
+	if isNestedStruct(s.Type()) && isNestedStruct(derefType(dst.Type())) {
+		target, _ := structTarget(dst)
+		return mergeStruct(target, s, o)
+	}
+	return convertValue(src, dst)
+}
+
+// fieldByIndexAlloc 同 FieldByIndex,路径中的nil嵌入指针会创建,无法创建时返回false
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 {
+			target, ok := structTarget(v)
+			if !ok {
+				return v, false
+			}
+			v = target
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,82 @@
+package kreflect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type mergeProfile struct {
+	Bio  string
+	Tags []string
+}
+
+type mergeUser struct {
+	testBase
+	Name    string
+	Age     int
+	Active  bool
+	Address testAddress
+	Profile *mergeProfile
+}
+
+type mergeUserPatch struct {
+	Name    *string
+	Age     *int
+	Active  *bool
+	Address *testAddress
+	Unknown string
+}
+
+func TestMergeStructs(t *testing.T) {
+	user := mergeUser{
+		testBase: testBase{ID: 1},
+		Name:     "tom",
+		Age:      18,
+		Active:   true,
+		Address:  testAddress{City: "sh", Zip: "200000"},
+	}
+	err := MergeStructs(&user, mergeUser{
+		Name:    "amy",
+		Address: testAddress{City: "bj"},
+		Profile: &mergeProfile{Bio: "hi"},
+	})
+	if err != nil {
+		t.Fatalf("MergeStructs() error = %v", err)
+	}
+	expected := mergeUser{
+		testBase: testBase{ID: 1},
+		Name:     "amy",
+		Age:      18,
+		Active:   true,
+		Address:  testAddress{City: "bj", Zip: "200000"},
+		Profile:  &mergeProfile{Bio: "hi"},
+	}
+	if !reflect.DeepEqual(user, expected) {
+		t.Errorf("MergeStructs() = %+v, want %+v", user, expected)
+	}
+
+	name, age, active := "", 0, false
+	patch := mergeUserPatch{Name: &name, Age: &age, Active: &active, Unknown: "x"}
+	merged := user
+	if err := MergeStructs(&merged, patch); err != nil || !reflect.DeepEqual(merged, user) {
+		t.Errorf("MergeStructs() zero pointers = %+v, %v, want unchanged", merged, err)
+	}
+	if err := MergeStructs(&merged, &patch, WithMergePointerFields()); err != nil {
+		t.Fatalf("MergeStructs() error = %v", err)
+	}
+	if merged.Name != "" || merged.Age != 0 || merged.Active || merged.Address != user.Address {
+		t.Errorf("MergeStructs() pointer fields = %+v", merged)
+	}
+
+	if err := MergeStructs(user, patch); !errors.Is(err, ErrNotStructPointer) {
+		t.Errorf("MergeStructs() error = %v, want ErrNotStructPointer", err)
+	}
+	if err := MergeStructs(&user, 1); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("MergeStructs() error = %v, want ErrNotStruct", err)
+	}
+	type badPatch struct{ Age string }
+	if err := MergeStructs(&user, badPatch{Age: "old"}); !errors.Is(err, ErrConvert) {
+		t.Errorf("MergeStructs() error = %v, want ErrConvert", err)
+	}
+}
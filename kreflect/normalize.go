@@ -0,0 +1,138 @@
+package kreflect
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// ToStringSlice 将任意类型尽量转换为[]string类型
+//
+// 参数说明:
+//   - a: 任意类型的值(any)
+//
+// 返回值说明:
+//   - []string: 转换后的切片
+//
+// 注意事项:
+//   - nil和nil指针返回nil
+//   - 切片和数组的每个元素使用 ToString 转换,[]byte视为单个字符串
+//   - map返回按字符串顺序排列的key,适用于 map[string]struct{} 等形式的集合
+//   - 其他单个值返回只包含 ToString(a) 的切片
+//
+// 示例:
+//
+//	ToStringSlice([]any{1, "a", true})                   // ["1" "a" "true"]
+//	ToStringSlice([2]int{1, 2})                          // ["1" "2"]
+//	ToStringSlice(map[string]bool{"b": true, "a": true}) // ["a" "b"]
+//	ToStringSlice(42)                                    // ["42"]
+func ToStringSlice(a any) []string {
+	if s, ok := a.([]string); ok {
+		return s
+	}
+	rv, ok := indirect(a)
+	if !ok {
+		return nil
+	}
+	if b, ok := bytesOf(rv); ok {
+		return []string{string(b)}
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+		result := make([]string, rv.Len())
+		for i := range result {
+			result[i] = ToString(valueInterface(rv.Index(i)))
+		}
+		return result
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		result := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			result = append(result, ToString(valueInterface(k)))
+		}
+		sort.Strings(result)
+		return result
+	default:
+		return []string{ToString(valueInterface(rv))}
+	}
+}
+
+// ToMapStringAny 将任意类型尽量转换为map[string]any类型
+//
+// 参数说明:
+//   - a: 任意类型的值(any)
+//
+// 返回值说明:
+//   - map[string]any: 转换后的map,无法转换时返回nil
+//
+// 注意事项:
+//   - map的key使用 ToString 转换,值中key不是string的map(如yaml解析出的map[any]any)
+//     及[]any中的这类map会递归转换,输入已是map[string]any时同样如此,返回的是新map
+//   - 结构体使用 StructToMap 按json标签转换
+//   - JSON对象格式的string和[]byte会先解析
+//   - 其他类型返回nil
+//
+// 示例:
+//
+//	ToMapStringAny(map[any]any{1: "a"}) // map[1:a]
+//	ToMapStringAny(`{"name":"k"}`)      // map[name:k]
+//	ToMapStringAny(User{Name: "k"})     // map[name:k]
+func ToMapStringAny(a any) map[string]any {
+	rv, ok := indirect(a)
+	if !ok {
+		return nil
+	}
+	if b, ok := bytesOf(rv); ok {
+		return jsonToMap(b)
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return jsonToMap([]byte(rv.String()))
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		result := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result[ToString(valueInterface(iter.Key()))] = normalizeValue(valueInterface(iter.Value()))
+		}
+		return result
+	case reflect.Struct:
+		if !isNestedStruct(rv.Type()) {
+			return nil
+		}
+		return StructToMap(rv)
+	default:
+		return nil
+	}
+}
+
+// jsonToMap 解析JSON对象,格式错误时返回nil
+func jsonToMap(b []byte) map[string]any {
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// normalizeValue 将map递归转换为map[string]any,[]any中的元素同样处理
+func normalizeValue(a any) any {
+	if value, ok := a.([]any); ok {
+		result := make([]any, len(value))
+		for i, item := range value {
+			result[i] = normalizeValue(item)
+		}
+		return result
+	}
+	if rv := reflect.ValueOf(a); rv.Kind() == reflect.Map {
+		return ToMapStringAny(a)
+	}
+	return a
+}
@@ -0,0 +1,68 @@
+package kreflect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToStringSlice(t *testing.T) {
+	var nilSlice []int
+	var nilPtr *[]string
+	tests := []struct {
+		input    any
+		expected []string
+	}{
+		{nil, nil},
+		{nilSlice, nil},
+		{nilPtr, nil},
+		{[]string{"a", "b"}, []string{"a", "b"}},
+		{[]any{1, "a", true, nil}, []string{"1", "a", "true", ""}},
+		{[2]int{1, 2}, []string{"1", "2"}},
+		{&[]int{3}, []string{"3"}},
+		{[]byte("ab"), []string{"ab"}},
+		{map[string]bool{"b": true, "a": true}, []string{"a", "b"}},
+		{map[int]struct{}{2: {}, 1: {}}, []string{"1", "2"}},
+		{42, []string{"42"}},
+		{"x", []string{"x"}},
+	}
+	for _, test := range tests {
+		if got := ToStringSlice(test.input); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("ToStringSlice(%v) = %#v, want %#v", test.input, got, test.expected)
+		}
+	}
+}
+
+func TestToMapStringAny(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+	tests := []struct {
+		input    any
+		expected map[string]any
+	}{
+		{nil, nil},
+		{map[string]any{"a": 1}, map[string]any{"a": 1}},
+		{
+			map[string]any{"a": map[any]any{"b": 1}, "c": map[string]any{"d": map[int]int{1: 2}}},
+			map[string]any{"a": map[string]any{"b": 1}, "c": map[string]any{"d": map[string]any{"1": 2}}},
+		},
+		{map[string]int{"a": 1}, map[string]any{"a": 1}},
+		{map[int]string{1: "a"}, map[string]any{"1": "a"}},
+		{
+			map[any]any{"a": map[any]any{"b": 1}, "c": []any{map[any]any{1: 2}, 3}},
+			map[string]any{"a": map[string]any{"b": 1}, "c": []any{map[string]any{"1": 2}, 3}},
+		},
+		{item{Name: "k"}, map[string]any{"name": "k"}},
+		{&item{Name: "k"}, map[string]any{"name": "k"}},
+		{`{"name":"k"}`, map[string]any{"name": "k"}},
+		{[]byte(`{"n":1}`), map[string]any{"n": float64(1)}},
+		{"not json", nil},
+		{42, nil},
+		{[]int{1}, nil},
+	}
+	for _, test := range tests {
+		if got := ToMapStringAny(test.input); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("ToMapStringAny(%v) = %#v, want %#v", test.input, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package kreflect
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidPath     = errors.New("kreflect: invalid path")
+	ErrFieldNotFound   = errors.New("kreflect: field not found")
+	ErrIndexOutOfRange = errors.New("kreflect: index out of range")
+	ErrNilValue        = errors.New("kreflect: nil value in path")
+	ErrNotSettable     = errors.New("kreflect: value is not settable")
+)
+
+// pathToken 路径中的一段,字段名称或者切片、数组的下标、map的key
+type pathToken struct {
+	name    string // 字段名称,isIndex为true时为下标或key
+	isIndex bool
+	path    string // 到当前段为止的路径,用于错误信息
+}
+
+// parsePath 解析路径,如 "Items[2].Name"、"Meta[key]"、"[0].ID"
+func parsePath(path string) ([]pathToken, error) {
+	var tokens []pathToken
+	rest := path
+	for rest != "" {
+		consumed := len(path) - len(rest)
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, errors.Wrapf(ErrInvalidPath, "%q: missing ]", path)
+			}
+			tokens = append(tokens, pathToken{name: rest[1:end], isIndex: true, path: path[:consumed+end+1]})
+			rest = rest[end+1:]
+		case '.':
+			if consumed == 0 || len(rest) == 1 || rest[1] == '.' || rest[1] == '[' {
+				return nil, errors.Wrapf(ErrInvalidPath, "%q", path)
+			}
+			rest = rest[1:]
+		default:
+			if consumed > 0 && path[consumed-1] == ']' {
+				return nil, errors.Wrapf(ErrInvalidPath, "%q: missing . after ]", path)
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			tokens = append(tokens, pathToken{name: rest[:end], path: path[:consumed+end]})
+			rest = rest[end:]
+		}
+	}
+	return tokens, nil
+}
+
+// GetField 根据路径读取字段的值
+//
+// 参数说明:
+//   - obj: 结构体、切片、map或它们的指针
+//   - path: 以"."分隔的字段名称,使用"[下标]"访问切片和数组的元素,使用"[key]"访问map的值,
+//     如 "Order.Items[2].Name"、"Meta[region]",为空时返回obj本身
+//
+// 返回值说明:
+//   - any: 字段的值
+//   - error: 路径格式错误时返回 ErrInvalidPath,字段不存在或未导出时返回 ErrFieldNotFound,
+//     下标越界或map中不存在key时返回 ErrIndexOutOfRange,路径中间的值为nil时返回 ErrNilValue,错误信息中带有出错的路径
+//
+// 注意事项:
+//   - 使用结构体的字段名称而不是标签,支持匿名嵌入结构体提升的字段
+//   - 路径格式与 Diff 返回的路径一致
+//
+// 示例:
+//
+//	name, err := GetField(order, "Items[2].Name")
+func GetField(obj any, path string) (any, error) {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	v, err := walkPath(reflect.ValueOf(obj), tokens)
+	if err != nil {
+		return nil, err
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	return v.Interface(), nil
+}
+
+// SetField 根据路径设置字段的值
+//
+// 参数说明:
+//   - obj: 结构体、切片或map的指针,map也可以直接传入
+//   - path: 字段路径,格式同 GetField,不能为空
+//   - value: 字段的新值,会使用 ConvertTo 转换为字段的类型
+//
+// 返回值说明:
+//   - error: 除 GetField 的错误外,obj无法修改时返回 ErrNotSettable,类型无法转换时返回 ErrConvert
+//
+// 注意事项:
+//   - 路径中间为nil的指针和map会自动创建
+//   - 切片下标越界时返回 ErrIndexOutOfRange,不会自动扩容
+//
+// 示例:
+//
+//	err := SetField(&order, "Items[2].Price", "9.9")
+func SetField(obj any, path string, value any) error {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return errors.Wrapf(ErrInvalidPath, "%q: empty path", path)
+	}
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.CanSet() && v.Kind() != reflect.Map {
+		return errors.Wrapf(ErrNotSettable, "%T", obj)
+	}
+	return setPath(v, tokens, value)
+}
+
+// walkPath 沿路径读取值
+func walkPath(v reflect.Value, tokens []pathToken) (reflect.Value, error) {
+	var err error
+	for _, token := range tokens {
+		if v, err = derefPath(v, token); err != nil {
+			return v, err
+		}
+		if v, err = stepPath(v, token); err != nil {
+			return v, err
+		}
+	}
+	return v, nil
+}
+
+// derefPath 解引用指针和接口,值为nil时返回 ErrNilValue
+func derefPath(v reflect.Value, token pathToken) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, errors.Wrapf(ErrNilValue, "before %q", token.path)
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return v, errors.Wrapf(ErrNilValue, "before %q", token.path)
+	}
+	return v, nil
+}
+
+// stepPath 读取路径中的一段
+func stepPath(v reflect.Value, token pathToken) (reflect.Value, error) {
+	if !token.isIndex {
+		if v.Kind() != reflect.Struct {
+			return v, errors.Wrapf(ErrFieldNotFound, "%q: %s is not a struct", token.path, v.Type())
+		}
+		field, ok := v.Type().FieldByName(token.name)
+		if !ok || !field.IsExported() {
+			return v, errors.Wrapf(ErrFieldNotFound, "%q", token.path)
+		}
+		f, err := v.FieldByIndexErr(field.Index)
+		if err != nil {
+			return v, errors.Wrapf(ErrNilValue, "%q: %v", token.path, err)
+		}
+		return f, nil
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.String:
+		i, err := strconv.Atoi(token.name)
+		if err != nil {
+			return v, errors.Wrapf(ErrInvalidPath, "%q: invalid index", token.path)
+		}
+		if i < 0 || i >= v.Len() {
+			return v, errors.Wrapf(ErrIndexOutOfRange, "%q: length %d", token.path, v.Len())
+		}
+		return v.Index(i), nil
+	case reflect.Map:
+		key, err := ConvertTo(token.name, v.Type().Key())
+		if err != nil {
+			return v, errors.WithMessagef(err, "%q: invalid key", token.path)
+		}
+		elem := v.MapIndex(key)
+		if !elem.IsValid() {
+			return v, errors.Wrapf(ErrIndexOutOfRange, "%q: key not found", token.path)
+		}
+		return elem, nil
+	default:
+		return v, errors.Wrapf(ErrInvalidPath, "%q: %s cannot be indexed", token.path, v.Type())
+	}
+}
+
+// setPath 沿路径设置值,v必须是可以修改的,map的值和接口中的值会复制后修改再写回
+func setPath(v reflect.Value, tokens []pathToken, value any) error {
+	if len(tokens) == 0 {
+		converted, err := ConvertTo(value, v.Type())
+		if err != nil {
+			return err
+		}
+		v.Set(converted)
+		return nil
+	}
+	token := tokens[0]
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setPath(v.Elem(), tokens, value)
+	case reflect.Interface:
+		if v.IsNil() {
+			return errors.Wrapf(ErrNilValue, "before %q", token.path)
+		}
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := setPath(elem, tokens, value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Map:
+		if !token.isIndex {
+			break
+		}
+		key, err := ConvertTo(token.name, v.Type().Key())
+		if err != nil {
+			return errors.WithMessagef(err, "%q: invalid key", token.path)
+		}
+		if v.IsNil() {
+			if !v.CanSet() {
+				return errors.Wrapf(ErrNotSettable, "before %q: nil map", token.path)
+			}
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if old := v.MapIndex(key); old.IsValid() {
+			elem.Set(old)
+		}
+		if err := setPath(elem, tokens[1:], value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	case reflect.String:
+		if token.isIndex {
+			return errors.Wrapf(ErrNotSettable, "%q: string is immutable", token.path)
+		}
+	}
+	next, err := stepPath(v, token)
+	if err != nil {
+		return err
+	}
+	return setPath(next, tokens[1:], value)
+}
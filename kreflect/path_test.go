@@ -0,0 +1,153 @@
+package kreflect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type pathItem struct {
+	Name  string
+	Price float64
+}
+
+type pathOrder struct {
+	testBase
+	Items    []pathItem
+	Meta     map[string]any
+	Counts   map[int]int
+	Owner    *testAddress
+	Fixed    [2]pathItem
+	Any      any
+	internal string
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []string
+		err      bool
+	}{
+		{"", nil, false},
+		{"A.B", []string{"A", "B"}, false},
+		{"Items[2].Name", []string{"Items", "[2]", "Name"}, false},
+		{"[0][1]", []string{"[0]", "[1]"}, false},
+		{"Meta[a.b]", []string{"Meta", "[a.b]"}, false},
+		{".A", nil, true},
+		{"A.", nil, true},
+		{"A..B", nil, true},
+		{"A[0", nil, true},
+		{"A[0]B", nil, true},
+	}
+	for _, test := range tests {
+		tokens, err := parsePath(test.path)
+		if (err != nil) != test.err {
+			t.Errorf("parsePath(%q) error = %v, want err %v", test.path, err, test.err)
+			continue
+		}
+		var got []string
+		for _, token := range tokens {
+			if token.isIndex {
+				got = append(got, "["+token.name+"]")
+			} else {
+				got = append(got, token.name)
+			}
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("parsePath(%q) = %v, want %v", test.path, got, test.expected)
+		}
+	}
+}
+
+func TestGetField(t *testing.T) {
+	order := &pathOrder{
+		testBase: testBase{ID: 9},
+		Items:    []pathItem{{"a", 1}, {"b", 2}},
+		Meta:     map[string]any{"tags": []string{"x", "y"}},
+		Counts:   map[int]int{3: 30},
+		Any:      pathItem{"c", 3},
+	}
+	tests := []struct {
+		path     string
+		expected any
+		err      error
+	}{
+		{"ID", int64(9), nil},
+		{"Items[1].Name", "b", nil},
+		{"Meta[tags][1]", "y", nil},
+		{"Counts[3]", 30, nil},
+		{"Any.Price", 3.0, nil},
+		{"Items[5]", nil, ErrIndexOutOfRange},
+		{"Meta[none]", nil, ErrIndexOutOfRange},
+		{"Missing", nil, ErrFieldNotFound},
+		{"internal", nil, ErrFieldNotFound},
+		{"Owner.City", nil, ErrNilValue},
+		{"Items.Name", nil, ErrFieldNotFound},
+		{"Counts[x]", nil, ErrConvert},
+		{"Items[x]", nil, ErrInvalidPath},
+	}
+	for _, test := range tests {
+		got, err := GetField(order, test.path)
+		if !errors.Is(err, test.err) || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("GetField(%q) = %v, %v, want %v, %v", test.path, got, err, test.expected, test.err)
+		}
+	}
+	if got, err := GetField([]int{1, 2}, "[1]"); err != nil || got != 2 {
+		t.Errorf("GetField(slice) = %v, %v", got, err)
+	}
+}
+
+func TestSetField(t *testing.T) {
+	order := &pathOrder{Items: []pathItem{{"a", 1}}, Any: pathItem{"c", 3}}
+	sets := []struct {
+		path  string
+		value any
+	}{
+		{"ID", "12"},
+		{"Items[0].Price", "9.5"},
+		{"Meta[region]", "sh"},
+		{"Counts[1]", 2.0},
+		{"Owner.City", "bj"},
+		{"Fixed[1].Name", "f"},
+		{"Any.Name", "d"},
+	}
+	for _, set := range sets {
+		if err := SetField(order, set.path, set.value); err != nil {
+			t.Fatalf("SetField(%q) error = %v", set.path, err)
+		}
+	}
+	expected := &pathOrder{
+		testBase: testBase{ID: 12},
+		Items:    []pathItem{{"a", 9.5}},
+		Meta:     map[string]any{"region": "sh"},
+		Counts:   map[int]int{1: 2},
+		Owner:    &testAddress{City: "bj"},
+		Fixed:    [2]pathItem{{}, {Name: "f"}},
+		Any:      pathItem{"d", 3},
+	}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("SetField() = %+v, want %+v", order, expected)
+	}
+
+	m := map[string]pathItem{"a": {}}
+	if err := SetField(m, "[a].Name", "x"); err != nil || m["a"].Name != "x" {
+		t.Errorf("SetField(map) = %v, %v", m, err)
+	}
+
+	errTests := []struct {
+		obj  any
+		path string
+		err  error
+	}{
+		{order, "Items[3].Name", ErrIndexOutOfRange},
+		{order, "ID", ErrConvert},
+		{*order, "ID", ErrNotSettable},
+		{order, "", ErrInvalidPath},
+		{order, "internal", ErrFieldNotFound},
+	}
+	for _, test := range errTests {
+		if err := SetField(test.obj, test.path, "x"); !errors.Is(err, test.err) {
+			t.Errorf("SetField(%q) error = %v, want %v", test.path, err, test.err)
+		}
+	}
+}
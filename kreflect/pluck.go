@@ -0,0 +1,71 @@
+package kreflect
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotSlice = errors.New("kreflect: not a slice or array")
+)
+
+// Pluck 提取结构体切片中每个元素的某个字段,组成新的切片
+//
+// 参数说明:
+//   - slice: 结构体或结构体指针的切片、数组,也可以是它们的指针
+//   - field: 字段名称,支持 GetField 的路径格式,如 "User.ID"
+//
+// 返回值说明:
+//   - []any: 字段值组成的切片,与slice的顺序一致
+//   - error: slice不是切片或数组时返回 ErrNotSlice,读取字段失败时返回 GetField 的错误,并带有元素的下标
+//
+// 注意事项:
+//   - 类型确定时优先使用 PluckFunc,没有反射的开销
+//
+// 示例:
+//
+//	ids, err := Pluck(orders, "ID")
+//	db.Where("id IN ?", ids)
+func Pluck(slice any, field string) ([]any, error) {
+	tokens, err := parsePath(field)
+	if err != nil {
+		return nil, err
+	}
+	rv, ok := indirect(slice)
+	if !ok {
+		return nil, nil
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, errors.Wrapf(ErrNotSlice, "%T", slice)
+	}
+	result := make([]any, rv.Len())
+	for i := range result {
+		v, err := walkPath(rv.Index(i), tokens)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "[%d]", i)
+		}
+		result[i] = v.Interface()
+	}
+	return result, nil
+}
+
+// PluckFunc 使用函数提取切片中每个元素的值,组成新的切片
+//
+// 参数说明:
+//   - s: 任意类型的切片
+//   - fn: 提取函数,接收元素,返回需要的值
+//
+// 返回值说明:
+//   - []V: 提取的值组成的切片,与s的顺序一致
+//
+// 示例:
+//
+//	ids := PluckFunc(orders, func(o *Order) int64 { return o.ID })
+func PluckFunc[T, V any](s []T, fn func(T) V) []V {
+	result := make([]V, len(s))
+	for i, item := range s {
+		result[i] = fn(item)
+	}
+	return result
+}
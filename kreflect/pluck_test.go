@@ -0,0 +1,46 @@
+package kreflect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPluck(t *testing.T) {
+	orders := []*pathOrder{
+		{testBase: testBase{ID: 1}, Owner: &testAddress{City: "sh"}},
+		{testBase: testBase{ID: 2}, Owner: &testAddress{City: "bj"}},
+	}
+	tests := []struct {
+		slice    any
+		field    string
+		expected []any
+		err      error
+	}{
+		{orders, "ID", []any{int64(1), int64(2)}, nil},
+		{&orders, "Owner.City", []any{"sh", "bj"}, nil},
+		{[2]testAddress{{City: "a"}, {City: "b"}}, "City", []any{"a", "b"}, nil},
+		{[]testAddress{}, "City", []any{}, nil},
+		{nil, "City", nil, nil},
+		{orders, "Missing", nil, ErrFieldNotFound},
+		{[]*pathOrder{nil}, "ID", nil, ErrNilValue},
+		{orders[0], "ID", nil, ErrNotSlice},
+	}
+	for _, test := range tests {
+		got, err := Pluck(test.slice, test.field)
+		if !errors.Is(err, test.err) || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("Pluck(%T, %q) = %v, %v, want %v, %v", test.slice, test.field, got, err, test.expected, test.err)
+		}
+	}
+}
+
+func TestPluckFunc(t *testing.T) {
+	addresses := []testAddress{{City: "sh"}, {City: "bj"}}
+	got := PluckFunc(addresses, func(a testAddress) string { return a.City })
+	if !reflect.DeepEqual(got, []string{"sh", "bj"}) {
+		t.Errorf("PluckFunc() = %v", got)
+	}
+	if got := PluckFunc([]testAddress(nil), func(a testAddress) string { return a.City }); len(got) != 0 {
+		t.Errorf("PluckFunc(nil) = %v", got)
+	}
+}
@@ -0,0 +1,323 @@
+package kreflect
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotStructPointer = errors.New("kreflect: dst must be a non-nil pointer to struct")
+)
+
+// StructOptions 结构体相关操作的配置项
+type StructOptions struct {
+	Tag      string // 读取字段名称的标签,默认为json,标签为空时使用字段名称,为"-"时忽略该字段
+	OmitZero bool   // 是否忽略零值字段
+	Flatten  bool   // 是否将嵌套结构体展开为以"."连接的key,如 "Address.City",MapToStruct 时从展开的key中读取
+}
+
+type StructOption func(*StructOptions)
+
+func NewStructOptions() *StructOptions {
+	return &StructOptions{
+		Tag: "json",
+	}
+}
+
+// WithTag 设置读取字段名称的标签
+func WithTag(tag string) StructOption {
+	return func(o *StructOptions) {
+		o.Tag = tag
+	}
+}
+
+// WithOmitZero 忽略零值字段
+func WithOmitZero() StructOption {
+	return func(o *StructOptions) {
+		o.OmitZero = true
+	}
+}
+
+// WithFlatten 将嵌套结构体展开为以"."连接的key
+func WithFlatten() StructOption {
+	return func(o *StructOptions) {
+		o.Flatten = true
+	}
+}
+
+// StructToMap 将结构体转换为map
+//
+// 参数说明:
+//   - v: 结构体或结构体指针
+//   - opts: 可选配置项,参见 StructOptions
+//
+// 返回值说明:
+//   - map[string]any: 转换后的map,v不是结构体或为nil时返回nil
+//
+// 注意事项:
+//   - 忽略未导出的字段,标签带有omitempty的字段为零值时也会被忽略
+//   - 没有标签名称的匿名嵌入结构体,其字段提升到外层,与encoding/json一致
+//   - 嵌套的结构体和结构体指针会递归转换为map,实现了json.Marshaler或encoding.TextMarshaler的类型(如time.Time)保持原值
+//   - 切片和map中的结构体保持原值
+//   - 结构体指针形成环时(如链表的节点指向自身),指回的字段被忽略
+//
+// 示例:
+//
+//	type Address struct {
+//	    City string `json:"city"`
+//	}
+//	type User struct {
+//	    Name    string  `json:"name"`
+//	    Age     int     `json:"age,omitempty"`
+//	    Address Address `json:"address"`
+//	}
+//	StructToMap(User{Name: "tom", Address: Address{City: "sh"}})
+//	// map[name:tom address:map[city:sh]]
+//	StructToMap(User{Name: "tom", Address: Address{City: "sh"}}, WithFlatten())
+//	// map[name:tom address.city:sh]
+func StructToMap(v any, opts ...StructOption) map[string]any {
+	o := NewStructOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	rv, ok := indirect(v)
+	if !ok || rv.Kind() != reflect.Struct {
+		return nil
+	}
+	m := make(map[string]any)
+	structToMap(rv, o, "", m, make(map[visitKey]bool))
+	return m
+}
+
+// structToMap path记录当前递归路径上的结构体,指针指回路径上的结构体时形成环,跳过该字段
+func structToMap(rv reflect.Value, o *StructOptions, prefix string, m map[string]any, path map[visitKey]bool) {
+	if rv.CanAddr() {
+		key := visitKey{ptr: rv.Addr().Pointer(), typ: rv.Type()}
+		path[key] = true
+		defer delete(path, key)
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, omitempty, ok := fieldName(field, o.Tag)
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if (o.OmitZero || omitempty) && fv.IsZero() {
+			continue
+		}
+		if field.Anonymous && !hasTagName(field, o.Tag) {
+			if ev, ok := indirect(fv); ok && isNestedStruct(ev.Type()) {
+				if !inPath(ev, path) {
+					structToMap(ev, o, prefix, m, path)
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		key := prefix + name
+		ev, ok := indirect(fv)
+		if !ok || !isNestedStruct(ev.Type()) {
+			m[key] = fv.Interface()
+			continue
+		}
+		if inPath(ev, path) {
+			continue
+		}
+		if o.Flatten {
+			structToMap(ev, o, key+".", m, path)
+			continue
+		}
+		nested := make(map[string]any)
+		structToMap(ev, o, "", nested, path)
+		m[key] = nested
+	}
+}
+
+// inPath 判断结构体是否在当前递归路径上
+func inPath(rv reflect.Value, path map[visitKey]bool) bool {
+	return rv.CanAddr() && path[visitKey{ptr: rv.Addr().Pointer(), typ: rv.Type()}]
+}
+
+// MapToStruct 根据标签将map中的值赋给结构体的字段,并进行类型转换
+//
+// 参数说明:
+//   - m: 字段名称到值的map
+//   - dst: 结构体指针
+//   - opts: 可选配置项,参见 StructOptions,OmitZero无效
+//
+// 返回值说明:
+//   - error: dst不是结构体指针时返回 ErrNotStructPointer,字段无法转换时返回 ErrConvert,并带有字段名称
+//
+// 注意事项:
+//   - 字段名称的规则同 StructToMap,名称完全一致的key优先,否则不区分大小写匹配
+//   - 类型转换参见 ConvertTo,如字符串转换为数字、浮点数转换为整数、时间字符串转换为time.Time
+//   - map中没有的字段保持原值,值为nil时设置为零值
+//   - 嵌套的结构体从map[string]any中递归赋值,结构体指针为nil时会创建
+//   - 比json序列化再反序列化更轻量,遇到第一个错误时返回,已赋值的字段不会回滚
+//
+// 示例:
+//
+//	var user User
+//	err := MapToStruct(map[string]any{
+//	    "name":    "tom",
+//	    "age":     "18",
+//	    "address": map[string]any{"city": "sh"},
+//	}, &user)
+func MapToStruct(m map[string]any, dst any, opts ...StructOption) error {
+	o := NewStructOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+	return mapToStruct(reflect.ValueOf(m), rv.Elem(), o, "")
+}
+
+func mapToStruct(m, dst reflect.Value, o *StructOptions, prefix string) error {
+	rt := dst.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, _, ok := fieldName(field, o.Tag)
+		if !ok {
+			continue
+		}
+		fv := dst.Field(i)
+		if field.Anonymous && !hasTagName(field, o.Tag) {
+			if target, ok := structTarget(fv); ok {
+				if err := mapToStruct(m, target, o, prefix); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		key := prefix + name
+		if o.Flatten && isNestedStruct(derefType(field.Type)) && hasKeyPrefix(m, key+".") {
+			target, _ := structTarget(fv)
+			if err := mapToStruct(m, target, o, key+"."); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := mapLookup(m, key)
+		if !ok {
+			continue
+		}
+		if nested, ok := indirect(value); ok && nested.Kind() == reflect.Map && nested.Type().Key().Kind() == reflect.String && isNestedStruct(derefType(field.Type)) {
+			target, _ := structTarget(fv)
+			if err := mapToStruct(nested, target, o, ""); err != nil {
+				return errors.WithMessagef(err, "field %s", key)
+			}
+			continue
+		}
+		if err := convertValue(value, fv); err != nil {
+			return errors.WithMessagef(err, "field %s", key)
+		}
+	}
+	return nil
+}
+
+// structTarget 返回结构体或结构体指针字段指向的结构体,指针为nil时会创建,未导出的nil指针返回false
+func structTarget(fv reflect.Value) (reflect.Value, bool) {
+	if fv.Kind() == reflect.Struct {
+		return fv, true
+	}
+	if fv.Kind() != reflect.Ptr || fv.Type().Elem().Kind() != reflect.Struct {
+		return fv, false
+	}
+	if fv.IsNil() {
+		if !fv.CanSet() {
+			return fv, false
+		}
+		fv.Set(reflect.New(fv.Type().Elem()))
+	}
+	return fv.Elem(), true
+}
+
+// mapLookup 查找key对应的值,没有完全一致的key时不区分大小写查找
+func mapLookup(m reflect.Value, key string) (reflect.Value, bool) {
+	k := reflect.ValueOf(key).Convert(m.Type().Key())
+	if v := m.MapIndex(k); v.IsValid() {
+		return v, true
+	}
+	iter := m.MapRange()
+	for iter.Next() {
+		if strings.EqualFold(iter.Key().String(), key) {
+			return iter.Value(), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// hasKeyPrefix map中是否存在以prefix开头的key,不区分大小写
+func hasKeyPrefix(m reflect.Value, prefix string) bool {
+	iter := m.MapRange()
+	for iter.Next() {
+		if k := iter.Key().String(); len(k) >= len(prefix) && strings.EqualFold(k[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// derefType 返回指针指向的类型
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// fieldName 返回字段在标签中的名称,以及是否带有omitempty
+// 未导出或标签为"-"的字段返回false,未导出的匿名嵌入结构体需要提升其导出的字段,返回true
+func fieldName(field reflect.StructField, tag string) (name string, omitempty bool, ok bool) {
+	if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+		return "", false, false
+	}
+	value := field.Tag.Get(tag)
+	if value == "-" {
+		return "", false, false
+	}
+	name, options, _ := strings.Cut(value, ",")
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, true
+}
+
+// hasTagName 字段的标签中是否指定了名称
+func hasTagName(field reflect.StructField, tag string) bool {
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	return name != ""
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// isNestedStruct 是否为需要递归处理的结构体,实现了序列化接口的结构体(如time.Time)视为普通值
+func isNestedStruct(rt reflect.Type) bool {
+	if rt.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PointerTo(rt)
+	return !pt.Implements(jsonMarshalerType) && !pt.Implements(textMarshalerType)
+}
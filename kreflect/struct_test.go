@@ -0,0 +1,167 @@
+package kreflect
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	City string `json:"city" db:"city_name"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type testBase struct {
+	ID int64 `json:"id"`
+}
+
+type testUser struct {
+	testBase
+	Name     string       `json:"name" db:"user_name"`
+	Age      int          `json:"age,omitempty"`
+	Password string       `json:"-"`
+	Address  testAddress  `json:"address"`
+	Backup   *testAddress `json:"backup"`
+	Created  time.Time    `json:"created"`
+	Tags     []string
+	secret   string
+}
+
+func TestStructToMap(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	user := testUser{
+		testBase: testBase{ID: 1},
+		Name:     "tom",
+		Password: "123",
+		Address:  testAddress{City: "sh"},
+		Created:  created,
+		secret:   "x",
+	}
+
+	tests := []struct {
+		name     string
+		input    any
+		opts     []StructOption
+		expected map[string]any
+	}{
+		{"default", user, nil, map[string]any{
+			"id":      int64(1),
+			"name":    "tom",
+			"address": map[string]any{"city": "sh"},
+			"backup":  (*testAddress)(nil),
+			"created": created,
+			"Tags":    []string(nil),
+		}},
+		{"pointer omit zero", &user, []StructOption{WithOmitZero()}, map[string]any{
+			"id":      int64(1),
+			"name":    "tom",
+			"address": map[string]any{"city": "sh"},
+			"created": created,
+		}},
+		{"flatten", user, []StructOption{WithFlatten(), WithOmitZero()}, map[string]any{
+			"id":           int64(1),
+			"name":         "tom",
+			"address.city": "sh",
+			"created":      created,
+		}},
+		{"custom tag", testAddress{City: "bj", Zip: "100"}, []StructOption{WithTag("db")}, map[string]any{
+			"city_name": "bj",
+			"Zip":       "100",
+		}},
+		{"not struct", 1, nil, nil},
+		{"nil", (*testUser)(nil), nil, nil},
+	}
+	for _, test := range tests {
+		if got := StructToMap(test.input, test.opts...); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: StructToMap() = %v, want %v", test.name, got, test.expected)
+		}
+	}
+
+	user.Backup = &testAddress{City: "gz", Zip: "510"}
+	got := StructToMap(user, WithFlatten())
+	if got["backup.city"] != "gz" || got["backup.zip"] != "510" {
+		t.Errorf("StructToMap() flatten pointer = %v", got)
+	}
+}
+
+type testNode struct {
+	Name string
+	Next *testNode
+}
+
+func TestStructToMapCycle(t *testing.T) {
+	n := &testNode{Name: "a"}
+	n.Next = n
+	want := map[string]any{"Name": "a"}
+	if got := StructToMap(n); !reflect.DeepEqual(got, want) {
+		t.Errorf("StructToMap(self cycle) = %v, want %v", got, want)
+	}
+
+	a := &testNode{Name: "a"}
+	b := &testNode{Name: "b", Next: a}
+	a.Next = b
+	want = map[string]any{"Name": "a", "Next": map[string]any{"Name": "b"}}
+	if got := StructToMap(a); !reflect.DeepEqual(got, want) {
+		t.Errorf("StructToMap(cycle) = %v, want %v", got, want)
+	}
+	want = map[string]any{"Name": "a", "Next.Name": "b"}
+	if got := StructToMap(a, WithFlatten()); !reflect.DeepEqual(got, want) {
+		t.Errorf("StructToMap(cycle, WithFlatten()) = %v, want %v", got, want)
+	}
+
+	// 共享但不形成环的指针正常转换
+	shared := &testAddress{City: "sh"}
+	type pair struct {
+		A *testAddress
+		B *testAddress
+	}
+	want = map[string]any{"A": map[string]any{"city": "sh"}, "B": map[string]any{"city": "sh"}}
+	if got := StructToMap(pair{A: shared, B: shared}); !reflect.DeepEqual(got, want) {
+		t.Errorf("StructToMap(shared) = %v, want %v", got, want)
+	}
+}
+
+func TestMapToStruct(t *testing.T) {
+	var user testUser
+	err := MapToStruct(map[string]any{
+		"id":      "7",
+		"NAME":    "tom",
+		"age":     18.0,
+		"address": map[string]any{"city": "sh", "zip": 200000},
+		"backup":  map[string]any{"city": "gz"},
+		"created": "2024-01-02 03:04:05",
+		"tags":    []any{"a", 1},
+		"secret":  "x",
+	}, &user)
+	if err != nil {
+		t.Fatalf("MapToStruct() error = %v", err)
+	}
+	expected := testUser{
+		testBase: testBase{ID: 7},
+		Name:     "tom",
+		Age:      18,
+		Address:  testAddress{City: "sh", Zip: "200000"},
+		Backup:   &testAddress{City: "gz"},
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local),
+		Tags:     []string{"a", "1"},
+	}
+	if !reflect.DeepEqual(user, expected) {
+		t.Errorf("MapToStruct() = %+v, want %+v", user, expected)
+	}
+
+	var flat testUser
+	err = MapToStruct(map[string]any{"user_name": "amy", "address.city_name": "bj", "backup.city_name": "cd"}, &flat, WithTag("db"), WithFlatten())
+	if err != nil || flat.Name != "amy" || flat.Address.City != "bj" || flat.Backup == nil || flat.Backup.City != "cd" {
+		t.Errorf("MapToStruct() flatten = %+v, %v", flat, err)
+	}
+
+	err = MapToStruct(map[string]any{"age": "old"}, &user)
+	if !errors.Is(err, ErrConvert) || !strings.Contains(err.Error(), "field age") {
+		t.Errorf("MapToStruct() error = %v, want ErrConvert with field name", err)
+	}
+	if err := MapToStruct(nil, user); !errors.Is(err, ErrNotStructPointer) {
+		t.Errorf("MapToStruct() error = %v, want ErrNotStructPointer", err)
+	}
+}
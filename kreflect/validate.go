@@ -0,0 +1,297 @@
+package kreflect
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidRule = errors.New("kreflect: invalid validate rule")
+)
+
+// validateTag 校验规则使用的标签
+const validateTag = "validate"
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field string // 字段路径,如 "Items[0].Name"
+	Rule  string // 未通过的规则,如 "min"
+	Param string // 规则的参数,如 "3"
+	Value any    // 字段的值
+}
+
+// Error 返回 "字段 failed on 规则=参数" 格式的字符串
+func (e *FieldError) Error() string {
+	if e.Param == "" {
+		return fmt.Sprintf("%s failed on %s", e.Field, e.Rule)
+	}
+	return fmt.Sprintf("%s failed on %s=%s", e.Field, e.Rule, e.Param)
+}
+
+// ValidationErrors 所有未通过校验的字段
+type ValidationErrors []*FieldError
+
+// Error 返回所有字段错误,以"; "分隔
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return "kreflect: validation failed: " + strings.Join(msgs, "; ")
+}
+
+// validateRule 解析后的一条规则
+type validateRule struct {
+	name  string
+	param string
+}
+
+var (
+	ruleCache   sync.Map // map[string][]validateRule
+	regexpCache sync.Map // map[string]*regexp.Regexp
+)
+
+// Validate 根据结构体标签中的规则校验字段
+//
+// 参数说明:
+//   - v: 结构体或结构体指针
+//
+// 返回值说明:
+//   - error: 全部通过时返回nil,否则返回 ValidationErrors,包含所有未通过的字段;
+//     规则格式错误时返回 ErrInvalidRule
+//
+// 注意事项:
+//   - 规则写在validate标签中,以","分隔,支持的规则:
+//   - required: 不能为零值,字符串、切片和map不能为空,指针不能为nil
+//   - min=n / max=n: 数字的大小,字符串的字符数,切片、数组和map的长度,time.Duration可以使用如 "1s" 的参数
+//   - len=n: 字符串的字符数,切片、数组和map的长度
+//   - oneof=a b c: 值必须是以空格分隔的其中一个
+//   - regexp=pattern: 字符串必须匹配正则表达式,由于正则中可能包含",",必须是最后一条规则
+//   - 指针为nil且没有required规则时跳过其他规则,否则校验指向的值
+//   - 嵌套的结构体、结构体切片的元素会递归校验
+//
+// 示例:
+//
+//	type CreateUser struct {
+//	    Name  string   `validate:"required,min=2,max=20"`
+//	    Role  string   `validate:"oneof=admin user"`
+//	    Email string   `validate:"regexp=^\\S+@\\S+$"`
+//	    Tags  []string `validate:"max=5"`
+//	}
+//	var verrs ValidationErrors
+//	if err := Validate(req); errors.As(err, &verrs) {
+//	    for _, fe := range verrs {
+//	        log.Println(fe.Field, fe.Rule)
+//	    }
+//	}
+func Validate(v any) error {
+	rv, ok := indirect(v)
+	if !ok || rv.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	var errs ValidationErrors
+	if err := validateStruct(rv, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateStruct(rv reflect.Value, prefix string, errs *ValidationErrors) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		path := prefix + field.Name
+		if field.Anonymous {
+			path = strings.TrimSuffix(prefix, ".")
+		}
+		rules, err := parseRules(field.Tag.Get(validateTag))
+		if err != nil {
+			return errors.WithMessagef(err, "field %s", field.Name)
+		}
+		if err := validateValue(rv.Field(i), path, rules, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateValue(fv reflect.Value, path string, rules []validateRule, errs *ValidationErrors) error {
+	for _, rule := range rules {
+		if rule.name == "required" && !checkRequired(fv) {
+			*errs = append(*errs, &FieldError{Field: path, Rule: rule.name, Value: valueInterface(fv)})
+			return nil
+		}
+	}
+	v, ok := indirect(fv)
+	if !ok {
+		return nil
+	}
+	for _, rule := range rules {
+		if rule.name == "required" {
+			continue
+		}
+		passed, err := checkRule(v, rule)
+		if err != nil {
+			return errors.WithMessagef(err, "field %s", path)
+		}
+		if !passed {
+			*errs = append(*errs, &FieldError{Field: path, Rule: rule.name, Param: rule.param, Value: valueInterface(v)})
+		}
+	}
+	switch {
+	case isNestedStruct(v.Type()):
+		return validateStruct(v, joinPath(path), errs)
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && isNestedStruct(derefType(v.Type().Elem())):
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), nil, errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// joinPath 返回嵌套字段路径的前缀
+func joinPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + "."
+}
+
+// parseRules 解析规则,结果会被缓存
+func parseRules(tag string) ([]validateRule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	if rules, ok := ruleCache.Load(tag); ok {
+		return rules.([]validateRule), nil
+	}
+	var rules []validateRule
+	rest := tag
+	for rest != "" {
+		var part string
+		if strings.HasPrefix(rest, "regexp=") {
+			part, rest = rest, ""
+		} else {
+			part, rest, _ = strings.Cut(rest, ",")
+		}
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch name {
+		case "required":
+		case "min", "max", "len", "oneof":
+			if param == "" {
+				return nil, errors.Wrapf(ErrInvalidRule, "%q: missing parameter", part)
+			}
+		case "regexp":
+			if _, err := compileRegexp(param); err != nil {
+				return nil, errors.Wrapf(ErrInvalidRule, "%q: %v", part, err)
+			}
+		case "":
+			continue
+		default:
+			return nil, errors.Wrapf(ErrInvalidRule, "%q: unknown rule", part)
+		}
+		rules = append(rules, validateRule{name: name, param: param})
+	}
+	ruleCache.Store(tag, rules)
+	return rules, nil
+}
+
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexpCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexpCache.Store(pattern, re)
+	return re, nil
+}
+
+// checkRequired 指针不能为nil,指向的值或其他值不能为空
+func checkRequired(v reflect.Value) bool {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		return !v.IsNil()
+	}
+	return !IsEmpty(v)
+}
+
+func checkRule(v reflect.Value, rule validateRule) (bool, error) {
+	switch rule.name {
+	case "min", "max", "len":
+		return checkSize(v, rule)
+	case "oneof":
+		s := ToString(valueInterface(v))
+		for _, option := range strings.Fields(rule.param) {
+			if s == option {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "regexp":
+		if v.Kind() != reflect.String {
+			return false, errors.Wrapf(ErrInvalidRule, "regexp on %s", v.Type())
+		}
+		re, err := compileRegexp(rule.param)
+		if err != nil {
+			return false, errors.Wrapf(ErrInvalidRule, "%v", err)
+		}
+		return re.MatchString(v.String()), nil
+	}
+	return true, nil
+}
+
+// checkSize 校验min、max和len规则
+func checkSize(v reflect.Value, rule validateRule) (bool, error) {
+	var size, limit float64
+	switch v.Kind() {
+	case reflect.String:
+		size = float64(utf8.RuneCountInString(v.String()))
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		size = float64(v.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if rule.name == "len" {
+			return false, errors.Wrapf(ErrInvalidRule, "len on %s", v.Type())
+		}
+		size, _ = ToFloat64(v)
+	default:
+		return false, errors.Wrapf(ErrInvalidRule, "%s on %s", rule.name, v.Type())
+	}
+	if v.Type() == durationType {
+		d, err := ToDuration(rule.param)
+		if err != nil {
+			return false, errors.Wrapf(ErrInvalidRule, "%s=%s", rule.name, rule.param)
+		}
+		limit = float64(d)
+	} else {
+		f, err := ToFloat64(rule.param)
+		if err != nil {
+			return false, errors.Wrapf(ErrInvalidRule, "%s=%s", rule.name, rule.param)
+		}
+		limit = f
+	}
+	switch rule.name {
+	case "min":
+		return size >= limit, nil
+	case "max":
+		return size <= limit, nil
+	default:
+		return size == limit, nil
+	}
+}
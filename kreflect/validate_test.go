@@ -0,0 +1,102 @@
+package kreflect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type validateItem struct {
+	SKU string `validate:"required,len=4"`
+}
+
+type validateBase struct {
+	Tenant string `validate:"required"`
+}
+
+type validateRequest struct {
+	validateBase
+	Name    string         `validate:"required,min=2,max=5"`
+	Age     int            `validate:"min=18,max=60"`
+	Role    string         `validate:"oneof=admin user"`
+	Email   string         `validate:"regexp=^[a-z]+@[a-z]+\\.(com|cn)$"`
+	Tags    []string       `validate:"max=2"`
+	Nick    *string        `validate:"min=2"`
+	Owner   *testAddress   `validate:"required"`
+	Timeout time.Duration  `validate:"min=1s,max=1m"`
+	Items   []validateItem `validate:"required"`
+	Address testAddress
+	skipped string `validate:"required"`
+}
+
+func TestValidate(t *testing.T) {
+	valid := validateRequest{
+		validateBase: validateBase{Tenant: "t1"},
+		Name:         "tom",
+		Age:          20,
+		Role:         "admin",
+		Email:        "tom@example.com",
+		Owner:        &testAddress{},
+		Timeout:      time.Second,
+		Items:        []validateItem{{SKU: "A001"}},
+	}
+	if err := Validate(&valid); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	nick := "x"
+	invalid := validateRequest{
+		Name:    "名字太长了啊",
+		Age:     10,
+		Role:    "guest",
+		Email:   "TOM@example.com",
+		Tags:    []string{"a", "b", "c"},
+		Nick:    &nick,
+		Timeout: time.Hour,
+		Items:   []validateItem{{SKU: "A001"}, {SKU: "B1"}},
+	}
+	err := Validate(invalid)
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+	var got []string
+	for _, fe := range verrs {
+		got = append(got, fe.Error())
+	}
+	expected := []string{
+		"Tenant failed on required",
+		"Name failed on max=5",
+		"Age failed on min=18",
+		"Role failed on oneof=admin user",
+		"Email failed on regexp=^[a-z]+@[a-z]+\\.(com|cn)$",
+		"Tags failed on max=2",
+		"Nick failed on min=2",
+		"Owner failed on required",
+		"Timeout failed on max=1m",
+		"Items[1].SKU failed on len=4",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Validate() = %q, want %q", got, expected)
+	}
+	if verrs[2].Value != 10 {
+		t.Errorf("FieldError.Value = %v, want 10", verrs[2].Value)
+	}
+
+	type badRule struct {
+		A string `validate:"unknown"`
+	}
+	if err := Validate(badRule{}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Validate() error = %v, want ErrInvalidRule", err)
+	}
+	type badParam struct {
+		A bool `validate:"min=1"`
+	}
+	if err := Validate(badParam{}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Validate() error = %v, want ErrInvalidRule", err)
+	}
+	if err := Validate(1); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("Validate() error = %v, want ErrNotStruct", err)
+	}
+}
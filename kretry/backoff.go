@@ -9,9 +9,21 @@ import (
 
 const maxInt64 = float64(math.MaxInt64 - 512)
 
+// JitterMode 随机抖动的方式
+type JitterMode int
+
+const (
+	JitterNone         JitterMode = iota // 不抖动
+	JitterUniform                        // 在min和退避时间之间均匀随机, WithJitter(true) 使用该方式
+	JitterFull                           // 全抖动,在0和退避时间之间均匀随机,可能小于min
+	JitterEqual                          // 等抖动,一半为退避时间的一半,另一半在0和退避时间的一半之间随机
+	JitterDecorrelated                   // 去相关抖动,在min和上一次退避时间的3倍之间随机,不依赖尝试次数
+)
+
 // Backoff 实现指数退避算法
 type Backoff struct {
 	attempt atomic.Uint64 // 当前尝试次数
+	last    atomic.Int64  // 上一次的退避时间,用于去相关抖动
 	opts    *BackOffOptions
 }
 
@@ -26,11 +38,13 @@ type Backoff struct {
 // 注意事项:
 //   - 默认参数: factor=2, jitter=false, min=100ms, max=10s
 //   - 默认参数下的回退时间为: 10次序列为100ms 200ms 400ms 800ms 1.6s 3.2s 6.4s 10s 10s 10s
-//   - 可以通过WithFactor, WithJitter, WithMin, WithMax等函数自定义参数
+//   - 可以通过WithFactor, WithJitter, WithJitterMode, WithMin, WithMax等函数自定义参数
+//   - 大量实例同时重试时,使用 JitterFull 或 JitterDecorrelated 可以更好地分散重试的时间
 //
 // 示例:
 //
 //	b := NewBackoff(WithFactor(1.5), WithJitter(true))
+//	b = NewBackoff(WithJitterMode(JitterFull))
 func NewBackoff(opts ...BackoffOption) *Backoff {
 	options := NewBackOffOptions()
 	for _, opt := range opts {
@@ -55,8 +69,13 @@ func NewBackoff(opts ...BackoffOption) *Backoff {
 //
 //	d := b.Duration() // 获取当前退避时间
 func (b *Backoff) Duration() time.Duration {
-	d := b.ForAttempt(float64(b.attempt.Add(1) - 1))
-	return d
+	attempt := float64(b.attempt.Add(1) - 1)
+	if b.opts.jitterMode == JitterDecorrelated {
+		d := b.decorrelated(time.Duration(b.last.Load()))
+		b.last.Store(int64(d))
+		return d
+	}
+	return b.ForAttempt(attempt)
 }
 
 // ForAttempt 根据尝试次数计算退避时间
@@ -70,19 +89,13 @@ func (b *Backoff) Duration() time.Duration {
 // 注意事项:
 //   - 如果启用了jitter，返回的时间会有随机波动
 //   - 返回的时间不会超过maxInt64
+//   - 去相关抖动时,以上一次尝试不抖动的退避时间作为上一次的退避时间
 //
 // 示例:
 //
 //	d := b.ForAttempt(3) // 计算第3次尝试的退避时间
 func (b *Backoff) ForAttempt(attempt float64) time.Duration {
-	min := b.opts.min
-	if min <= 0 {
-		min = 100 * time.Millisecond
-	}
-	max := b.opts.max
-	if max <= 0 {
-		max = 10 * time.Second
-	}
+	min, max := b.bounds()
 	if min >= max {
 		return max
 	}
@@ -92,9 +105,26 @@ func (b *Backoff) ForAttempt(attempt float64) time.Duration {
 	}
 	minTime := float64(min)
 	duration := minTime * math.Pow(factor, attempt)
-	if b.opts.jitter {
+	switch b.opts.jitterMode {
+	case JitterUniform:
 		duration = rand.Float64()*(duration-minTime) + minTime
+	case JitterFull:
+		// 先限制最大值再抖动,使结果在0和max之间均匀分布
+		return time.Duration(rand.Float64() * math.Min(duration, float64(max)))
+	case JitterEqual:
+		half := math.Min(duration, float64(max)) / 2
+		duration = half + rand.Float64()*half
+	case JitterDecorrelated:
+		if attempt == 0 {
+			return b.decorrelated(0)
+		}
+		return b.decorrelated(b.clamp(minTime*math.Pow(factor, attempt-1), min, max))
 	}
+	return b.clamp(duration, min, max)
+}
+
+// clamp 将退避时间限制在min和max之间
+func (b *Backoff) clamp(duration float64, min, max time.Duration) time.Duration {
 	if duration > maxInt64 {
 		return max
 	}
@@ -109,12 +139,41 @@ func (b *Backoff) ForAttempt(attempt float64) time.Duration {
 	return dur
 }
 
+// decorrelated 根据上一次的退避时间计算去相关抖动的退避时间
+// 参数:
+//   - prev: 上一次的退避时间,第一次时为0
+func (b *Backoff) decorrelated(prev time.Duration) time.Duration {
+	min, max := b.bounds()
+	if min >= max {
+		return max
+	}
+	upper := math.Min(float64(prev)*3, float64(max))
+	if upper <= float64(min) {
+		return min
+	}
+	return b.clamp(float64(min)+rand.Float64()*(upper-float64(min)), min, max)
+}
+
+// bounds 返回最小和最大退避时间,未设置时使用默认值
+func (b *Backoff) bounds() (min, max time.Duration) {
+	min = b.opts.min
+	if min <= 0 {
+		min = 100 * time.Millisecond
+	}
+	max = b.opts.max
+	if max <= 0 {
+		max = 10 * time.Second
+	}
+	return min, max
+}
+
 // Reset 重置尝试次数
 //
 // 注意事项:
 //   - 重置后下次调用Duration将从第一次尝试开始计算
 func (b *Backoff) Reset() {
 	b.attempt.Store(0)
+	b.last.Store(0)
 }
 
 // Attempt 获取当前尝试次数
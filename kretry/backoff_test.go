@@ -0,0 +1,74 @@
+package kretry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffJitterMode(t *testing.T) {
+	const (
+		min = 100 * time.Millisecond
+		max = 2 * time.Second
+	)
+
+	t.Run("full jitter", func(t *testing.T) {
+		b := NewBackoff(WithMin(min), WithMax(max), WithJitterMode(JitterFull))
+		var belowMin bool
+		for i := 0; i < 1000; i++ {
+			d := b.ForAttempt(3) // 不抖动时为800ms
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, 800*time.Millisecond)
+			belowMin = belowMin || d < min
+		}
+		assert.True(t, belowMin, "全抖动可能小于min")
+		for i := 0; i < 100; i++ {
+			assert.LessOrEqual(t, b.ForAttempt(20), max)
+		}
+	})
+
+	t.Run("equal jitter", func(t *testing.T) {
+		b := NewBackoff(WithMin(min), WithMax(max), WithJitterMode(JitterEqual))
+		for i := 0; i < 1000; i++ {
+			d := b.ForAttempt(3)
+			assert.GreaterOrEqual(t, d, 400*time.Millisecond)
+			assert.LessOrEqual(t, d, 800*time.Millisecond)
+			d = b.ForAttempt(20)
+			assert.GreaterOrEqual(t, d, max/2)
+			assert.LessOrEqual(t, d, max)
+		}
+	})
+
+	t.Run("decorrelated jitter", func(t *testing.T) {
+		b := NewBackoff(WithMin(min), WithMax(max), WithJitterMode(JitterDecorrelated))
+		assert.Equal(t, min, b.Duration(), "第一次为min")
+		prev := min
+		for i := 0; i < 100; i++ {
+			d := b.Duration()
+			assert.GreaterOrEqual(t, d, min)
+			assert.LessOrEqual(t, d, prev*3)
+			assert.LessOrEqual(t, d, max)
+			prev = d
+		}
+		b.Reset()
+		assert.Equal(t, min, b.Duration(), "重置后从min开始")
+
+		for i := 0; i < 100; i++ {
+			d := b.ForAttempt(3)
+			assert.GreaterOrEqual(t, d, min)
+			assert.LessOrEqual(t, d, 3*400*time.Millisecond)
+		}
+	})
+
+	t.Run("uniform jitter", func(t *testing.T) {
+		b := NewBackoff(WithMin(min), WithMax(max), WithJitter(true))
+		for i := 0; i < 1000; i++ {
+			d := b.ForAttempt(3)
+			assert.GreaterOrEqual(t, d, min)
+			assert.LessOrEqual(t, d, 800*time.Millisecond)
+		}
+		b = NewBackoff(WithMin(min), WithMax(max), WithJitterMode(JitterFull), WithJitter(false))
+		assert.Equal(t, 800*time.Millisecond, b.ForAttempt(3))
+	})
+}
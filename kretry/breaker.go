@@ -0,0 +1,226 @@
+package kretry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mtgnorton/k/kcollection"
+	"github.com/mtgnorton/k/kmonitor"
+	"github.com/mtgnorton/k/ktime"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrBreakerOpen = errors.New("retry: circuit breaker is open")
+)
+
+// BreakerState 熔断器的状态
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // 关闭,正常放行请求
+	BreakerOpen                         // 打开,拒绝所有请求
+	BreakerHalfOpen                     // 半开,放行少量探测请求,根据探测结果关闭或重新打开
+)
+
+// String 返回熔断器状态的名称
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerOptions 熔断器的配置项
+type BreakerOptions struct {
+	ErrorRate        float64       // 错误率阈值,窗口内错误率超过该值时打开熔断器
+	MinRequests      int64         // 最少请求数,窗口内请求数不足时不打开熔断器
+	OpenTimeout      time.Duration // 打开后经过该时间进入半开状态
+	HalfOpenRequests int           // 半开状态下同时放行的探测请求数
+	WindowSize       int           // 统计窗口的桶数量
+	WindowInterval   time.Duration // 统计窗口每个桶的时间间隔
+	Clock            ktime.Clock   // 计算打开时间和统计窗口使用的时钟
+}
+
+type BreakerOption func(*BreakerOptions)
+
+func NewBreakerOptions() *BreakerOptions {
+	return &BreakerOptions{
+		ErrorRate:        0.5,
+		MinRequests:      20,
+		OpenTimeout:      5 * time.Second,
+		HalfOpenRequests: 1,
+		WindowSize:       10,
+		WindowInterval:   time.Second,
+		Clock:            ktime.RealClock,
+	}
+}
+
+// WithBreakerErrorRate 设置打开熔断器的错误率阈值,取值0~1
+func WithBreakerErrorRate(rate float64) BreakerOption {
+	return func(o *BreakerOptions) {
+		o.ErrorRate = rate
+	}
+}
+
+// WithBreakerMinRequests 设置打开熔断器所需的最少请求数
+func WithBreakerMinRequests(n int64) BreakerOption {
+	return func(o *BreakerOptions) {
+		o.MinRequests = n
+	}
+}
+
+// WithBreakerOpenTimeout 设置熔断器打开后进入半开状态的时间
+func WithBreakerOpenTimeout(d time.Duration) BreakerOption {
+	return func(o *BreakerOptions) {
+		o.OpenTimeout = d
+	}
+}
+
+// WithBreakerHalfOpenRequests 设置半开状态下同时放行的探测请求数
+func WithBreakerHalfOpenRequests(n int) BreakerOption {
+	return func(o *BreakerOptions) {
+		o.HalfOpenRequests = n
+	}
+}
+
+// WithBreakerWindow 设置统计窗口的桶数量和每个桶的时间间隔
+func WithBreakerWindow(size int, interval time.Duration) BreakerOption {
+	return func(o *BreakerOptions) {
+		o.WindowSize = size
+		o.WindowInterval = interval
+	}
+}
+
+// WithBreakerClock 设置计算打开时间和统计窗口使用的时钟,测试时可以使用 ktime.FakeClock
+func WithBreakerClock(clock ktime.Clock) BreakerOption {
+	return func(o *BreakerOptions) {
+		o.Clock = clock
+	}
+}
+
+// Breaker 熔断器,基于 kmonitor.RollingResultCounter 统计窗口内的错误率
+// 错误率超过阈值时打开,拒绝所有请求;经过 OpenTimeout 后进入半开状态放行探测请求,探测成功则关闭,失败则重新打开
+type Breaker struct {
+	mu       sync.Mutex
+	opts     *BreakerOptions
+	counter  *kmonitor.RollingResultCounter[int64]
+	state    BreakerState
+	openedAt time.Time
+	probing  int // 半开状态下正在进行的探测请求数
+}
+
+// NewBreaker 创建一个熔断器
+//
+// 参数说明:
+//   - opts: 可选配置项,默认最近10秒内至少20个请求且错误率超过50%时打开,5秒后进入半开状态
+//
+// 注意事项:
+//   - 线程安全,可以在多个重试器之间共享,参见 WithBreaker
+//   - 也可以单独使用:调用 Allow 判断是否放行,再调用 Success 或 Failure 记录结果
+//
+// 示例:
+//
+//	breaker := NewBreaker(WithBreakerErrorRate(0.3), WithBreakerOpenTimeout(10*time.Second))
+//	result, err := Do(exec, WithBreaker(breaker))
+//	if errors.Is(err, ErrBreakerOpen) {
+//	    // 快速失败,使用降级逻辑
+//	}
+func NewBreaker(opts ...BreakerOption) *Breaker {
+	o := NewBreakerOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	b := &Breaker{opts: o}
+	b.counter = b.newCounter()
+	return b
+}
+
+func (b *Breaker) newCounter() *kmonitor.RollingResultCounter[int64] {
+	return kmonitor.NewRollingResultCounter(
+		kcollection.WithSize[int64, *kcollection.Bucket[int64]](b.opts.WindowSize),
+		kcollection.WithInterval[int64, *kcollection.Bucket[int64]](b.opts.WindowInterval),
+		kcollection.WithClock[int64, *kcollection.Bucket[int64]](b.opts.Clock))
+}
+
+// State 返回熔断器当前的状态
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tryHalfOpen()
+	return b.state
+}
+
+// Allow 判断是否放行请求
+// 返回:
+//   - error: 熔断器打开,或半开状态下探测请求已满时返回 ErrBreakerOpen
+//
+// 注意:
+//   - 放行后必须调用 Success 或 Failure 记录结果
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tryHalfOpen()
+	switch b.state {
+	case BreakerOpen:
+		return ErrBreakerOpen
+	case BreakerHalfOpen:
+		if b.probing >= b.opts.HalfOpenRequests {
+			return ErrBreakerOpen
+		}
+		b.probing++
+	}
+	return nil
+}
+
+// Success 记录一次成功的请求
+// 参数:
+//   - elapsed: 请求的耗时
+func (b *Breaker) Success(elapsed time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerHalfOpen {
+		// 探测成功,关闭熔断器并重新统计
+		b.state = BreakerClosed
+		b.probing = 0
+		b.counter = b.newCounter()
+	}
+	b.counter.AddSuccess(elapsed.Milliseconds())
+}
+
+// Failure 记录一次失败的请求
+// 参数:
+//   - elapsed: 请求的耗时
+func (b *Breaker) Failure(elapsed time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.counter.AddFail(elapsed.Milliseconds())
+	switch b.state {
+	case BreakerHalfOpen:
+		b.open()
+	case BreakerClosed:
+		if rate, samples := b.counter.ErrorRate(); samples >= b.opts.MinRequests && rate > b.opts.ErrorRate {
+			b.open()
+		}
+	}
+}
+
+func (b *Breaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = b.opts.Clock.Now()
+	b.probing = 0
+}
+
+// tryHalfOpen 打开时间超过 OpenTimeout 时进入半开状态
+func (b *Breaker) tryHalfOpen() {
+	if b.state == BreakerOpen && b.opts.Clock.Since(b.openedAt) >= b.opts.OpenTimeout {
+		b.state = BreakerHalfOpen
+		b.probing = 0
+	}
+}
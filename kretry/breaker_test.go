@@ -0,0 +1,67 @@
+package kretry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Run("state transition", func(t *testing.T) {
+		b := NewBreaker(WithBreakerMinRequests(4), WithBreakerErrorRate(0.5),
+			WithBreakerOpenTimeout(50*time.Millisecond), WithBreakerHalfOpenRequests(1))
+		assert.Equal(t, BreakerClosed, b.State())
+
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, b.Allow())
+			b.Success(time.Millisecond)
+		}
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, b.Allow())
+			b.Failure(time.Millisecond)
+		}
+		assert.Equal(t, BreakerClosed, b.State(), "错误率等于阈值不打开")
+
+		assert.NoError(t, b.Allow())
+		b.Failure(time.Millisecond)
+		assert.Equal(t, BreakerOpen, b.State())
+		assert.ErrorIs(t, b.Allow(), ErrBreakerOpen)
+
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(t, BreakerHalfOpen, b.State())
+		assert.NoError(t, b.Allow())
+		assert.ErrorIs(t, b.Allow(), ErrBreakerOpen, "探测请求已满")
+		b.Failure(time.Millisecond)
+		assert.Equal(t, BreakerOpen, b.State(), "探测失败重新打开")
+
+		time.Sleep(60 * time.Millisecond)
+		assert.NoError(t, b.Allow())
+		b.Success(time.Millisecond)
+		assert.Equal(t, BreakerClosed, b.State(), "探测成功关闭")
+		assert.Equal(t, "closed", b.State().String())
+	})
+
+	t.Run("with retry", func(t *testing.T) {
+		b := NewBreaker(WithBreakerMinRequests(2), WithBreakerErrorRate(0.5), WithBreakerOpenTimeout(time.Minute))
+		var attempt int
+		_, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			return "", errors.New("error")
+		}, WithBreaker(b), WithTimes(5), WithCustomDelay(make([]time.Duration, 5)))
+		assert.ErrorIs(t, err, ErrBreakerOpen)
+		assert.Equal(t, 2, attempt, "熔断器打开后停止重试")
+
+		// 共享同一个熔断器的重试器快速失败
+		var attempts int
+		_, err = Do(func(ctx context.Context) (string, error) {
+			return "ok", nil
+		}, WithBreaker(b), WithFinishHandler(func(n int, err error, elapsed time.Duration) {
+			attempts = n
+		}))
+		assert.ErrorIs(t, err, ErrBreakerOpen)
+		assert.Equal(t, 0, attempts)
+	})
+}
@@ -0,0 +1,131 @@
+package kretry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mtgnorton/k/kcollection"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrRetryBudgetExhausted = errors.New("retry: retry budget exhausted")
+)
+
+// RetryBudgetOptions 重试预算的配置项
+type RetryBudgetOptions struct {
+	Ratio          float64       // 重试次数占请求次数的最大比例
+	MinRetries     int64         // 窗口内至少允许的重试次数,避免请求较少时无法重试
+	WindowSize     int           // 统计窗口的桶数量
+	WindowInterval time.Duration // 统计窗口每个桶的时间间隔
+}
+
+type RetryBudgetOption func(*RetryBudgetOptions)
+
+func NewRetryBudgetOptions() *RetryBudgetOptions {
+	return &RetryBudgetOptions{
+		Ratio:          0.1,
+		MinRetries:     10,
+		WindowSize:     10,
+		WindowInterval: time.Second,
+	}
+}
+
+// WithBudgetRatio 设置重试次数占请求次数的最大比例
+func WithBudgetRatio(ratio float64) RetryBudgetOption {
+	return func(o *RetryBudgetOptions) {
+		o.Ratio = ratio
+	}
+}
+
+// WithBudgetMinRetries 设置窗口内至少允许的重试次数
+func WithBudgetMinRetries(n int64) RetryBudgetOption {
+	return func(o *RetryBudgetOptions) {
+		o.MinRetries = n
+	}
+}
+
+// WithBudgetWindow 设置统计窗口的桶数量和每个桶的时间间隔
+func WithBudgetWindow(size int, interval time.Duration) RetryBudgetOption {
+	return func(o *RetryBudgetOptions) {
+		o.WindowSize = size
+		o.WindowInterval = interval
+	}
+}
+
+type countWindow = kcollection.RollingWindow[int64, *kcollection.Bucket[int64]]
+
+// RetryBudget 重试预算,限制窗口内的重试次数不超过 MinRetries + Ratio*请求次数
+// 多个重试器共享同一个预算时,下游故障不会使整体流量放大到重试次数倍
+type RetryBudget struct {
+	mu       sync.Mutex
+	opts     *RetryBudgetOptions
+	requests *countWindow
+	retries  *countWindow
+}
+
+// NewRetryBudget 创建一个重试预算
+//
+// 参数说明:
+//   - opts: 可选配置项,默认最近10秒内重试次数不超过10+请求次数的10%
+//
+// 注意事项:
+//   - 线程安全,可以在多个重试器之间共享,参见 WithBudget
+//
+// 示例:
+//
+//	budget := NewRetryBudget(WithBudgetRatio(0.2))
+//	result, err := Do(exec, WithBudget(budget))
+//	if errors.Is(err, ErrRetryBudgetExhausted) {
+//	    // 预算耗尽,不再重试
+//	}
+func NewRetryBudget(opts ...RetryBudgetOption) *RetryBudget {
+	o := NewRetryBudgetOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	newWindow := func() *countWindow {
+		return kcollection.NewRollingWindow(func() *kcollection.Bucket[int64] {
+			return &kcollection.Bucket[int64]{}
+		}, kcollection.WithSize[int64, *kcollection.Bucket[int64]](o.WindowSize),
+			kcollection.WithInterval[int64, *kcollection.Bucket[int64]](o.WindowInterval))
+	}
+	return &RetryBudget{
+		opts:     o,
+		requests: newWindow(),
+		retries:  newWindow(),
+	}
+}
+
+// Request 记录一次请求,重试不计入请求
+func (b *RetryBudget) Request() {
+	b.requests.Add(1)
+}
+
+// TryRetry 尝试消耗一次重试的额度
+// 返回:
+//   - bool: 是否允许重试,允许时会记录一次重试
+func (b *RetryBudget) TryRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.Available() < 1 {
+		return false
+	}
+	b.retries.Add(1)
+	return true
+}
+
+// Available 返回窗口内剩余的重试次数
+func (b *RetryBudget) Available() int64 {
+	requests, retries := windowTotal(b.requests), windowTotal(b.retries)
+	return b.opts.MinRetries + int64(b.opts.Ratio*float64(requests)) - retries
+}
+
+// windowTotal 返回窗口内所有值的和
+func windowTotal(w *countWindow) int64 {
+	var total int64
+	w.Reduce(func(b *kcollection.Bucket[int64]) {
+		total += b.Sum
+	})
+	return total
+}
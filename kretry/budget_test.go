@@ -0,0 +1,51 @@
+package kretry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBudget(t *testing.T) {
+	t.Run("ratio and min retries", func(t *testing.T) {
+		b := NewRetryBudget(WithBudgetRatio(0.5), WithBudgetMinRetries(1))
+		assert.Equal(t, int64(1), b.Available())
+		for i := 0; i < 4; i++ {
+			b.Request()
+		}
+		assert.Equal(t, int64(3), b.Available())
+		for i := 0; i < 3; i++ {
+			assert.True(t, b.TryRetry())
+		}
+		assert.False(t, b.TryRetry())
+		assert.Equal(t, int64(0), b.Available())
+	})
+
+	t.Run("window expiry", func(t *testing.T) {
+		b := NewRetryBudget(WithBudgetRatio(0), WithBudgetMinRetries(1), WithBudgetWindow(2, 50*time.Millisecond))
+		assert.True(t, b.TryRetry())
+		assert.False(t, b.TryRetry())
+		time.Sleep(120 * time.Millisecond)
+		assert.True(t, b.TryRetry(), "过期的重试不再计入")
+	})
+
+	t.Run("shared by retries", func(t *testing.T) {
+		b := NewRetryBudget(WithBudgetRatio(0), WithBudgetMinRetries(3))
+		var attempts int
+		exec := func(ctx context.Context) (string, error) {
+			attempts++
+			return "", errors.New("error")
+		}
+		_, err := Do(exec, WithBudget(b), WithTimes(3), WithCustomDelay(make([]time.Duration, 3)))
+		assert.NotErrorIs(t, err, ErrRetryBudgetExhausted)
+		assert.Equal(t, 3, attempts, "最后一次失败后不消耗预算")
+
+		attempts = 0
+		_, err = Do(exec, WithBudget(b), WithTimes(3), WithCustomDelay(make([]time.Duration, 3)))
+		assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+		assert.Equal(t, 2, attempts)
+	})
+}
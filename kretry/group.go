@@ -0,0 +1,135 @@
+package kretry
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+// GroupResult 组中单个操作的结果
+type GroupResult struct {
+	Value any   // 执行成功时的结果
+	Err   error // 执行失败时的错误
+}
+
+// GroupResults 组中所有操作的结果,key为操作名称
+type GroupResults map[string]GroupResult
+
+// Err 返回所有失败操作的错误,按名称排序并以名称作为前缀,全部成功时返回nil
+func (r GroupResults) Err() error {
+	names := make([]string, 0, len(r))
+	for name, result := range r {
+		if result.Err != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	errs := make([]error, len(names))
+	for i, name := range names {
+		errs[i] = &groupError{name: name, err: r[name].Err}
+	}
+	return errors.Join(errs...)
+}
+
+type groupError struct {
+	name string
+	err  error
+}
+
+func (e *groupError) Error() string {
+	return e.name + ": " + e.err.Error()
+}
+
+func (e *groupError) Unwrap() error {
+	return e.err
+}
+
+// Group 一组独立重试的操作,共享同一份重试配置
+type Group struct {
+	mu    sync.Mutex
+	opts  []Option
+	execs map[string]ExecFunc[any]
+}
+
+// NewGroup 创建一组独立重试的操作
+//
+// 参数说明:
+//   - opts: 所有操作共享的重试配置,参见 Options
+//
+// 注意事项:
+//   - 每个操作独立重试,一个操作失败不影响其他操作
+//   - 每个操作使用 Backoff 的副本,互不影响;自定义的 BackoffStrategy 有状态时会被所有操作共享
+//
+// 举例:
+//
+//	g := NewGroup(WithContext(ctx), WithTimes(5))
+//	GroupAdd(g, "mysql", func(ctx context.Context) (*sql.DB, error) {
+//	    return connectMySQL(ctx)
+//	})
+//	GroupAdd(g, "redis", func(ctx context.Context) (*redis.Client, error) {
+//	    return connectRedis(ctx)
+//	})
+//	results := g.Run()
+//	if err := results.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+//	db := results["mysql"].Value.(*sql.DB)
+func NewGroup(opts ...Option) *Group {
+	return &Group{
+		opts:  opts,
+		execs: make(map[string]ExecFunc[any]),
+	}
+}
+
+// Add 添加一个操作,同名操作会被覆盖
+func (g *Group) Add(name string, exec ExecFunc[any]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.execs[name] = exec
+}
+
+// GroupAdd 向组中添加一个返回T类型结果的操作,同名操作会被覆盖
+func GroupAdd[T any](g *Group, name string, exec ExecFunc[T]) {
+	g.Add(name, func(ctx context.Context) (any, error) {
+		return exec(ctx)
+	})
+}
+
+// Run 并发执行所有操作,每个操作独立重试,等待所有操作结束后返回结果
+func (g *Group) Run() GroupResults {
+	g.mu.Lock()
+	execs := make(map[string]ExecFunc[any], len(g.execs))
+	for name, exec := range g.execs {
+		execs[name] = exec
+	}
+	g.mu.Unlock()
+
+	// 每个操作使用独立的 Backoff,避免共享尝试次数
+	opts := append(g.opts[:len(g.opts):len(g.opts)], func(o *Options) {
+		if o.Backoff != nil {
+			o.Backoff = o.Backoff.Copy()
+		}
+	})
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(GroupResults, len(execs))
+	)
+	for name, exec := range execs {
+		wg.Add(1)
+		go func(name string, exec ExecFunc[any]) {
+			defer wg.Done()
+			value, err := Do(exec, opts...)
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = GroupResult{Value: value, Err: err}
+		}(name, exec)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,50 @@
+package kretry
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	backoff := NewBackoff(WithMin(time.Millisecond), WithMax(5*time.Millisecond))
+	g := NewGroup(WithTimes(3), WithBackoff(backoff))
+
+	var flakyAttempts atomic.Int32
+	GroupAdd(g, "flaky", func(ctx context.Context) (int, error) {
+		if flakyAttempts.Add(1) < 3 {
+			return 0, errors.New("not ready")
+		}
+		return 42, nil
+	})
+	GroupAdd(g, "ok", func(ctx context.Context) (string, error) {
+		return "ready", nil
+	})
+	var brokenAttempts atomic.Int32
+	g.Add("broken", func(ctx context.Context) (any, error) {
+		brokenAttempts.Add(1)
+		return nil, io.ErrUnexpectedEOF
+	})
+
+	results := g.Run()
+	assert.Len(t, results, 3)
+	assert.Equal(t, 42, results["flaky"].Value)
+	assert.NoError(t, results["flaky"].Err)
+	assert.Equal(t, "ready", results["ok"].Value)
+	assert.ErrorIs(t, results["broken"].Err, io.ErrUnexpectedEOF)
+	assert.Equal(t, int32(3), flakyAttempts.Load(), "每个操作独立重试")
+	assert.Equal(t, int32(3), brokenAttempts.Load())
+	assert.Equal(t, float64(0), backoff.Attempt(), "使用Backoff的副本")
+
+	err := results.Err()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Contains(t, err.Error(), "broken: unexpected EOF")
+	assert.NotContains(t, err.Error(), "flaky")
+
+	assert.NoError(t, NewGroup().Run().Err())
+}
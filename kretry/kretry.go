@@ -3,6 +3,8 @@ package kretry
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"time"
 
 	"errors"
@@ -10,6 +12,15 @@ import (
 
 var DefaultRetryTimes = 3
 
+var (
+	ErrMaxElapsedTime = errors.New("retry: max elapsed time exceeded")
+	ErrInvalidResult  = errors.New("retry: invalid result")
+	// ErrValidatorType WithValidator 的参数类型与执行函数返回结果的类型不一致,属于使用错误,不会重试
+	ErrValidatorType = errors.New("retry: validator type mismatch")
+	// ErrWouldExceedDeadline 下次执行无法在ctx的截止时间前完成,可以通过 errors.Is 匹配 context.DeadlineExceeded
+	ErrWouldExceedDeadline = fmt.Errorf("retry: next attempt would exceed context deadline: %w", context.DeadlineExceeded)
+)
+
 // ErrorFunc 错误处理函数类型
 // 参数说明:
 //   - error: 需要处理的错误
@@ -18,12 +29,42 @@ var DefaultRetryTimes = 3
 //   - shouldStop: 是否停止重试,true表示停止重试,false表示继续重试
 type ErrorFunc func(error) (shouldStop bool)
 
+// RetryIfFunc 判断错误是否需要重试的函数类型
+// 参数说明:
+//   - err: 本次执行的错误
+//
+// 返回值说明:
+//   - retry: 是否需要重试,true表示重试,false表示立即停止
+type RetryIfFunc func(err error) (retry bool)
+
 // RetryFunc 重试回调函数类型
 // 参数说明:
 //   - attempt: 当前重试次数
 //   - err: 本次执行的错误
 type RetryFunc func(attempt int, err error)
 
+// SuccessFunc 执行成功时的回调函数类型
+// 参数说明:
+//   - attempt: 成功时的重试次数,从0开始,与 RetryFunc 一致
+//   - result: 执行结果
+type SuccessFunc func(attempt int, result any)
+
+// FinishFunc 重试结束时的回调函数类型,无论成功或失败都会调用
+// 参数说明:
+//   - attempts: 总的执行次数
+//   - err: 最终返回的错误,成功时为nil
+//   - elapsed: 总的耗时,包含重试间隔
+type FinishFunc func(attempts int, err error, elapsed time.Duration)
+
+// DelayFunc 计算重试间隔的函数类型
+// 参数说明:
+//   - attempt: 当前重试次数,从0开始,与 RetryFunc 一致
+//   - err: 本次执行的错误
+//
+// 返回值说明:
+//   - time.Duration: 下次执行前等待的时间
+type DelayFunc func(attempt int, err error) time.Duration
+
 // ExecFunc 执行函数类型
 // 参数说明:
 //   - ctx: 上下文对象,用于控制超时和取消
@@ -52,11 +93,6 @@ func New[T any](opts ...Option) *retry[T] {
 	for _, opt := range opts {
 		opt(options)
 	}
-	if len(options.CustomDelay) > 0 {
-		if len(options.CustomDelay) != options.AttemptTimes {
-			panic("CustomRetryDelay must be equal to AttemptTimes")
-		}
-	}
 	return &retry[T]{
 		opts: options,
 	}
@@ -72,11 +108,20 @@ func New[T any](opts ...Option) *retry[T] {
 //
 // 注意事项:
 //   - 默认情况下,重试次数为3次,重试间隔为100ms 200ms 400ms
-//   - 可以通过WithCustomRetryDelay设置自定义重试间隔,如果设置,则必须和重试次数一致,否则会panic
+//   - 可以通过WithDelayFunc根据重试次数和错误设置重试间隔,优先于WithCustomDelay和退避策略
+//   - 可以通过WithCustomDelay设置自定义重试间隔,长度小于重试次数时,之后的重试使用最后一个间隔
 //   - 如果成功,即使之前有失败也不会返回错误
+//   - 设置了Validator时,执行没有错误但结果校验失败也视为失败,错误包含 ErrInvalidResult 和校验的错误
+//   - Validator的参数类型与T不一致时立即返回,错误包含 ErrValidatorType
 //   - ctx超时控制是不精确的,只会在重试间隔内生效,如果执行一次成功,但是该次执行时间大于ctx的超时时间,则认为成功
+//   - ctx设置了截止时间时,如果剩余时间小于重试间隔加上之前执行的平均耗时,则立即停止重试,返回的错误包含 ErrWouldExceedDeadline
 //   - 当ErrorHandler返回true时会立即停止重试
-//   - 当重试一直失败,所有的错误会通过 errors.Join 合并返回
+//   - 当RetryIf返回false时会立即停止重试,返回本次执行的错误
+//   - 设置了Budget时,每次重试前消耗一次重试预算,预算耗尽时停止重试,返回的错误包含 ErrRetryBudgetExhausted
+//   - 设置了Breaker时,每次执行前检查熔断器,熔断器打开时立即停止重试,返回的错误包含 ErrBreakerOpen
+//   - 错误实现了 RetryAfterError 时,使用错误提供的重试间隔代替配置的重试间隔
+//   - 设置了MaxElapsedTime时,如果已执行的时间加上下次的重试间隔超过该时间,则不再重试,返回的错误包含 ErrMaxElapsedTime
+//   - 当重试一直失败,默认所有的错误会通过 errors.Join 合并返回,可以通过 WithErrorPolicy 修改
 //
 // 举例:
 //
@@ -84,46 +129,108 @@ func New[T any](opts ...Option) *retry[T] {
 //	    return "hello", nil
 //	})
 func (r *retry[T]) Do(exec ExecFunc[T]) (T, error) {
+	start := r.opts.Clock.Now()
+	result, attempts, err := r.do(exec, start)
+	if r.opts.FinishHandler != nil {
+		r.opts.FinishHandler(attempts, err, r.opts.Clock.Since(start))
+	}
+	return result, err
+}
+
+// do 执行带重试的操作
+// 返回值说明:
+//   - T: 执行成功时的结果
+//   - int: 执行的次数
+//   - error: 执行失败时的错误
+func (r *retry[T]) do(exec ExecFunc[T], start time.Time) (T, int, error) {
 	var result T
-	var errs []error
+	var errs []AttemptError
+	var execTotal time.Duration // 所有执行的总耗时,用于估计下次执行的耗时
 	if r.opts.Ctx.Err() != nil {
-		return result, r.opts.Ctx.Err()
+		return result, 0, r.opts.Ctx.Err()
+	}
+	if err := r.opts.checkValidator(reflect.TypeFor[T]()); err != nil {
+		return result, 0, err
+	}
+	if r.opts.Budget != nil {
+		r.opts.Budget.Request()
 	}
 	for attempt := 0; attempt < r.opts.AttemptTimes; attempt++ {
+		if r.opts.Breaker != nil {
+			if err := r.opts.Breaker.Allow(); err != nil {
+				return result, attempt, r.opts.buildError(errs, err)
+			}
+		}
+		execStart := r.opts.Clock.Now()
 		result, err := exec(r.opts.Ctx)
+		if err == nil && r.opts.Validator != nil {
+			if verr := r.opts.Validator(result); verr != nil {
+				if errors.Is(verr, ErrValidatorType) {
+					return result, attempt + 1, verr
+				}
+				err = fmt.Errorf("%w: %w", ErrInvalidResult, verr)
+			}
+		}
+		elapsed := r.opts.Clock.Since(execStart)
+		execTotal += elapsed
+		if r.opts.Breaker != nil {
+			if err == nil {
+				r.opts.Breaker.Success(elapsed)
+			} else {
+				r.opts.Breaker.Failure(elapsed)
+			}
+		}
 		if err == nil {
-			return result, nil // 成功立即返回
+			if r.opts.SuccessHandler != nil {
+				r.opts.SuccessHandler(attempt, result)
+			}
+			return result, attempt + 1, nil // 成功立即返回
 		}
 		// 错误处理流程
 		if r.opts.ErrorHandler != nil && r.opts.ErrorHandler(err) {
-			return result, err
+			return result, attempt + 1, err
 		}
-		errs = append(errs, err)
+		if r.opts.RetryIf != nil && !r.opts.RetryIf(err) {
+			return result, attempt + 1, err
+		}
+		errs = append(errs, AttemptError{Attempt: attempt, Err: err, Time: r.opts.Clock.Now()})
 
 		// 执行重试回调
 		if r.opts.RetryHandler != nil {
 			r.opts.RetryHandler(attempt, err)
 		}
 
+		if attempt+1 >= r.opts.AttemptTimes {
+			break // 最后一次执行失败后不再等待
+		}
 		// 使用可取消的定时器避免资源泄漏
-		var delay time.Duration
-		if len(r.opts.CustomDelay) > 0 {
-			delay = r.opts.CustomDelay[attempt]
-		} else {
-			delay = r.opts.Backoff.Duration()
+		delay := r.opts.delay(attempt, err)
+		// 错误提供了重试间隔时优先使用,如HTTP 429的Retry-After
+		if hint, ok := retryAfter(err); ok {
+			delay = hint
+		}
+		if r.opts.MaxElapsedTime > 0 && r.opts.Clock.Since(start)+delay > r.opts.MaxElapsedTime {
+			return result, attempt + 1, r.opts.buildError(errs, ErrMaxElapsedTime)
 		}
-		timer := time.NewTimer(delay)
+		// 剩余时间不足以等待并再执行一次时立即返回,而不是等待后因ctx超时失败
+		if deadline, ok := r.opts.Ctx.Deadline(); ok &&
+			time.Until(deadline) < delay+execTotal/time.Duration(attempt+1) {
+			return result, attempt + 1, r.opts.buildError(errs, ErrWouldExceedDeadline)
+		}
+		if r.opts.Budget != nil && !r.opts.Budget.TryRetry() {
+			return result, attempt + 1, r.opts.buildError(errs, ErrRetryBudgetExhausted)
+		}
+		timer := r.opts.Clock.NewTimer(delay)
 		select {
 		case <-r.opts.Ctx.Done():
 			timer.Stop()
-			errs = append(errs, r.opts.Ctx.Err())
-			return result, mergeErrors(errs)
-		case <-timer.C:
+			return result, attempt + 1, r.opts.buildError(errs, r.opts.Ctx.Err())
+		case <-timer.C():
 			timer.Stop()
 		}
 	}
 
-	return result, mergeErrors(errs)
+	return result, r.opts.AttemptTimes, r.opts.buildError(errs, nil)
 }
 
 // Do 执行带重试的函数调用
@@ -147,6 +254,57 @@ func Do[T any](exec ExecFunc[T], opts ...Option) (T, error) {
 	return r.Do(exec)
 }
 
+// DoVoid 执行带重试的没有返回值的函数调用
+//
+// 参数说明:
+//   - exec: 需要执行的函数
+//   - opts: 重试选项配置
+//
+// 返回值说明:
+//   - error: 执行失败时的错误信息
+//
+// 参见 retry.Do
+// 举例:
+//
+//	err := DoVoid(func(ctx context.Context) error {
+//	    return db.PingContext(ctx)
+//	})
+func DoVoid(exec func(ctx context.Context) error, opts ...Option) error {
+	_, err := Do(func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, exec(ctx)
+	}, opts...)
+	return err
+}
+
+// DoN 执行带重试的返回两个值的函数调用
+//
+// 参数说明:
+//   - exec: 需要执行的函数
+//   - opts: 重试选项配置
+//
+// 返回值说明:
+//   - T1: 执行成功时的第一个返回值
+//   - T2: 执行成功时的第二个返回值
+//   - error: 执行失败时的错误信息
+//
+// 参见 retry.Do
+// 举例:
+//
+//	body, code, err := DoN(func(ctx context.Context) ([]byte, int, error) {
+//	    return fetch(ctx, url)
+//	})
+func DoN[T1, T2 any](exec func(ctx context.Context) (T1, T2, error), opts ...Option) (T1, T2, error) {
+	type pair struct {
+		v1 T1
+		v2 T2
+	}
+	p, err := Do(func(ctx context.Context) (pair, error) {
+		v1, v2, err := exec(ctx)
+		return pair{v1, v2}, err
+	}, opts...)
+	return p.v1, p.v2, err
+}
+
 // mergeErrors 合并多个错误信息
 // 参数说明:
 //   - errs: 错误列表
@@ -1,10 +1,14 @@
 package kretry
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/mtgnorton/k/ktime"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -100,4 +104,284 @@ func TestRetry(t *testing.T) {
 		assert.Equal(t, "success", result)
 		assert.Equal(t, 3, attempt)
 	})
+
+	t.Run("with max elapsed time", func(t *testing.T) {
+		var attempt int
+		start := time.Now()
+		_, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			return "", errors.New("error")
+		}, WithTimes(10), WithCustomDelay(slices.Repeat([]time.Duration{30 * time.Millisecond}, 10)),
+			WithMaxElapsedTime(100*time.Millisecond))
+		assert.ErrorIs(t, err, ErrMaxElapsedTime)
+		// 第4次执行后已执行约90ms,再等待30ms会超过100ms
+		assert.Equal(t, 4, attempt)
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("max elapsed time after last attempt", func(t *testing.T) {
+		var attempt int
+		start := time.Now()
+		_, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			return "", errors.New("error")
+		}, WithTimes(2), WithCustomDelay([]time.Duration{0, time.Hour}),
+			WithMaxElapsedTime(100*time.Millisecond))
+		// 最后一次执行失败后不会再重试,返回执行的错误而不是 ErrMaxElapsedTime
+		assert.NotErrorIs(t, err, ErrMaxElapsedTime)
+		assert.EqualError(t, err, "error\nerror")
+		assert.Equal(t, 2, attempt)
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+}
+
+func TestDoVoid(t *testing.T) {
+	var attempt int
+	err := DoVoid(func(ctx context.Context) error {
+		attempt++
+		if attempt < 2 {
+			return errors.New("error")
+		}
+		return nil
+	}, WithBackoff(NewBackoff(WithMin(time.Millisecond))))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempt)
+
+	err = DoVoid(func(ctx context.Context) error {
+		return errors.New("always")
+	}, WithTimes(2), WithCustomDelay([]time.Duration{0, 0}))
+	assert.EqualError(t, err, "always\nalways")
+}
+
+func TestDoN(t *testing.T) {
+	var attempt int
+	body, code, err := DoN(func(ctx context.Context) (string, int, error) {
+		attempt++
+		if attempt < 2 {
+			return "", 500, errors.New("error")
+		}
+		return "ok", 200, nil
+	}, WithBackoff(NewBackoff(WithMin(time.Millisecond))))
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", body)
+	assert.Equal(t, 200, code)
+}
+
+func TestHandlers(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var (
+			successAttempt = -1
+			successResult  any
+			finishAttempts int
+			finishErr      error
+			finishElapsed  time.Duration
+		)
+		var attempt int
+		result, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			if attempt < 3 {
+				return "", errors.New("error")
+			}
+			return "ok", nil
+		}, WithCustomDelay([]time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}),
+			WithSuccessHandler(func(attempt int, result any) {
+				successAttempt, successResult = attempt, result
+			}),
+			WithFinishHandler(func(attempts int, err error, elapsed time.Duration) {
+				finishAttempts, finishErr, finishElapsed = attempts, err, elapsed
+			}))
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", result)
+		assert.Equal(t, 2, successAttempt)
+		assert.Equal(t, "ok", successResult)
+		assert.Equal(t, 3, finishAttempts)
+		assert.NoError(t, finishErr)
+		assert.GreaterOrEqual(t, finishElapsed, 20*time.Millisecond)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		var (
+			successCalled  bool
+			finishAttempts int
+			finishErr      error
+		)
+		_, err := Do(func(ctx context.Context) (string, error) {
+			return "", errors.New("error")
+		}, WithTimes(2), WithCustomDelay([]time.Duration{0, 0}),
+			WithSuccessHandler(func(attempt int, result any) {
+				successCalled = true
+			}),
+			WithFinishHandler(func(attempts int, err error, elapsed time.Duration) {
+				finishAttempts, finishErr = attempts, err
+			}))
+		assert.Error(t, err)
+		assert.False(t, successCalled)
+		assert.Equal(t, 2, finishAttempts)
+		assert.Equal(t, err, finishErr)
+	})
+}
+
+func TestDeadlineAwareSleep(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var attempt int
+	start := time.Now()
+	_, err := Do(func(ctx context.Context) (string, error) {
+		attempt++
+		time.Sleep(50 * time.Millisecond)
+		return "", errors.New("error")
+	}, WithContext(ctx), WithCustomDelay([]time.Duration{40 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond}),
+		WithTimes(3))
+	// 第2次执行后已用时约140ms,剩余约60ms,不足以等待40ms再执行50ms
+	assert.Equal(t, 2, attempt)
+	assert.ErrorIs(t, err, ErrWouldExceedDeadline)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 180*time.Millisecond, "立即返回而不是等待ctx超时")
+}
+
+func TestDelayFunc(t *testing.T) {
+	t.Run("depends on error", func(t *testing.T) {
+		rateLimited := errors.New("rate limited")
+		var (
+			attempt int
+			calls   []int
+		)
+		start := time.Now()
+		result, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			switch attempt {
+			case 1:
+				return "", rateLimited
+			case 2:
+				return "", errors.New("error")
+			}
+			return "ok", nil
+		}, WithDelayFunc(func(attempt int, err error) time.Duration {
+			calls = append(calls, attempt)
+			if errors.Is(err, rateLimited) {
+				return 30 * time.Millisecond
+			}
+			return time.Millisecond
+		}), WithCustomDelay([]time.Duration{time.Minute}))
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", result)
+		assert.Equal(t, []int{0, 1}, calls)
+		assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+		assert.Less(t, time.Since(start), time.Second, "优先于CustomDelay")
+	})
+
+	t.Run("custom delay shorter than attempts", func(t *testing.T) {
+		var attempt int
+		assert.NotPanics(t, func() {
+			_, err := Do(func(ctx context.Context) (string, error) {
+				attempt++
+				return "", errors.New("error")
+			}, WithTimes(4), WithCustomDelay([]time.Duration{time.Millisecond}))
+			assert.Error(t, err)
+		})
+		assert.Equal(t, 4, attempt)
+	})
+}
+
+func TestValidator(t *testing.T) {
+	t.Run("retry on invalid result", func(t *testing.T) {
+		var attempt int
+		result, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			if attempt < 3 {
+				return "", nil
+			}
+			return "body", nil
+		}, WithCustomDelay([]time.Duration{0}), WithValidator(func(body string) error {
+			if body == "" {
+				return errors.New("empty body")
+			}
+			return nil
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, "body", result)
+		assert.Equal(t, 3, attempt)
+	})
+
+	t.Run("invalid result error", func(t *testing.T) {
+		errStale := errors.New("stale version")
+		_, err := Do(func(ctx context.Context) (int, error) {
+			return 1, nil
+		}, WithTimes(2), WithCustomDelay([]time.Duration{0}), WithValidator(func(version int) error {
+			if version < 2 {
+				return errStale
+			}
+			return nil
+		}))
+		assert.ErrorIs(t, err, ErrInvalidResult)
+		assert.ErrorIs(t, err, errStale)
+	})
+
+	t.Run("result type mismatch", func(t *testing.T) {
+		var attempt int
+		var called bool
+		_, err := Do(func(ctx context.Context) (int, error) {
+			attempt++
+			return 1, nil
+		}, WithTimes(2), WithCustomDelay([]time.Duration{0}), WithValidator(func(body string) error {
+			called = true
+			return nil
+		}))
+		assert.ErrorIs(t, err, ErrValidatorType)
+		assert.EqualError(t, err, "retry: validator type mismatch: validator expects string, got int")
+		assert.Equal(t, 0, attempt, "执行前检查类型")
+		assert.False(t, called)
+	})
+
+	t.Run("interface result type mismatch", func(t *testing.T) {
+		var attempt int
+		_, err := Do(func(ctx context.Context) (any, error) {
+			attempt++
+			return 1, nil
+		}, WithTimes(3), WithCustomDelay([]time.Duration{0}), WithValidator(func(body string) error {
+			return nil
+		}))
+		assert.ErrorIs(t, err, ErrValidatorType)
+		assert.NotErrorIs(t, err, ErrInvalidResult)
+		assert.Equal(t, 1, attempt, "第一次执行后类型不一致时不再重试")
+
+		// 实现了校验函数参数的接口时可以使用
+		_, err = Do(func(ctx context.Context) (*bytes.Buffer, error) {
+			return bytes.NewBufferString("body"), nil
+		}, WithValidator(func(r io.Reader) error {
+			return nil
+		}))
+		assert.NoError(t, err)
+	})
+}
+
+func TestClock(t *testing.T) {
+	clock := ktime.NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+	var (
+		attempt int
+		elapsed time.Duration
+	)
+	done := make(chan error)
+	go func() {
+		_, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			if attempt < 3 {
+				return "", errors.New("error")
+			}
+			return "ok", nil
+		}, WithClock(clock), WithCustomDelay([]time.Duration{time.Minute, time.Hour}),
+			WithFinishHandler(func(attempts int, err error, d time.Duration) {
+				elapsed = d
+			}))
+		done <- err
+	}()
+
+	// 每次失败后等待重试间隔,前进模拟时间后立即开始下一次重试
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	assert.NoError(t, <-done)
+	assert.Equal(t, 3, attempt)
+	assert.Equal(t, time.Hour+time.Minute, elapsed)
 }
@@ -2,17 +2,33 @@ package kretry
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"time"
+
+	"github.com/mtgnorton/k/ktime"
 )
 
 type Options struct {
-	Ctx          context.Context // 当Ctx设置了超时时间, 则当Ctx超时后, 会停止重试
-	ErrorHandler ErrorFunc       // 错误处理回调函数
-	RetryHandler RetryFunc       // 重试时调用的函数
-	AttemptTimes int             // 重试次数
-	CustomDelay  []time.Duration // 自定义重试间隔时间,必须和重试次数一致
-	Backoff      *Backoff        // 退避策略
+	Ctx            context.Context // 当Ctx设置了超时时间, 则当Ctx超时后, 会停止重试
+	ErrorHandler   ErrorFunc       // 错误处理回调函数
+	RetryIf        RetryIfFunc     // 判断错误是否需要重试,为nil时所有错误都重试
+	RetryHandler   RetryFunc       // 重试时调用的函数
+	SuccessHandler SuccessFunc     // 执行成功时调用的函数
+	FinishHandler  FinishFunc      // 重试结束时调用的函数
+	AttemptTimes   int             // 重试次数
+	CustomDelay    []time.Duration // 自定义重试间隔时间,长度小于重试次数时之后使用最后一个间隔
+	DelayFunc      DelayFunc       // 计算重试间隔的函数,设置后优先于CustomDelay和退避策略
+	Backoff        *Backoff        // 退避策略
+	Strategy       BackoffStrategy // 自定义的退避策略,设置后优先于Backoff
+	MaxElapsedTime time.Duration   // 最长的重试时间,超过后不再重试,为0时不限制
+	Breaker        *Breaker        // 熔断器,为nil时不熔断
+	Budget         *RetryBudget    // 重试预算,为nil时不限制
+	ErrorPolicy    ErrorPolicy     // 重试失败时返回错误的方式
+	Validator      func(any) error // 校验执行结果,返回错误时视为执行失败,参见 WithValidator
+	Clock          ktime.Clock     // 计算耗时和等待重试间隔使用的时钟
 
+	validatorType reflect.Type // WithValidator 设置的校验函数的参数类型,用于在执行前检查类型
 }
 
 type Option func(o *Options)
@@ -22,6 +38,7 @@ func NewOptions() *Options {
 		Ctx:          context.Background(),
 		AttemptTimes: DefaultRetryTimes,
 		Backoff:      NewBackoff(),
+		Clock:        ktime.RealClock,
 	}
 }
 
@@ -37,12 +54,38 @@ func WithErrHandler(errHandle func(error) (stop bool)) Option {
 	}
 }
 
+// WithRetryIf 设置判断错误是否需要重试的函数,返回false时立即停止重试
+// 可以使用 RetryOnErrors, RetryOnType, RetryOnDeadline, RetryOnTemporary 等函数声明需要重试的错误
+//
+// 举例:
+//
+//	Do(exec, WithRetryIf(RetryIfAny(RetryOnErrors(io.ErrUnexpectedEOF), RetryOnTemporary())))
+func WithRetryIf(retryIf RetryIfFunc) Option {
+	return func(o *Options) {
+		o.RetryIf = retryIf
+	}
+}
+
 func WithRetryHandler(retryHandler func(attempt int, err error)) Option {
 	return func(o *Options) {
 		o.RetryHandler = retryHandler
 	}
 }
 
+// WithSuccessHandler 设置执行成功时调用的函数,可用于记录成功前的重试次数
+func WithSuccessHandler(successHandler func(attempt int, result any)) Option {
+	return func(o *Options) {
+		o.SuccessHandler = successHandler
+	}
+}
+
+// WithFinishHandler 设置重试结束时调用的函数,无论成功或失败都会调用,可用于统一记录日志和指标
+func WithFinishHandler(finishHandler func(attempts int, err error, elapsed time.Duration)) Option {
+	return func(o *Options) {
+		o.FinishHandler = finishHandler
+	}
+}
+
 func WithTimes(times int) Option {
 	return func(o *Options) {
 		o.AttemptTimes = times
@@ -55,17 +98,138 @@ func WithCustomDelay(delay []time.Duration) Option {
 	}
 }
 
+// WithDelayFunc 设置计算重试间隔的函数,可以根据错误决定等待的时间,如限流错误等待更久
+//
+// 举例:
+//
+//	WithDelayFunc(func(attempt int, err error) time.Duration {
+//	    if errors.Is(err, ErrRateLimited) {
+//	        return 10 * time.Second
+//	    }
+//	    return time.Duration(attempt+1) * 100 * time.Millisecond
+//	})
+func WithDelayFunc(fn DelayFunc) Option {
+	return func(o *Options) {
+		o.DelayFunc = fn
+	}
+}
+
+// delay 返回本次重试前等待的时间
+func (o *Options) delay(attempt int, err error) time.Duration {
+	if o.DelayFunc != nil {
+		return o.DelayFunc(attempt, err)
+	}
+	if len(o.CustomDelay) > 0 {
+		return o.CustomDelay[min(attempt, len(o.CustomDelay)-1)]
+	}
+	return o.backoff().Duration()
+}
+
 func WithBackoff(backoff *Backoff) Option {
 	return func(o *Options) {
 		o.Backoff = backoff
 	}
 }
 
+// WithMaxElapsedTime 设置最长的重试时间,从第一次执行开始计算,超过后不论剩余多少次都不再重试
+func WithMaxElapsedTime(d time.Duration) Option {
+	return func(o *Options) {
+		o.MaxElapsedTime = d
+	}
+}
+
+// WithBackoffStrategy 设置退避策略,如 NewConstantBackoff, NewLinearBackoff, NewFibonacciBackoff
+// 设置后优先于 WithBackoff
+func WithBackoffStrategy(strategy BackoffStrategy) Option {
+	return func(o *Options) {
+		o.Strategy = strategy
+	}
+}
+
+// backoff 返回生效的退避策略
+func (o *Options) backoff() BackoffStrategy {
+	if o.Strategy != nil {
+		return o.Strategy
+	}
+	return o.Backoff
+}
+
+// WithBreaker 设置熔断器,熔断器打开时快速失败,每次执行的结果都会记录到熔断器中
+// 同一个熔断器可以在多个重试器之间共享
+func WithBreaker(breaker *Breaker) Option {
+	return func(o *Options) {
+		o.Breaker = breaker
+	}
+}
+
+// WithBudget 设置重试预算,同一个预算可以在多个重试器之间共享
+func WithBudget(budget *RetryBudget) Option {
+	return func(o *Options) {
+		o.Budget = budget
+	}
+}
+
+// WithErrorPolicy 设置重试失败时返回错误的方式,参见 ErrorPolicy
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	return func(o *Options) {
+		o.ErrorPolicy = policy
+	}
+}
+
+// WithValidator 设置执行结果的校验函数,执行没有错误但结果不符合要求时(如响应体为空、版本过旧)也会重试
+// T必须与执行函数返回结果的类型一致,不一致时不执行也不重试,立即返回包含 ErrValidatorType 和两者类型的错误
+// 执行函数返回接口类型时只能在执行后检查,第一次执行的结果类型不一致时立即返回
+//
+// 举例:
+//
+//	Do(fetch, WithValidator(func(resp *Response) error {
+//	    if resp.Code != 0 {
+//	        return errors.Errorf("unexpected code: %d", resp.Code)
+//	    }
+//	    return nil
+//	}))
+func WithValidator[T any](validator func(T) error) Option {
+	return func(o *Options) {
+		o.validatorType = reflect.TypeFor[T]()
+		o.Validator = func(v any) error {
+			result, ok := v.(T)
+			if !ok && v != nil { // 结果为nil接口时使用零值
+				return fmt.Errorf("%w: validator expects %v, got %T", ErrValidatorType, o.validatorType, v)
+			}
+			return validator(result)
+		}
+	}
+}
+
+// checkValidator 检查执行函数返回结果的类型t能否传给 WithValidator 设置的校验函数
+// t为接口类型时结果的实际类型在执行后才能确定,不检查
+func (o *Options) checkValidator(t reflect.Type) error {
+	if o.Validator == nil || o.validatorType == nil || t.Kind() == reflect.Interface || t.AssignableTo(o.validatorType) {
+		return nil
+	}
+	return fmt.Errorf("%w: validator expects %v, got %v", ErrValidatorType, o.validatorType, t)
+}
+
+// WithClock 设置计算耗时和等待重试间隔使用的时钟,默认为 ktime.RealClock
+// 测试时使用 ktime.FakeClock 可以不用真正等待重试间隔
+//
+// 举例:
+//
+//	clock := ktime.NewFakeClock(time.Now())
+//	go Do(exec, WithClock(clock))
+//	clock.BlockUntil(1)        // 等待第一次失败后进入重试间隔
+//	clock.Advance(time.Second) // 立即开始下一次重试
+func WithClock(clock ktime.Clock) Option {
+	return func(o *Options) {
+		o.Clock = clock
+	}
+}
+
 type BackOffOptions struct {
-	factor float64       // 指数因子
-	jitter bool          // 是否添加随机抖动
-	min    time.Duration // 最小退避时间
-	max    time.Duration // 最大退避时间
+	factor     float64       // 指数因子
+	jitterMode JitterMode    // 随机抖动的方式
+	min        time.Duration // 最小退避时间
+	max        time.Duration // 最大退避时间
 }
 
 // BackoffOption 用于配置Backoff的选项函数类型
@@ -73,10 +237,10 @@ type BackoffOption func(b *BackOffOptions)
 
 func NewBackOffOptions() *BackOffOptions {
 	return &BackOffOptions{
-		factor: 2,
-		jitter: false,
-		min:    100 * time.Millisecond,
-		max:    10 * time.Second,
+		factor:     2,
+		jitterMode: JitterNone,
+		min:        100 * time.Millisecond,
+		max:        10 * time.Second,
 	}
 }
 
@@ -93,10 +257,24 @@ func WithFactor(factor float64) BackoffOption {
 // WithJitter 设置是否添加随机抖动
 //
 // 参数说明:
-//   - jitter: 是否启用随机抖动
+//   - jitter: 是否启用随机抖动,启用时使用 JitterUniform,参见 WithJitterMode
 func WithJitter(jitter bool) BackoffOption {
 	return func(b *BackOffOptions) {
-		b.jitter = jitter
+		if jitter {
+			b.jitterMode = JitterUniform
+		} else {
+			b.jitterMode = JitterNone
+		}
+	}
+}
+
+// WithJitterMode 设置随机抖动的方式
+//
+// 参数说明:
+//   - mode: 随机抖动的方式,参见 JitterMode
+func WithJitterMode(mode JitterMode) BackoffOption {
+	return func(b *BackOffOptions) {
+		b.jitterMode = mode
 	}
 }
 
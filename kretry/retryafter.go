@@ -0,0 +1,63 @@
+package kretry
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryAfterError 提供重试间隔的错误,如包装了HTTP 429响应的Retry-After
+// 错误链中存在该类型的错误且返回值大于0时,下次重试前等待该时间,而不是配置的重试间隔
+type RetryAfterError interface {
+	error
+	RetryAfter() time.Duration
+}
+
+type retryAfterError struct {
+	err   error
+	delay time.Duration
+}
+
+// NewRetryAfterError 包装err,使其提供重试间隔
+//
+// 参数说明:
+//   - err: 原始错误
+//   - delay: 下次重试前等待的时间
+//
+// 返回值说明:
+//   - error: 实现了 RetryAfterError 的错误,可以通过 errors.Is/As 匹配原始错误,err为nil时返回nil
+//
+// 举例:
+//
+//	if resp.StatusCode == http.StatusTooManyRequests {
+//	    seconds, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+//	    return nil, NewRetryAfterError(ErrRateLimited, time.Duration(seconds)*time.Second)
+//	}
+func NewRetryAfterError(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &retryAfterError{err: err, delay: delay}
+}
+
+func (e *retryAfterError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryAfterError) Unwrap() error {
+	return e.err
+}
+
+// RetryAfter 返回下次重试前等待的时间
+func (e *retryAfterError) RetryAfter() time.Duration {
+	return e.delay
+}
+
+// retryAfter 返回错误链中提供的重试间隔
+func retryAfter(err error) (time.Duration, bool) {
+	var hint RetryAfterError
+	if errors.As(err, &hint) && hint.RetryAfter() > 0 {
+		return hint.RetryAfter(), true
+	}
+	return 0, false
+}
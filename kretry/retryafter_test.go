@@ -0,0 +1,46 @@
+package kretry
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	t.Run("wrap", func(t *testing.T) {
+		assert.Nil(t, NewRetryAfterError(nil, time.Second))
+
+		err := errors.Wrap(NewRetryAfterError(io.EOF, time.Second), "fetch")
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, "fetch: EOF", err.Error())
+		d, ok := retryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, d)
+
+		_, ok = retryAfter(NewRetryAfterError(io.EOF, 0))
+		assert.False(t, ok, "为0时使用配置的重试间隔")
+		_, ok = retryAfter(io.EOF)
+		assert.False(t, ok)
+	})
+
+	t.Run("honor hint", func(t *testing.T) {
+		var attempt int
+		start := time.Now()
+		result, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			if attempt == 1 {
+				return "", NewRetryAfterError(errors.New("too many requests"), 20*time.Millisecond)
+			}
+			return "ok", nil
+		}, WithCustomDelay([]time.Duration{time.Minute, time.Minute, time.Minute}))
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", result)
+		elapsed := time.Since(start)
+		assert.GreaterOrEqual(t, elapsed, 20*time.Millisecond)
+		assert.Less(t, elapsed, time.Second)
+	})
+}
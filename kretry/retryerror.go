@@ -0,0 +1,87 @@
+package kretry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrorPolicy 重试失败时返回错误的方式
+type ErrorPolicy int
+
+const (
+	ErrorPolicyJoin       ErrorPolicy = iota // 通过 errors.Join 合并所有错误返回,默认方式
+	ErrorPolicyLast                          // 只返回最后一次执行的错误,因ctx结束等原因停止时同时包含停止的原因
+	ErrorPolicyStructured                    // 返回 *RetryError,包含每次执行的错误和时间
+)
+
+// AttemptError 单次执行的错误
+type AttemptError struct {
+	Attempt int       // 重试次数,从0开始
+	Err     error     // 执行的错误
+	Time    time.Time // 执行失败的时间
+}
+
+// RetryError 重试失败的错误,使用 ErrorPolicyStructured 时返回
+// 可以通过 errors.Is/As 匹配任意一次执行的错误和停止的原因
+type RetryError struct {
+	Attempts []AttemptError // 每次执行的错误
+	Reason   error          // 提前停止的原因,如ctx结束、ErrMaxElapsedTime,重试次数用尽时为nil
+}
+
+// Last 返回最后一次执行的错误,没有执行时返回nil
+func (e *RetryError) Last() error {
+	if len(e.Attempts) == 0 {
+		return nil
+	}
+	return e.Attempts[len(e.Attempts)-1].Err
+}
+
+// Error 只包含执行次数、最后一次的错误和停止的原因,避免日志过长
+func (e *RetryError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "retry: %d attempts failed", len(e.Attempts))
+	if last := e.Last(); last != nil {
+		fmt.Fprintf(&sb, ", last error: %s", last)
+	}
+	if e.Reason != nil {
+		fmt.Fprintf(&sb, ", stopped: %s", e.Reason)
+	}
+	return sb.String()
+}
+
+// Unwrap 返回所有执行的错误和停止的原因
+func (e *RetryError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Attempts)+1)
+	for _, a := range e.Attempts {
+		errs = append(errs, a.Err)
+	}
+	if e.Reason != nil {
+		errs = append(errs, e.Reason)
+	}
+	return errs
+}
+
+// buildError 按 ErrorPolicy 构建重试失败时返回的错误
+// 参数:
+//   - attempts: 每次执行的错误
+//   - reason: 提前停止的原因,重试次数用尽时为nil
+func (o *Options) buildError(attempts []AttemptError, reason error) error {
+	var errs []error
+	switch o.ErrorPolicy {
+	case ErrorPolicyStructured:
+		return &RetryError{Attempts: attempts, Reason: reason}
+	case ErrorPolicyLast:
+		if len(attempts) > 0 {
+			errs = append(errs, attempts[len(attempts)-1].Err)
+		}
+	default:
+		for _, a := range attempts {
+			errs = append(errs, a.Err)
+		}
+	}
+	if reason != nil {
+		errs = append(errs, reason)
+	}
+	return mergeErrors(errs)
+}
@@ -0,0 +1,70 @@
+package kretry
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorPolicy(t *testing.T) {
+	exec := func() ExecFunc[string] {
+		var attempt int
+		return func(ctx context.Context) (string, error) {
+			attempt++
+			if attempt == 1 {
+				return "", io.ErrUnexpectedEOF
+			}
+			return "", errors.Errorf("error %d", attempt)
+		}
+	}
+	delays := WithCustomDelay([]time.Duration{0})
+
+	t.Run("join", func(t *testing.T) {
+		_, err := Do(exec(), delays)
+		assert.EqualError(t, err, "unexpected EOF\nerror 2\nerror 3")
+	})
+
+	t.Run("last", func(t *testing.T) {
+		_, err := Do(exec(), delays, WithErrorPolicy(ErrorPolicyLast))
+		assert.EqualError(t, err, "error 3")
+		assert.NotErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		_, err = Do(exec(), delays, WithErrorPolicy(ErrorPolicyLast), WithMaxElapsedTime(time.Nanosecond))
+		assert.EqualError(t, err, "unexpected EOF\n"+ErrMaxElapsedTime.Error(), "包含停止的原因")
+	})
+
+	t.Run("structured", func(t *testing.T) {
+		start := time.Now()
+		_, err := Do(exec(), delays, WithErrorPolicy(ErrorPolicyStructured))
+		var retryErr *RetryError
+		assert.True(t, errors.As(err, &retryErr))
+		assert.Len(t, retryErr.Attempts, 3)
+		for i, a := range retryErr.Attempts {
+			assert.Equal(t, i, a.Attempt)
+			assert.False(t, a.Time.Before(start))
+		}
+		assert.Nil(t, retryErr.Reason)
+		assert.EqualError(t, retryErr.Last(), "error 3")
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, "retry: 3 attempts failed, last error: error 3", err.Error())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = New[string](WithErrorPolicy(ErrorPolicyStructured),
+			WithBreaker(openBreaker())).Do(exec())
+		assert.ErrorIs(t, err, ErrBreakerOpen)
+		assert.Equal(t, "retry: 0 attempts failed, stopped: "+ErrBreakerOpen.Error(), err.Error())
+		_, err = Do(exec(), WithContext(ctx), WithErrorPolicy(ErrorPolicyStructured))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func openBreaker() *Breaker {
+	b := NewBreaker(WithBreakerMinRequests(1), WithBreakerOpenTimeout(time.Minute))
+	b.Failure(0)
+	return b
+}
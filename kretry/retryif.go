@@ -0,0 +1,66 @@
+package kretry
+
+import (
+	"net"
+
+	"github.com/mtgnorton/k/kbase"
+	"github.com/pkg/errors"
+)
+
+// RetryOnErrors 返回只在错误匹配targets中任意一个时重试的判断函数,使用 errors.Is 匹配
+//
+// 举例:
+//
+//	WithRetryIf(RetryOnErrors(io.ErrUnexpectedEOF, syscall.ECONNRESET))
+func RetryOnErrors(targets ...error) RetryIfFunc {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RetryOnType 返回只在错误链中存在E类型的错误时重试的判断函数,使用 errors.As 匹配
+//
+// 举例:
+//
+//	WithRetryIf(RetryOnType[*net.OpError]())
+func RetryOnType[E error]() RetryIfFunc {
+	return func(err error) bool {
+		var target E
+		return errors.As(err, &target)
+	}
+}
+
+// RetryOnDeadline 返回只在超时错误时重试的判断函数,参见 kbase.IsDeadlineError
+func RetryOnDeadline() RetryIfFunc {
+	return kbase.IsDeadlineError
+}
+
+// RetryOnTemporary 返回只在临时的网络错误时重试的判断函数
+// 网络错误超时,或错误实现了 Temporary() bool 并返回true时视为临时错误
+func RetryOnTemporary() RetryIfFunc {
+	return func(err error) bool {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return true
+		}
+		var temporary interface{ Temporary() bool }
+		return errors.As(err, &temporary) && temporary.Temporary()
+	}
+}
+
+// RetryIfAny 返回任意一个判断函数返回true时重试的判断函数
+func RetryIfAny(fns ...RetryIfFunc) RetryIfFunc {
+	return func(err error) bool {
+		for _, fn := range fns {
+			if fn(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
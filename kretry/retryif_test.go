@@ -0,0 +1,57 @@
+package kretry
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type temporaryError struct{ temporary bool }
+
+func (e temporaryError) Error() string   { return "temporary" }
+func (e temporaryError) Temporary() bool { return e.temporary }
+
+func TestRetryIf(t *testing.T) {
+	t.Run("stop on non retryable error", func(t *testing.T) {
+		var attempt int
+		_, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			if attempt == 1 {
+				return "", io.ErrUnexpectedEOF
+			}
+			return "", io.EOF
+		}, WithRetryIf(RetryOnErrors(io.ErrUnexpectedEOF)), WithCustomDelay([]time.Duration{0, 0, 0}))
+		assert.Equal(t, io.EOF, err, "返回本次执行的错误")
+		assert.Equal(t, 2, attempt)
+	})
+
+	t.Run("matchers", func(t *testing.T) {
+		wrapped := errors.Wrap(io.ErrUnexpectedEOF, "read body")
+		assert.True(t, RetryOnErrors(io.EOF, io.ErrUnexpectedEOF)(wrapped))
+		assert.False(t, RetryOnErrors(io.EOF)(wrapped))
+		assert.False(t, RetryOnErrors()(wrapped))
+
+		opErr := &net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}
+		assert.True(t, RetryOnType[*net.OpError]()(errors.Wrap(opErr, "connect")))
+		assert.False(t, RetryOnType[*net.OpError]()(wrapped))
+
+		assert.True(t, RetryOnDeadline()(errors.Wrap(context.DeadlineExceeded, "query")))
+		assert.False(t, RetryOnDeadline()(context.Canceled))
+
+		assert.True(t, RetryOnTemporary()(opErr), "超时的网络错误")
+		assert.True(t, RetryOnTemporary()(errors.Wrap(temporaryError{true}, "send")))
+		assert.False(t, RetryOnTemporary()(temporaryError{false}))
+		assert.False(t, RetryOnTemporary()(wrapped))
+
+		matcher := RetryIfAny(RetryOnErrors(io.EOF), RetryOnDeadline())
+		assert.True(t, matcher(io.EOF))
+		assert.True(t, matcher(context.DeadlineExceeded))
+		assert.False(t, matcher(wrapped))
+	})
+}
@@ -0,0 +1,144 @@
+package kretry
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// BackoffStrategy 退避策略,Backoff 实现了该接口
+type BackoffStrategy interface {
+	// Duration 返回当前尝试的退避时间,每次调用都会增加尝试次数
+	Duration() time.Duration
+	// ForAttempt 根据尝试次数计算退避时间,尝试次数从0开始
+	ForAttempt(attempt float64) time.Duration
+	// Reset 重置尝试次数
+	Reset()
+}
+
+var (
+	_ BackoffStrategy = (*Backoff)(nil)
+	_ BackoffStrategy = (*ConstantBackoff)(nil)
+	_ BackoffStrategy = (*LinearBackoff)(nil)
+	_ BackoffStrategy = (*FibonacciBackoff)(nil)
+)
+
+// ConstantBackoff 固定间隔的退避策略
+type ConstantBackoff struct {
+	interval time.Duration
+}
+
+// NewConstantBackoff 创建固定间隔的退避策略
+//
+// 参数说明:
+//   - interval: 每次的退避时间
+//
+// 示例:
+//
+//	Do(exec, WithBackoffStrategy(NewConstantBackoff(time.Second)))
+func NewConstantBackoff(interval time.Duration) *ConstantBackoff {
+	return &ConstantBackoff{interval: interval}
+}
+
+// Duration 返回固定的退避时间
+func (b *ConstantBackoff) Duration() time.Duration {
+	return b.interval
+}
+
+// ForAttempt 返回固定的退避时间
+func (b *ConstantBackoff) ForAttempt(attempt float64) time.Duration {
+	return b.interval
+}
+
+// Reset 固定间隔没有状态,无效果
+func (b *ConstantBackoff) Reset() {}
+
+// LinearBackoff 线性增长的退避策略,第n次的退避时间为 initial + n*step,不超过max
+type LinearBackoff struct {
+	attempt atomic.Uint64
+	initial time.Duration
+	step    time.Duration
+	max     time.Duration
+}
+
+// NewLinearBackoff 创建线性增长的退避策略
+//
+// 参数说明:
+//   - initial: 第一次的退避时间
+//   - step: 每次增加的时间
+//   - max: 最大退避时间,小于等于0时不限制
+//
+// 示例:
+//
+//	// 1s 1.5s 2s 2.5s 3s 3s ...
+//	b := NewLinearBackoff(time.Second, 500*time.Millisecond, 3*time.Second)
+func NewLinearBackoff(initial, step, max time.Duration) *LinearBackoff {
+	return &LinearBackoff{initial: initial, step: step, max: max}
+}
+
+// Duration 返回当前尝试的退避时间,每次调用都会增加尝试次数
+func (b *LinearBackoff) Duration() time.Duration {
+	return b.ForAttempt(float64(b.attempt.Add(1) - 1))
+}
+
+// ForAttempt 根据尝试次数计算退避时间
+func (b *LinearBackoff) ForAttempt(attempt float64) time.Duration {
+	return capDuration(float64(b.initial)+attempt*float64(b.step), b.max)
+}
+
+// Reset 重置尝试次数
+func (b *LinearBackoff) Reset() {
+	b.attempt.Store(0)
+}
+
+// FibonacciBackoff 按斐波那契数列增长的退避策略,第n次的退避时间为 unit*fib(n+1),不超过max
+// 增长比指数退避平缓,适合轮询等场景
+type FibonacciBackoff struct {
+	attempt atomic.Uint64
+	unit    time.Duration
+	max     time.Duration
+}
+
+// NewFibonacciBackoff 创建按斐波那契数列增长的退避策略
+//
+// 参数说明:
+//   - unit: 单位时间,也是第一次的退避时间
+//   - max: 最大退避时间,小于等于0时不限制
+//
+// 示例:
+//
+//	// 100ms 100ms 200ms 300ms 500ms 800ms 1.3s ...
+//	b := NewFibonacciBackoff(100*time.Millisecond, 10*time.Second)
+func NewFibonacciBackoff(unit, max time.Duration) *FibonacciBackoff {
+	return &FibonacciBackoff{unit: unit, max: max}
+}
+
+// Duration 返回当前尝试的退避时间,每次调用都会增加尝试次数
+func (b *FibonacciBackoff) Duration() time.Duration {
+	return b.ForAttempt(float64(b.attempt.Add(1) - 1))
+}
+
+// ForAttempt 根据尝试次数计算退避时间
+func (b *FibonacciBackoff) ForAttempt(attempt float64) time.Duration {
+	prev, cur := 0.0, 1.0
+	for i := 0; i < int(attempt); i++ {
+		prev, cur = cur, prev+cur
+		if b.max > 0 && cur*float64(b.unit) >= float64(b.max) || cur*float64(b.unit) > maxInt64 {
+			break
+		}
+	}
+	return capDuration(cur*float64(b.unit), b.max)
+}
+
+// Reset 重置尝试次数
+func (b *FibonacciBackoff) Reset() {
+	b.attempt.Store(0)
+}
+
+// capDuration 将退避时间限制在0和max之间,max小于等于0时只限制不溢出
+func capDuration(duration float64, max time.Duration) time.Duration {
+	if max > 0 && duration > float64(max) {
+		return max
+	}
+	return time.Duration(math.Max(0, math.Min(duration, maxInt64)))
+}
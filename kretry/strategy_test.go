@@ -0,0 +1,58 @@
+package kretry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func durations(b BackoffStrategy, n int) []time.Duration {
+	ds := make([]time.Duration, n)
+	for i := range ds {
+		ds[i] = b.Duration()
+	}
+	return ds
+}
+
+func TestBackoffStrategy(t *testing.T) {
+	ms := time.Millisecond
+
+	t.Run("constant", func(t *testing.T) {
+		b := NewConstantBackoff(50 * ms)
+		assert.Equal(t, []time.Duration{50 * ms, 50 * ms, 50 * ms}, durations(b, 3))
+		assert.Equal(t, 50*ms, b.ForAttempt(100))
+	})
+
+	t.Run("linear", func(t *testing.T) {
+		b := NewLinearBackoff(100*ms, 50*ms, 220*ms)
+		assert.Equal(t, []time.Duration{100 * ms, 150 * ms, 200 * ms, 220 * ms, 220 * ms}, durations(b, 5))
+		b.Reset()
+		assert.Equal(t, 100*ms, b.Duration())
+		assert.Equal(t, time.Duration(1e9*ms), NewLinearBackoff(0, ms, 0).ForAttempt(1e9), "max为0时不限制")
+	})
+
+	t.Run("fibonacci", func(t *testing.T) {
+		b := NewFibonacciBackoff(100*ms, time.Second)
+		assert.Equal(t, []time.Duration{100 * ms, 100 * ms, 200 * ms, 300 * ms, 500 * ms, 800 * ms, time.Second, time.Second},
+			durations(b, 8))
+		b.Reset()
+		assert.Equal(t, 100*ms, b.Duration())
+		assert.Equal(t, time.Second, b.ForAttempt(1e6))
+		assert.Greater(t, NewFibonacciBackoff(ms, 0).ForAttempt(1e6), time.Duration(0), "不溢出")
+	})
+
+	t.Run("with retry", func(t *testing.T) {
+		var attempt int
+		start := time.Now()
+		_, err := Do(func(ctx context.Context) (string, error) {
+			attempt++
+			return "", errors.New("error")
+		}, WithTimes(3), WithBackoffStrategy(NewConstantBackoff(10*ms)))
+		assert.Error(t, err)
+		assert.Equal(t, 3, attempt)
+		assert.Less(t, time.Since(start), 100*ms, "使用固定间隔而不是默认的指数退避")
+	})
+}
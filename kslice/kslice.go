@@ -10,6 +10,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/mtgnorton/k/kbase"
 	"github.com/mtgnorton/k/kmath"
 	"github.com/mtgnorton/k/kreflect"
 )
@@ -117,7 +118,7 @@ func LoopConcAsyncFirstSuccess[T any, V any](
 //   - 该函数不会阻塞，而是立即返回结果通道和取消函数
 //   - 如果concurrency参数小于等于0，并发数会被设置为1
 //   - 每个元素都会在一个独立的goroutine中处理
-//   - 处理过程中的panic会被捕获并作为错误返回
+//   - 处理过程中的panic会被捕获并作为错误返回,可以通过 errors.Is(err, kbase.ErrPanic) 判断
 //   - 调用取消函数后，所有正在进行的任务会被终止,如果exec函数一直阻塞,无法完成,会导致goroutine泄露
 //   - 结果通道会在所有任务完成后自动关闭
 //
@@ -168,12 +169,9 @@ func LoopConcAsync[T any, V any](
 					wg.Done()
 				}()
 				var result Result[T, V]
+				var panicErr error
 				func() {
-					defer func() {
-						if r := recover(); r != nil {
-							result.Error = fmt.Errorf("panic: %v, item: %+v, index: %d", r, item, index)
-						}
-					}()
+					defer kbase.Recover(&panicErr)
 					v, err := exec(item)
 					result = Result[T, V]{
 						Key:    index,
@@ -182,6 +180,9 @@ func LoopConcAsync[T any, V any](
 						Error:  err,
 					}
 				}()
+				if panicErr != nil {
+					result.Error = fmt.Errorf("%w, item: %+v, index: %d", panicErr, item, index)
+				}
 				if isCancel.Load() {
 					return
 				}
@@ -311,7 +312,7 @@ func ItemToSlice[T any, V any](s []T, fn func(index int, item T) V) []V {
 //   - []T: 过滤后的新切片
 //
 // 注意事项:
-//   - 如果未提供过滤函数，则默认过滤掉nil值
+//   - 如果未提供过滤函数，则默认过滤掉nil值，需要过滤零值或空值时可以结合 kreflect.IsZero 或 kreflect.IsEmpty
 //   - 返回的新切片长度可能小于原切片
 //
 // 示例:
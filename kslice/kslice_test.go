@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mtgnorton/k/kbase"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -183,6 +184,26 @@ func TestLoopConcAsync(t *testing.T) {
 		assert.Equal(t, "处理数字3时发生错误", errors[2].Error(), "错误消息不匹配")
 	})
 
+	t.Run("panic转换为错误", func(t *testing.T) {
+		resultCh, cancel := LoopConcAsync([]int{1, 2}, func(n int) (int, error) {
+			if n == 2 {
+				panic("boom")
+			}
+			return n, nil
+		})
+		defer cancel()
+
+		var errs []error
+		for result := range resultCh {
+			if result.Error != nil {
+				errs = append(errs, result.Error)
+			}
+		}
+		assert.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], kbase.ErrPanic)
+		assert.EqualError(t, errs[0], "panic: boom, item: 2, index: 1")
+	})
+
 	t.Run("get first result then cancel", func(t *testing.T) {
 		var data []int
 		for i := 0; i < 100000; i++ {
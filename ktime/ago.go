@@ -0,0 +1,130 @@
+package ktime
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	LangZh = "zh"
+	LangEn = "en"
+)
+
+// TimeAgoLocale TimeAgo 使用的语言配置
+type TimeAgoLocale struct {
+	JustNow string // 不到1分钟时的文字,如 "刚刚"
+	Past    string // 过去时间的格式,%s 为时间间隔,如 "%s前"
+	Future  string // 将来时间的格式,如 "%s后"
+	// Units 各单位的格式,依次为分钟、小时、天、月、年,每个单位为单数和复数两种格式,%d 为数量
+	Units [5][2]string
+}
+
+var (
+	timeAgoMu      sync.RWMutex
+	timeAgoLocales = map[string]TimeAgoLocale{
+		LangZh: {
+			JustNow: "刚刚",
+			Past:    "%s前",
+			Future:  "%s后",
+			Units: [5][2]string{
+				{"%d分钟", "%d分钟"},
+				{"%d小时", "%d小时"},
+				{"%d天", "%d天"},
+				{"%d个月", "%d个月"},
+				{"%d年", "%d年"},
+			},
+		},
+		LangEn: {
+			JustNow: "just now",
+			Past:    "%s ago",
+			Future:  "in %s",
+			Units: [5][2]string{
+				{"%d minute", "%d minutes"},
+				{"%d hour", "%d hours"},
+				{"%d day", "%d days"},
+				{"%d month", "%d months"},
+				{"%d year", "%d years"},
+			},
+		},
+	}
+)
+
+// RegisterTimeAgoLocale 注册 TimeAgo 使用的语言,已存在时覆盖
+//
+// 示例:
+//
+//	RegisterTimeAgoLocale("ja", TimeAgoLocale{
+//	    JustNow: "たった今",
+//	    Past:    "%s前",
+//	    Future:  "%s後",
+//	    Units:   [5][2]string{{"%d分", "%d分"}, {"%d時間", "%d時間"}, {"%d日", "%d日"}, {"%dか月", "%dか月"}, {"%d年", "%d年"}},
+//	})
+func RegisterTimeAgoLocale(lang string, locale TimeAgoLocale) {
+	timeAgoMu.Lock()
+	defer timeAgoMu.Unlock()
+	timeAgoLocales[lang] = locale
+}
+
+// TimeAgo 返回t相对于当前时间的描述,如 "3分钟前"、"3 minutes ago"
+//
+// 参数:
+//   - t: 需要描述的时间
+//   - lang: 可选的语言,支持 LangZh、LangEn 和 RegisterTimeAgoLocale 注册的语言,默认为 LangZh
+//
+// 返回值:
+//   - string: 相对时间的描述,t在当前时间之后时返回如 "3分钟后"、"in 3 minutes"
+//
+// 注意事项:
+//   - 不到1分钟时返回 "刚刚"、"just now"
+//   - 按分钟、小时、天、月(30天)、年(365天)中最大的单位向下取整
+//   - 未注册的语言使用 LangZh
+//
+// 示例:
+//
+//	TimeAgo(time.Now().Add(-3 * time.Minute))              // "3分钟前"
+//	TimeAgo(time.Now().Add(-26*time.Hour), LangEn)         // "1 day ago"
+//	TimeAgo(time.Now().Add(2*time.Hour+time.Minute), "en") // "in 2 hours"
+func TimeAgo(t time.Time, lang ...string) string {
+	return timeAgo(t, time.Now(), lang...)
+}
+
+func timeAgo(t, now time.Time, lang ...string) string {
+	timeAgoMu.RLock()
+	locale := timeAgoLocales[LangZh]
+	if len(lang) > 0 {
+		if l, ok := timeAgoLocales[lang[0]]; ok {
+			locale = l
+		}
+	}
+	timeAgoMu.RUnlock()
+
+	d := now.Sub(t)
+	format := locale.Past
+	if d < 0 {
+		d, format = -d, locale.Future
+	}
+	if d < time.Minute {
+		return locale.JustNow
+	}
+
+	var n int64
+	var unit int
+	switch {
+	case d < time.Hour:
+		n, unit = int64(d/time.Minute), 0
+	case d < Day:
+		n, unit = int64(d/time.Hour), 1
+	case d < 30*Day:
+		n, unit = int64(d/Day), 2
+	case d < 365*Day:
+		n, unit = int64(d/(30*Day)), 3
+	default:
+		n, unit = int64(d/(365*Day)), 4
+	}
+	plural := 0
+	if n != 1 {
+		plural = 1
+	}
+	return fmt.Sprintf(format, fmt.Sprintf(locale.Units[unit][plural], n))
+}
@@ -0,0 +1,46 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeAgo(t *testing.T) {
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		offset   time.Duration
+		lang     []string
+		expected string
+	}{
+		{name: "刚刚", offset: -30 * time.Second, expected: "刚刚"},
+		{name: "分钟前", offset: -3 * time.Minute, expected: "3分钟前"},
+		{name: "小时前", offset: -5*time.Hour - 59*time.Minute, expected: "5小时前"},
+		{name: "天前", offset: -26 * time.Hour, expected: "1天前"},
+		{name: "月前", offset: -65 * Day, expected: "2个月前"},
+		{name: "年前", offset: -800 * Day, expected: "2年前"},
+		{name: "之后", offset: 3 * time.Minute, expected: "3分钟后"},
+		{name: "英文刚刚", offset: 10 * time.Second, lang: []string{LangEn}, expected: "just now"},
+		{name: "英文单数", offset: -time.Minute, lang: []string{LangEn}, expected: "1 minute ago"},
+		{name: "英文复数", offset: -3 * time.Hour, lang: []string{LangEn}, expected: "3 hours ago"},
+		{name: "英文之后", offset: 2*time.Hour + time.Minute, lang: []string{LangEn}, expected: "in 2 hours"},
+		{name: "未注册的语言", offset: -time.Minute, lang: []string{"fr"}, expected: "1分钟前"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, timeAgo(now.Add(tt.offset), now, tt.lang...))
+		})
+	}
+}
+
+func TestRegisterTimeAgoLocale(t *testing.T) {
+	RegisterTimeAgoLocale("ja", TimeAgoLocale{
+		JustNow: "たった今",
+		Past:    "%s前",
+		Future:  "%s後",
+		Units:   [5][2]string{{"%d分", "%d分"}, {"%d時間", "%d時間"}, {"%d日", "%d日"}, {"%dか月", "%dか月"}, {"%d年", "%d年"}},
+	})
+	assert.Equal(t, "2時間前", TimeAgo(time.Now().Add(-2*time.Hour-time.Second), "ja"))
+}
@@ -0,0 +1,79 @@
+package ktime
+
+import "time"
+
+// AlignTo 返回t所在的按interval划分的时间桶的开始时间
+//
+// 参数:
+//   - t: 时间
+//   - interval: 时间桶的长度,需要能整除一天,如 time.Minute、5*time.Minute、time.Hour;小于等于0时返回t
+//
+// 返回值:
+//   - time.Time: 不晚于t的最后一个边界,使用t的时区
+//
+// 注意事项:
+//   - 与time.Time.Truncate不同,按t所在时区的wall clock从当天0点开始对齐,如 +05:30 时区的整点
+//   - UTC的时间与 kcollection.RollingWindow 开启 AlignWallClock 时的桶边界一致
+//
+// 示例:
+//
+//	AlignTo(t, time.Hour).Format("2006010215") // 按小时分区的key,如 "2024051512"
+//	AlignTo(t, 5*time.Minute)                  // 12:34:56 -> 12:30:00
+func AlignTo(t time.Time, interval time.Duration) time.Time {
+	if interval <= 0 {
+		return t
+	}
+	return t.Add(-alignElapsed(t, interval))
+}
+
+// nextAligned 返回t之后(不包括t)第一个按t所在时区的wall clock对齐到interval的时间
+func nextAligned(t time.Time, interval time.Duration) time.Time {
+	return t.Add(interval - alignElapsed(t, interval))
+}
+
+// alignElapsed 返回t距离所在时间桶的开始时间经过的时间
+func alignElapsed(t time.Time, interval time.Duration) time.Duration {
+	_, offset := t.Zone()
+	wall := t.UnixNano() + int64(offset)*int64(time.Second)
+	elapsed := time.Duration(wall % int64(interval))
+	if elapsed < 0 {
+		elapsed += interval
+	}
+	return elapsed
+}
+
+// BucketIndex 返回从start开始按interval划分时t所在时间桶的序号
+//
+// 参数:
+//   - t: 时间
+//   - start: 第0个时间桶的开始时间
+//   - interval: 时间桶的长度,小于等于0时返回0
+//
+// 返回值:
+//   - int64: 时间桶的序号,t早于start时为负数,如 start 前1纳秒为 -1
+//
+// 示例:
+//
+//	BucketIndex(t, StartOfDay(t), time.Hour) // t是当天的第几个小时
+func BucketIndex(t, start time.Time, interval time.Duration) int64 {
+	if interval <= 0 {
+		return 0
+	}
+	d := t.Sub(start)
+	index := int64(d / interval)
+	if d%interval < 0 {
+		index--
+	}
+	return index
+}
+
+// BucketRange 返回t所在的按interval划分的时间桶,边界同 AlignTo
+//
+// 示例:
+//
+//	r := BucketRange(t, time.Hour) // [12:00:00, 13:00:00)
+//	rows := query(r.Start, r.End)
+func BucketRange(t time.Time, interval time.Duration) TimeRange {
+	start := AlignTo(t, interval)
+	return TimeRange{Start: start, End: start.Add(max(interval, 0))}
+}
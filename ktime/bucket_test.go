@@ -0,0 +1,92 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlignTo(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	tests := []struct {
+		name     string
+		t        time.Time
+		interval time.Duration
+		want     time.Time
+	}{
+		{"5分钟", time.Date(2024, 5, 15, 12, 34, 56, 0, time.UTC), 5 * time.Minute, time.Date(2024, 5, 15, 12, 30, 0, 0, time.UTC)},
+		{"正好在边界上", time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC), time.Hour, time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)},
+		{"半小时时区的整点", time.Date(2024, 5, 15, 12, 34, 0, 0, kolkata), time.Hour, time.Date(2024, 5, 15, 12, 0, 0, 0, kolkata)},
+		{"按时区的0点对齐", time.Date(2024, 5, 15, 3, 0, 0, 0, kolkata), 24 * time.Hour, time.Date(2024, 5, 15, 0, 0, 0, 0, kolkata)},
+		{"1970年之前", time.Date(1969, 12, 31, 23, 59, 30, 0, time.UTC), time.Minute, time.Date(1969, 12, 31, 23, 59, 0, 0, time.UTC)},
+		{"interval小于等于0", time.Date(2024, 5, 15, 12, 34, 56, 0, time.UTC), 0, time.Date(2024, 5, 15, 12, 34, 56, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AlignTo(tt.t, tt.interval)
+			assert.True(t, tt.want.Equal(got), got)
+			assert.Equal(t, tt.t.Location(), got.Location())
+		})
+	}
+
+	t.Run("分区key", func(t *testing.T) {
+		ts := time.Date(2024, 5, 15, 12, 34, 56, 0, time.UTC)
+		assert.Equal(t, "2024051512", AlignTo(ts, time.Hour).Format("2006010215"))
+	})
+}
+
+func TestNextAligned(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	tests := []struct {
+		name     string
+		t        time.Time
+		interval time.Duration
+		want     time.Time
+	}{
+		{"下一分钟", time.Date(2024, 5, 15, 10, 20, 30, 0, time.UTC), time.Minute, time.Date(2024, 5, 15, 10, 21, 0, 0, time.UTC)},
+		{"正好在边界上时取下一个", time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC), time.Hour, time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC)},
+		{"半小时时区的整点", time.Date(2024, 5, 15, 10, 20, 0, 0, kolkata), time.Hour, time.Date(2024, 5, 15, 11, 0, 0, 0, kolkata)},
+		{"按时区的0点对齐", time.Date(2024, 5, 15, 23, 0, 0, 0, kolkata), 24 * time.Hour, time.Date(2024, 5, 16, 0, 0, 0, 0, kolkata)},
+		{"15分钟", time.Date(2024, 5, 15, 10, 31, 0, 0, time.UTC), 15 * time.Minute, time.Date(2024, 5, 15, 10, 45, 0, 0, time.UTC)},
+		{"1970年之前", time.Date(1969, 12, 31, 23, 59, 30, 0, time.UTC), time.Minute, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(nextAligned(tt.t, tt.interval)), nextAligned(tt.t, tt.interval))
+		})
+	}
+}
+
+func TestBucketIndex(t *testing.T) {
+	start := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		t    time.Time
+		want int64
+	}{
+		{"第0个", start, 0},
+		{"第0个的末尾", start.Add(time.Hour - time.Nanosecond), 0},
+		{"第12个", start.Add(12*time.Hour + 34*time.Minute), 12},
+		{"start之前", start.Add(-time.Nanosecond), -1},
+		{"start之前正好在边界上", start.Add(-2 * time.Hour), -2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BucketIndex(tt.t, start, time.Hour))
+		})
+	}
+	assert.Equal(t, int64(0), BucketIndex(start.Add(time.Hour), start, 0))
+}
+
+func TestBucketRange(t *testing.T) {
+	ts := time.Date(2024, 5, 15, 12, 34, 56, 0, time.UTC)
+	r := BucketRange(ts, time.Hour)
+	assert.Equal(t, TimeRange{
+		Start: time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 5, 15, 13, 0, 0, 0, time.UTC),
+	}, r)
+	assert.True(t, r.Contains(ts))
+	assert.False(t, r.Contains(r.End))
+	assert.Equal(t, BucketIndex(ts, r.Start, time.Hour), int64(0))
+}
@@ -0,0 +1,261 @@
+package ktime
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock 时钟接口,依赖时间的代码通过Clock获取时间和创建定时器,测试时替换为 FakeClock 即可精确控制时间
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, f func()) Timer
+	Sleep(d time.Duration)
+}
+
+// Timer 同time.Timer,通过 Clock 创建
+type Timer interface {
+	C() <-chan time.Time // AfterFunc创建的Timer返回nil
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker 同time.Ticker,通过 Clock 创建
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// RealClock 使用系统时间的时钟
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                  { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration { return time.Since(t) }
+func (realClock) Sleep(d time.Duration)           { time.Sleep(d) }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// RelativeNow 返回clock相对于系统启动时间的时间,使用 RealClock 时同 Now
+func RelativeNow(clock Clock) time.Duration {
+	return clock.Since(initTime)
+}
+
+// FakeClock 可以手动控制的时钟,用于测试
+//
+// 注意事项:
+//   - 时间只在调用 Advance 或 Set 时前进
+//   - 到期的Timer和Ticker与time包一样向缓冲为1的通道发送时间,通道已满时丢弃
+//   - AfterFunc的函数在调用 Advance 的协程中同步执行,Advance返回时已经执行完毕
+//   - 被测代码在其他协程中创建定时器时,先使用 BlockUntil 等待定时器创建后再 Advance
+//
+// 示例:
+//
+//	clock := NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+//	go func() {
+//	    clock.Sleep(time.Minute)
+//	    close(done)
+//	}()
+//	clock.BlockUntil(1)
+//	clock.Advance(time.Minute) // Sleep返回
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter FakeClock 中等待到期的Timer、Ticker或Sleep
+type fakeWaiter struct {
+	clock  *FakeClock
+	when   time.Time
+	period time.Duration // Ticker的周期,Timer为0
+	ch     chan time.Time
+	fn     func()
+}
+
+// NewFakeClock 创建从now开始的 FakeClock
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now 返回当前的模拟时间
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since 返回从t到当前模拟时间经过的时间
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// NewTimer 创建在模拟时间经过d后到期的Timer
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1)}
+	c.schedule(w, d)
+	return w
+}
+
+// NewTicker 创建模拟时间每经过d触发一次的Ticker,d必须大于0,否则会panic
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{clock: c, period: d, ch: make(chan time.Time, 1)}
+	c.schedule(w, d)
+	return fakeTicker{w}
+}
+
+// AfterFunc 创建在模拟时间经过d后执行f的Timer
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	w := &fakeWaiter{clock: c, fn: f}
+	c.schedule(w, d)
+	return w
+}
+
+// Sleep 阻塞直到模拟时间经过d
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.NewTimer(d).C()
+}
+
+// Advance 将模拟时间前进d,并按到期时间的顺序触发其间到期的所有定时器
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set 将模拟时间设置为t,t早于当前时间时只修改时间,不触发定时器
+func (c *FakeClock) Set(t time.Time) {
+	for {
+		c.mu.Lock()
+		if len(c.waiters) == 0 || c.waiters[0].when.After(t) {
+			c.now = t
+			c.mu.Unlock()
+			return
+		}
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		if w.when.After(c.now) {
+			c.now = w.when
+		}
+		now := c.now
+		if w.period > 0 {
+			c.insert(w, now.Add(w.period))
+		}
+		c.mu.Unlock()
+		// 在锁外触发,AfterFunc的函数中可以继续使用时钟
+		w.fire(now)
+	}
+}
+
+// BlockUntil 阻塞直到等待中的Timer、Ticker和Sleep的数量达到n
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// Waiters 返回等待中的Timer、Ticker和Sleep的数量
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// schedule 从当前时间开始经过d后到期
+func (c *FakeClock) schedule(w *fakeWaiter, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.insert(w, c.now.Add(d))
+}
+
+// insert 按到期时间插入,到期时间相同时先插入的先触发,调用时需要持有锁
+func (c *FakeClock) insert(w *fakeWaiter, when time.Time) {
+	w.when = when
+	i := sort.Search(len(c.waiters), func(i int) bool {
+		return c.waiters[i].when.After(when)
+	})
+	c.waiters = append(c.waiters, nil)
+	copy(c.waiters[i+1:], c.waiters[i:])
+	c.waiters[i] = w
+	c.cond.Broadcast()
+}
+
+// remove 移除等待中的w,返回w是否在等待中,调用时需要持有锁
+func (c *FakeClock) remove(w *fakeWaiter) bool {
+	for i, waiter := range c.waiters {
+		if waiter == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (w *fakeWaiter) fire(now time.Time) {
+	if w.fn != nil {
+		w.fn()
+		return
+	}
+	select {
+	case w.ch <- now:
+	default:
+	}
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+// Stop 停止Timer,返回Timer是否在等待中
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.remove(w)
+}
+
+// Reset 重新设置到期时间,返回Timer之前是否在等待中
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := w.clock.remove(w)
+	if w.period > 0 {
+		w.period = d
+	}
+	w.clock.insert(w, w.clock.now.Add(d))
+	return active
+}
+
+// fakeTicker 使Ticker的Stop和Reset没有返回值
+type fakeTicker struct{ *fakeWaiter }
+
+func (t fakeTicker) Stop()                 { t.fakeWaiter.Stop() }
+func (t fakeTicker) Reset(d time.Duration) { t.fakeWaiter.Reset(d) }
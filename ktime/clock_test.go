@@ -0,0 +1,104 @@
+package ktime
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealClock(t *testing.T) {
+	start := RealClock.Now()
+	timer := RealClock.NewTimer(time.Millisecond)
+	<-timer.C()
+	assert.GreaterOrEqual(t, RealClock.Since(start), time.Millisecond)
+
+	ticker := RealClock.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+
+	fired := make(chan struct{})
+	RealClock.AfterFunc(time.Millisecond, func() { close(fired) })
+	<-fired
+
+	assert.InDelta(t, float64(Now()), float64(RelativeNow(RealClock)), float64(time.Second))
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	start := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	timer := clock.NewTimer(time.Minute)
+	clock.Advance(59 * time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-timer.C())
+	assert.Equal(t, time.Minute, clock.Since(start))
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(time.Second))
+	assert.True(t, timer.Stop())
+	clock.Advance(time.Hour)
+	assert.Equal(t, 0, clock.Waiters())
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ticker := clock.NewTicker(10 * time.Second)
+	for i := 1; i <= 3; i++ {
+		clock.Advance(10 * time.Second)
+		assert.Equal(t, time.Unix(int64(i*10), 0), <-ticker.C())
+	}
+
+	// 通道已满时丢弃
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, time.Unix(40, 0), <-ticker.C())
+	assert.Len(t, ticker.C(), 0)
+
+	ticker.Reset(time.Second)
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Unix(61, 0), <-ticker.C())
+	ticker.Stop()
+	assert.Equal(t, 0, clock.Waiters())
+	assert.Panics(t, func() { clock.NewTicker(0) })
+}
+
+func TestFakeClockAfterFunc(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var order []int
+	clock.AfterFunc(2*time.Second, func() { order = append(order, 2) })
+	clock.AfterFunc(time.Second, func() {
+		order = append(order, 1)
+		// 函数中可以继续使用时钟
+		assert.Equal(t, time.Unix(1, 0), clock.Now())
+		clock.AfterFunc(time.Second, func() { order = append(order, 3) })
+	})
+	stopped := clock.AfterFunc(time.Second, func() { order = append(order, -1) })
+	assert.True(t, stopped.Stop())
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, []int{1, 2, 3}, order)
+	assert.Equal(t, time.Unix(5, 0), clock.Now())
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var woke atomic.Bool
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Minute)
+		woke.Store(true)
+		close(done)
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(30 * time.Second)
+	assert.False(t, woke.Load())
+	clock.Advance(30 * time.Second)
+	<-done
+	assert.True(t, woke.Load())
+}
@@ -0,0 +1,296 @@
+package ktime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidCron = errors.New("invalid cron expression")
+)
+
+// cronSearchYears Next 和 Prev 最多查找的年数,超过时认为表达式永远不会匹配,如 "0 0 30 2 *"
+const cronSearchYears = 5
+
+// cronField cron表达式中一个字段的取值范围和别名
+type cronField struct {
+	name     string
+	min, max int
+	// openMax "*" 和 "5/15" 等没有结束值的形式展开到的最大值,为0时使用max
+	openMax int
+	names   map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 星期的7也表示周日,解析后统一为0;只有显式写出的7表示周日,"*" 和 "1/2" 只展开到6,否则 "1/2" 会包含周日
+	cronDow = cronField{name: "day of week", min: 0, max: 7, openMax: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronDescriptors 预定义的表达式
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// CronSchedule 解析后的cron表达式,每个字段使用位图记录匹配的值
+type CronSchedule struct {
+	expr                                  string
+	second, minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted          bool
+}
+
+// ParseCron 解析cron表达式
+//
+// 参数:
+//   - expr: cron表达式,支持5个字段 "分 时 日 月 星期",或者6个字段 "秒 分 时 日 月 星期",
+//     也支持 @yearly、@monthly、@weekly、@daily、@hourly 等预定义表达式
+//
+// 返回值:
+//   - *CronSchedule: 解析后的表达式,使用 Next 和 Prev 计算运行时间
+//   - error: 表达式格式错误时返回 ErrInvalidCron
+//
+// 注意事项:
+//   - 每个字段支持 "*"、"?"、数字、范围 "1-5"、列表 "1,3,5"、步长 "*/15"、"0-30/10"、"5/15"
+//   - 月份和星期支持英文缩写,不区分大小写,如 "JAN"、"mon-fri",星期的0和7都表示周日
+//   - 与标准cron一致,日和星期都不是 "*" 时,满足其中一个即可匹配
+//
+// 示例:
+//
+//	s, err := ParseCron("*/15 9-18 * * mon-fri") // 工作日9点到18点每15分钟
+//	next := s.Next(time.Now())
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		descriptor, ok := cronDescriptors[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidCron, "%q: unknown descriptor", expr)
+		}
+		fields = strings.Fields(descriptor)
+	}
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, errors.Wrapf(ErrInvalidCron, "%q: expected 5 or 6 fields, got %d", expr, len(fields))
+	}
+
+	s := &CronSchedule{expr: expr}
+	var err error
+	for i, f := range []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&s.second, cronSecond},
+		{&s.minute, cronMinute},
+		{&s.hour, cronHour},
+		{&s.dom, cronDom},
+		{&s.month, cronMonth},
+		{&s.dow, cronDow},
+	} {
+		if *f.bits, err = parseCronField(fields[i], f.field); err != nil {
+			return nil, errors.WithMessagef(err, "%q", expr)
+		}
+	}
+	// 星期的7转换为0
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domRestricted = !isCronWildcard(fields[3])
+	s.dowRestricted = !isCronWildcard(fields[5])
+	return s, nil
+}
+
+// MustParseCron 同 ParseCron,解析失败时panic
+func MustParseCron(expr string) *CronSchedule {
+	s, err := ParseCron(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// isCronWildcard 判断字段是否为 "*" 或 "?",不包括 "*/2" 等带步长的形式
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseCronField 解析一个字段,返回匹配值的位图
+func parseCronField(expr string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n <= 0 {
+				return 0, errors.Wrapf(ErrInvalidCron, "%s: invalid step %q", field.name, item)
+			}
+			step = n
+		}
+
+		openMax := field.max
+		if field.openMax > 0 {
+			openMax = field.openMax
+		}
+		var start, end int
+		switch {
+		case rangeExpr == "*" || rangeExpr == "?":
+			start, end = field.min, openMax
+		default:
+			lo, hi, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if start, err = parseCronValue(lo, field); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseCronValue(hi, field); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = max(openMax, start)
+			}
+			if start > end {
+				return 0, errors.Wrapf(ErrInvalidCron, "%s: invalid range %q", field.name, item)
+			}
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue 解析单个数字或者别名
+func parseCronValue(s string, field cronField) (int, error) {
+	if v, ok := field.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < field.min || v > field.max {
+		return 0, errors.Wrapf(ErrInvalidCron, "%s: invalid value %q", field.name, s)
+	}
+	return v, nil
+}
+
+// String 返回原始的表达式
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next 返回after之后(不包括after)第一个匹配的时间
+//
+// 参数:
+//   - after: 开始查找的时间,结果使用after的时区
+//
+// 返回值:
+//   - time.Time: 下一次运行的时间,精确到秒,5年内都没有匹配的时间时返回零值
+//
+// 注意事项:
+//   - 在after所在的时区计算,需要其他时区时先使用 after.In(loc) 转换
+//   - 夏令时开始时跳过的时刻不会匹配,结束时重复的时刻只匹配第一次
+//
+// 示例:
+//
+//	s := MustParseCron("0 3 * * *")
+//	next := s.Next(time.Now()) // 下一个凌晨3点
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + cronSearchYears
+	for t.Year() <= limit {
+		y, m, d := t.Date()
+		switch {
+		case !s.matchMonth(t):
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case !s.matchHour(t):
+			t = cronForward(t, time.Date(y, m, d, t.Hour()+1, 0, 0, 0, loc), time.Hour-time.Duration(t.Minute())*time.Minute)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = cronForward(t, time.Date(y, m, d, t.Hour(), t.Minute()+1, 0, 0, loc), time.Minute)
+		case s.second&(1<<t.Second()) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// cronForward 按wall clock前进到candidate,夏令时结束时会跳过重复的时刻;
+// 在重复的时刻中candidate可能早于t,此时按绝对时间前进,d为到下一个整点或整分钟(忽略秒)的时间
+func cronForward(t, candidate time.Time, d time.Duration) time.Time {
+	if candidate.After(t) {
+		return candidate
+	}
+	return t.Add(d - time.Duration(t.Second())*time.Second)
+}
+
+// Prev 返回before之前(不包括before)最后一个匹配的时间,5年内都没有匹配的时间时返回零值,其他同 Next
+//
+// 示例:
+//
+//	s := MustParseCron("0 3 * * *")
+//	last := s.Prev(time.Now()) // 上一个凌晨3点,可用于判断服务停止期间是否错过了运行
+func (s *CronSchedule) Prev(before time.Time) time.Time {
+	loc := before.Location()
+	t := before.Add(-time.Nanosecond).Truncate(time.Second)
+	limit := t.Year() - cronSearchYears
+	for t.Year() >= limit {
+		y, m, d := t.Date()
+		switch {
+		case !s.matchMonth(t):
+			t = time.Date(y, m, 1, 0, 0, 0, 0, loc).Add(-time.Second)
+		case !s.matchDay(t):
+			t = time.Date(y, m, d, 0, 0, 0, 0, loc).Add(-time.Second)
+		case !s.matchHour(t):
+			// 按wall clock回到上一个小时的最后一秒,不使用Truncate,Truncate按UTC取整,在半小时时区中会出错
+			t = t.Add(-time.Duration(t.Minute()*60+t.Second()+1) * time.Second)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(-time.Duration(t.Second()+1) * time.Second)
+		case s.second&(1<<t.Second()) == 0:
+			t = t.Add(-time.Second)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchMonth(t time.Time) bool {
+	return s.month&(1<<t.Month()) != 0
+}
+
+func (s *CronSchedule) matchHour(t time.Time) bool {
+	return s.hour&(1<<t.Hour()) != 0
+}
+
+// matchDay 日和星期都有限制时满足其中一个即可,否则需要都满足
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<t.Day()) != 0
+	dowMatch := s.dow&(1<<t.Weekday()) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
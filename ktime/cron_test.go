@@ -0,0 +1,127 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronError(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"* * * foo *",
+		"@every",
+	} {
+		_, err := ParseCron(expr)
+		assert.ErrorIs(t, err, ErrInvalidCron, expr)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// 2024-05-15 是周三
+	base := time.Date(2024, 5, 15, 10, 20, 30, 500, time.UTC)
+	tests := []struct {
+		name     string
+		expr     string
+		after    time.Time
+		expected time.Time
+	}{
+		{"每分钟", "* * * * *", base, time.Date(2024, 5, 15, 10, 21, 0, 0, time.UTC)},
+		{"不包括after本身", "21 10 * * *", time.Date(2024, 5, 15, 10, 21, 0, 0, time.UTC), time.Date(2024, 5, 16, 10, 21, 0, 0, time.UTC)},
+		{"步长", "*/15 * * * *", base, time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)},
+		{"范围和步长", "0-30/10 11 * * *", base, time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC)},
+		{"起始值和步长", "5/20 * * * *", base, time.Date(2024, 5, 15, 10, 25, 0, 0, time.UTC)},
+		{"列表", "0 8,12,18 * * *", base, time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)},
+		{"工作日", "0 9 * * mon-fri", time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC), time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC)},
+		{"星期7为周日", "0 0 * * 7", base, time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{"星期的起始值和步长不包括周日", "0 0 * * 1/2", time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)},
+		{"星期的步长不包括周日", "0 0 * * */3", time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 18, 0, 0, 0, 0, time.UTC)},
+		{"星期的范围到7包括周日", "0 0 * * 5-7", time.Date(2024, 5, 18, 1, 0, 0, 0, time.UTC), time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{"月份别名", "0 0 1 JAN *", base, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"日和星期满足其一", "0 0 1 * fri", base, time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+		{"问号", "0 0 ? * fri", base, time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+		{"月末31日", "0 0 31 * *", base, time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)},
+		{"闰日", "0 0 29 2 *", base, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"秒字段", "*/10 * * * * *", base, time.Date(2024, 5, 15, 10, 20, 40, 0, time.UTC)},
+		{"预定义", "@daily", base, time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)},
+		{"预定义每周", "@weekly", base, time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{"跨年", "0 0 1 1 *", time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"不会匹配", "0 0 30 2 *", base, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseCron(tt.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Next(tt.after))
+		})
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	base := time.Date(2024, 5, 15, 10, 20, 30, 500, time.UTC)
+	tests := []struct {
+		name     string
+		expr     string
+		before   time.Time
+		expected time.Time
+	}{
+		{"每分钟", "* * * * *", base, time.Date(2024, 5, 15, 10, 20, 0, 0, time.UTC)},
+		{"不包括before本身", "20 10 * * *", time.Date(2024, 5, 15, 10, 20, 0, 0, time.UTC), time.Date(2024, 5, 14, 10, 20, 0, 0, time.UTC)},
+		{"每天凌晨3点", "0 3 * * *", base, time.Date(2024, 5, 15, 3, 0, 0, 0, time.UTC)},
+		{"上个月", "30 23 10 * *", base, time.Date(2024, 5, 10, 23, 30, 0, 0, time.UTC)},
+		{"跨年", "0 12 31 12 *", base, time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC)},
+		{"秒字段", "15 * * * * *", base, time.Date(2024, 5, 15, 10, 20, 15, 0, time.UTC)},
+		{"不会匹配", "0 0 31 4 *", base, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MustParseCron(tt.expr).Prev(tt.before))
+		})
+	}
+}
+
+func TestCronNextPrevConsistent(t *testing.T) {
+	s := MustParseCron("7 */5 1-10 * mon")
+	cur := s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	for i := 0; i < 50; i++ {
+		next := s.Next(cur)
+		assert.True(t, next.After(cur))
+		assert.Equal(t, cur, s.Prev(next), "prev of %v", next)
+		cur = next
+	}
+}
+
+func TestCronZone(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	s := MustParseCron("45 9 * * *")
+	after := time.Date(2024, 5, 15, 10, 15, 0, 0, kolkata)
+	assert.Equal(t, time.Date(2024, 5, 15, 9, 45, 0, 0, kolkata), s.Prev(after))
+	assert.Equal(t, time.Date(2024, 5, 16, 9, 45, 0, 0, kolkata), s.Next(after))
+}
+
+func TestCronDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	// 2024-11-03 1:00-2:00 重复两次,只匹配第一次
+	s := MustParseCron("30 1 * * *")
+	first := s.Next(time.Date(2024, 11, 3, 0, 0, 0, 0, loc))
+	assert.Equal(t, "2024-11-03 01:30:00 EDT", first.Format("2006-01-02 15:04:05 MST"))
+	assert.Equal(t, "2024-11-04 01:30:00 EST", s.Next(first).Format("2006-01-02 15:04:05 MST"))
+
+	// 2024-03-10 2:00-3:00 不存在
+	s = MustParseCron("30 2 * * *")
+	assert.Equal(t, "2024-03-11 02:30:00 EDT", s.Next(time.Date(2024, 3, 10, 0, 0, 0, 0, loc)).Format("2006-01-02 15:04:05 MST"))
+}
@@ -0,0 +1,129 @@
+package ktime
+
+import (
+	"sync"
+	"time"
+)
+
+// DeadlineOptions 截止时间的配置项
+type DeadlineOptions struct {
+	Clock Clock // 计时使用的时钟
+}
+
+type DeadlineOption func(*DeadlineOptions)
+
+func NewDeadlineOptions() *DeadlineOptions {
+	return &DeadlineOptions{
+		Clock: RealClock,
+	}
+}
+
+// WithDeadlineClock 设置计时使用的时钟,默认为 RealClock
+func WithDeadlineClock(clock Clock) DeadlineOption {
+	return func(o *DeadlineOptions) {
+		o.Clock = clock
+	}
+}
+
+// Deadline 截止时间,可以查询剩余时间,并在到期时关闭 Done 返回的通道
+//
+// 注意事项:
+//   - 线程安全
+//   - 不再需要时调用 Stop 释放定时器
+type Deadline struct {
+	at    time.Time
+	clock Clock
+	done  chan struct{}
+	once  sync.Once
+	timer Timer
+}
+
+// NewDeadline 创建从当前时间开始经过d后到期的截止时间
+//
+// 参数:
+//   - d: 剩余时间,小于等于0时立即到期
+//   - opts: 可选配置项,参见 DeadlineOptions
+//
+// 示例:
+//
+//	deadline := NewDeadline(30 * time.Second)
+//	defer deadline.Stop()
+//	select {
+//	case <-finished:
+//	case <-deadline.Done():
+//	    log.Println("shutdown timeout")
+//	}
+func NewDeadline(d time.Duration, opts ...DeadlineOption) *Deadline {
+	o := NewDeadlineOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return newDeadline(o.Clock.Now().Add(d), o.Clock)
+}
+
+// DeadlineAt 创建在t到期的截止时间,t早于当前时间时立即到期,opts同 NewDeadline
+func DeadlineAt(t time.Time, opts ...DeadlineOption) *Deadline {
+	o := NewDeadlineOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return newDeadline(t, o.Clock)
+}
+
+func newDeadline(at time.Time, clock Clock) *Deadline {
+	d := &Deadline{at: at, clock: clock, done: make(chan struct{})}
+	remaining := at.Sub(clock.Now())
+	if remaining <= 0 {
+		d.expire()
+		return d
+	}
+	d.timer = clock.AfterFunc(remaining, d.expire)
+	return d
+}
+
+func (d *Deadline) expire() {
+	d.once.Do(func() {
+		close(d.done)
+	})
+}
+
+// At 返回到期的时间
+func (d *Deadline) At() time.Time {
+	return d.at
+}
+
+// Remaining 返回剩余时间,已经到期时返回0
+func (d *Deadline) Remaining() time.Duration {
+	return max(d.at.Sub(d.clock.Now()), 0)
+}
+
+// Expired 判断是否已经到期
+func (d *Deadline) Expired() bool {
+	return !d.clock.Now().Before(d.at)
+}
+
+// Done 返回到期时关闭的通道,只会关闭一次
+func (d *Deadline) Done() <-chan struct{} {
+	return d.done
+}
+
+// Stop 停止定时器,之后 Done 返回的通道不会再关闭,Remaining 和 Expired 不受影响
+//
+// 返回值:
+//   - bool: 是否在到期前停止
+func (d *Deadline) Stop() bool {
+	if d.timer == nil {
+		return false
+	}
+	return d.timer.Stop()
+}
+
+// WithGrace 返回在当前截止时间之后再经过grace到期的截止时间,使用相同的时钟
+//
+// 示例:
+//
+//	soft := NewDeadline(30 * time.Second)   // 到期后停止接收新请求
+//	hard := soft.WithGrace(5 * time.Second) // 再经过5秒强制退出
+func (d *Deadline) WithGrace(grace time.Duration) *Deadline {
+	return newDeadline(d.at.Add(grace), d.clock)
+}
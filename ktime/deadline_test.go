@@ -0,0 +1,81 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadline(t *testing.T) {
+	start := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("到期", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		d := NewDeadline(time.Minute, WithDeadlineClock(clock))
+		assert.Equal(t, start.Add(time.Minute), d.At())
+		assert.Equal(t, time.Minute, d.Remaining())
+		assert.False(t, d.Expired())
+
+		clock.Advance(40 * time.Second)
+		assert.Equal(t, 20*time.Second, d.Remaining())
+		select {
+		case <-d.Done():
+			t.Fatal("未到期不应该关闭通道")
+		default:
+		}
+
+		clock.Advance(20 * time.Second)
+		assert.True(t, d.Expired())
+		assert.Equal(t, time.Duration(0), d.Remaining())
+		<-d.Done()
+		clock.Advance(time.Minute)
+		assert.Equal(t, time.Duration(0), d.Remaining())
+		assert.False(t, d.Stop(), "已经到期")
+	})
+
+	t.Run("已经过去的时间立即到期", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		d := DeadlineAt(start.Add(-time.Second), WithDeadlineClock(clock))
+		assert.True(t, d.Expired())
+		<-d.Done()
+		<-NewDeadline(0, WithDeadlineClock(clock)).Done()
+	})
+
+	t.Run("停止", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		d := NewDeadline(time.Minute, WithDeadlineClock(clock))
+		assert.True(t, d.Stop())
+		clock.Advance(time.Hour)
+		assert.True(t, d.Expired())
+		select {
+		case <-d.Done():
+			t.Fatal("停止后不应该关闭通道")
+		default:
+		}
+	})
+
+	t.Run("宽限期", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		soft := NewDeadline(30*time.Second, WithDeadlineClock(clock))
+		hard := soft.WithGrace(5 * time.Second)
+		assert.Equal(t, start.Add(35*time.Second), hard.At())
+
+		clock.Advance(30 * time.Second)
+		<-soft.Done()
+		assert.False(t, hard.Expired())
+		assert.Equal(t, 5*time.Second, hard.Remaining())
+		clock.Advance(5 * time.Second)
+		<-hard.Done()
+	})
+
+	t.Run("真实时钟", func(t *testing.T) {
+		d := NewDeadline(10 * time.Millisecond)
+		select {
+		case <-d.Done():
+		case <-time.After(time.Second):
+			t.Fatal("应该到期")
+		}
+		assert.True(t, d.Expired())
+	})
+}
@@ -0,0 +1,95 @@
+package ktime
+
+import (
+	"context"
+	"time"
+)
+
+// Drift 返回系统时钟相对于单调时钟的偏移
+//
+// 返回值:
+//   - time.Duration: 当前系统时钟减去按单调时钟推算的时间,正数表示系统时钟被向前调整,负数表示被向后调整
+//
+// 注意事项:
+//   - Now 和 Since 基于单调时钟,不受系统时钟调整的影响;而 time.Now() 的墙上时间、时间戳和日志时间会随系统时钟跳变,
+//     偏移较大时两者计算的时间窗口会错位
+//   - NTP平滑校时也会使偏移缓慢变化,需要关注的是偏移的突变,参见 WatchDrift
+//
+// 示例:
+//
+//	if d := Drift(); d > time.Second || d < -time.Second {
+//	    log.Printf("system clock stepped by %s", d)
+//	}
+func Drift() time.Duration {
+	return drift(time.Now(), initTime)
+}
+
+// drift 返回now的墙上时间减去从base的墙上时间经过单调时钟的时间差后的时间
+func drift(now, base time.Time) time.Duration {
+	// Round(0) 去掉单调时钟,只比较墙上时间
+	return now.Round(0).Sub(base.Round(0)) - now.Sub(base)
+}
+
+// DriftOptions WatchDrift 的配置项
+type DriftOptions struct {
+	Interval time.Duration // 检查偏移的间隔
+}
+
+type DriftOption func(*DriftOptions)
+
+func NewDriftOptions() *DriftOptions {
+	return &DriftOptions{
+		Interval: 10 * time.Second,
+	}
+}
+
+// WithDriftInterval 设置检查偏移的间隔,默认为10秒
+func WithDriftInterval(interval time.Duration) DriftOption {
+	return func(o *DriftOptions) {
+		o.Interval = interval
+	}
+}
+
+// WatchDrift 定期检查 Drift,偏移的变化超过阈值时调用处理函数,用于发现虚拟机暂停恢复、手动修改时间等系统时钟跳变
+//
+// 参数:
+//   - ctx: 上下文,ctx结束时停止检查
+//   - threshold: 阈值,偏移与上次报告时(首次为开始检查时)相比变化超过该值时调用handler
+//   - handler: 处理函数,参数为变化量和当前的偏移,在检查的协程中同步执行
+//   - opts: 可选配置项,参见 DriftOptions
+//
+// 注意事项:
+//   - 开始检查前已经存在的偏移不会报告,每次跳变只报告一次
+//   - 在独立的协程中检查,立即返回
+//
+// 示例:
+//
+//	WatchDrift(ctx, time.Second, func(delta, drift time.Duration) {
+//	    log.Printf("system clock stepped by %s, total drift %s", delta, drift)
+//	    window.Reset() // 基于墙上时间的窗口需要重建
+//	})
+func WatchDrift(ctx context.Context, threshold time.Duration, handler func(delta, drift time.Duration), opts ...DriftOption) {
+	o := NewDriftOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	go watchDrift(ctx, threshold, handler, o.Interval, Drift)
+}
+
+func watchDrift(ctx context.Context, threshold time.Duration, handler func(delta, drift time.Duration), interval time.Duration, measure func() time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	baseline := measure()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		d := measure()
+		if delta := d - baseline; delta > threshold || delta < -threshold {
+			baseline = d
+			handler(delta, d)
+		}
+	}
+}
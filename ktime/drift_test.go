@@ -0,0 +1,62 @@
+package ktime
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrift(t *testing.T) {
+	assert.InDelta(t, 0, float64(Drift()), float64(time.Second), "系统时钟没有被调整")
+
+	base := time.Now()
+	assert.Equal(t, time.Duration(0), drift(base.Add(time.Minute), base))
+	// 不包含单调时钟时只能比较墙上时间
+	assert.Equal(t, time.Duration(0), drift(base.Round(0).Add(time.Minute), base))
+}
+
+func TestWatchDrift(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		current = 5 * time.Second // 开始检查前已经存在的偏移
+		reports [][2]time.Duration
+	)
+	measure := func() time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return current
+	}
+	setDrift := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		current = d
+	}
+	getReports := func() [][2]time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([][2]time.Duration(nil), reports...)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchDrift(ctx, time.Second, func(delta, drift time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, [2]time.Duration{delta, drift})
+	}, time.Millisecond, measure)
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, getReports(), "开始检查前的偏移不报告")
+
+	setDrift(5*time.Second + 500*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, getReports(), "未超过阈值")
+
+	setDrift(-time.Minute)
+	assert.Eventually(t, func() bool { return len(getReports()) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, [][2]time.Duration{{-time.Minute - 5*time.Second, -time.Minute}}, getReports(), "每次跳变只报告一次")
+}
@@ -0,0 +1,185 @@
+package ktime
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidDuration = errors.New("invalid duration")
+)
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// durationUnits FormatDuration 使用的单位,从大到小排列
+var durationUnits = []struct {
+	name string
+	unit time.Duration
+}{
+	{"d", Day},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"µs", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// parseUnits ParseHumanDuration 支持的单位
+var parseUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond, // U+00B5
+	"μs": time.Microsecond, // U+03BC
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  Day,
+	"w":  Week,
+}
+
+// FormatDuration 将时间间隔格式化为易读的字符串
+//
+// 参数:
+//   - d: 时间间隔
+//   - precision: 最多显示的单位数量,从最大的非零单位开始计算,小于等于0时显示到纳秒
+//
+// 返回值:
+//   - string: 格式化后的字符串,如 "2h 3m 10s"、"1d 4h",单位依次为d、h、m、s、ms、µs、ns
+//
+// 注意事项:
+//   - 超出精度的部分直接舍去,不会四舍五入
+//   - 精度范围内值为0的单位不显示,如 "1d 5m"
+//   - 0返回 "0s",负数带有 "-" 前缀
+//
+// 示例:
+//
+//	FormatDuration(2*time.Hour+3*time.Minute+10*time.Second, 0) // "2h 3m 10s"
+//	FormatDuration(28*time.Hour+30*time.Minute, 2)             // "1d 4h"
+//	FormatDuration(1500*time.Millisecond, 0)                   // "1s 500ms"
+func FormatDuration(d time.Duration, precision int) string {
+	if d == 0 {
+		return "0s"
+	}
+	sign := ""
+	// 使用uint64避免math.MinInt64取反溢出
+	rest := uint64(d)
+	if d < 0 {
+		sign = "-"
+		rest = -rest
+	}
+
+	parts := make([]string, 0, len(durationUnits))
+	counted := 0
+	for _, u := range durationUnits {
+		n := rest / uint64(u.unit)
+		rest %= uint64(u.unit)
+		if n == 0 && counted == 0 {
+			continue
+		}
+		if n > 0 {
+			parts = append(parts, strconv.FormatUint(n, 10)+u.name)
+		}
+		counted++
+		if precision > 0 && counted >= precision {
+			break
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// ParseHumanDuration 解析易读的时间间隔字符串,在time.ParseDuration的基础上支持天和周
+//
+// 参数:
+//   - s: 时间间隔字符串,如 "1d2h30m"、"1w"、"1.5d"、"2h 30m"
+//
+// 返回值:
+//   - time.Duration: 解析后的时间间隔
+//   - error: 格式错误或溢出时返回 ErrInvalidDuration
+//
+// 注意事项:
+//   - 支持的单位: ns、us(µs)、ms、s、m、h、d(24小时)、w(7天)
+//   - 数字和单位之间、各部分之间可以有空白,可以带有 "+"、"-" 前缀
+//   - 与time.ParseDuration一样,只有 "0" 可以省略单位
+//   - 天按固定的24小时计算,不考虑夏令时
+//
+// 示例:
+//
+//	d, err := ParseHumanDuration("1d2h30m") // 26h30m0s, nil
+//	d, err = ParseHumanDuration("2w")       // 336h0m0s, nil
+func ParseHumanDuration(s string) (time.Duration, error) {
+	orig := s
+	s = strings.Join(strings.Fields(s), "")
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, errors.Wrapf(ErrInvalidDuration, "%q", orig)
+	}
+
+	var total uint64
+	for s != "" {
+		i := 0
+		for i < len(s) && (s[i] == '.' || s[i] >= '0' && s[i] <= '9') {
+			i++
+		}
+		number := s[:i]
+		s = s[i:]
+		i = 0
+		for i < len(s) && s[i] != '.' && (s[i] < '0' || s[i] > '9') {
+			i++
+		}
+		unit, ok := parseUnits[s[:i]]
+		if !ok {
+			return 0, errors.Wrapf(ErrInvalidDuration, "%q: unknown unit %q", orig, s[:i])
+		}
+		s = s[i:]
+
+		v, ok := durationValue(number, unit)
+		if !ok || total+v < total || total+v > math.MaxInt64 {
+			return 0, errors.Wrapf(ErrInvalidDuration, "%q: invalid number %q", orig, number)
+		}
+		total += v
+	}
+	if neg {
+		return -time.Duration(total), nil
+	}
+	return time.Duration(total), nil
+}
+
+// durationValue 计算 number*unit,整数部分使用整数运算,避免大数值丢失精度
+func durationValue(number string, unit time.Duration) (uint64, bool) {
+	whole, frac, _ := strings.Cut(number, ".")
+	if whole == "" && frac == "" || strings.Contains(frac, ".") {
+		return 0, false
+	}
+	var v uint64
+	if whole != "" {
+		n, err := strconv.ParseUint(whole, 10, 64)
+		if err != nil || n > math.MaxInt64/uint64(unit) {
+			return 0, false
+		}
+		v = n * uint64(unit)
+	}
+	if frac != "" {
+		f, err := strconv.ParseFloat("0."+frac, 64)
+		if err != nil {
+			return 0, false
+		}
+		v += uint64(math.Round(f * float64(unit)))
+	}
+	return v, true
+}
@@ -0,0 +1,80 @@
+package ktime
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		d         time.Duration
+		precision int
+		expected  string
+	}{
+		{name: "零", d: 0, expected: "0s"},
+		{name: "时分秒", d: 2*time.Hour + 3*time.Minute + 10*time.Second, expected: "2h 3m 10s"},
+		{name: "天", d: 28*time.Hour + 30*time.Minute, precision: 2, expected: "1d 4h"},
+		{name: "跳过零值单位", d: Day + 5*time.Minute, expected: "1d 5m"},
+		{name: "精度内的零值单位也计数", d: Day + 5*time.Minute, precision: 2, expected: "1d"},
+		{name: "毫秒", d: 1500 * time.Millisecond, expected: "1s 500ms"},
+		{name: "微秒和纳秒", d: 1501 * time.Nanosecond, expected: "1µs 501ns"},
+		{name: "小于一秒", d: 500 * time.Millisecond, precision: 1, expected: "500ms"},
+		{name: "负数", d: -90 * time.Second, expected: "-1m 30s"},
+		{name: "最小值", d: math.MinInt64, precision: 1, expected: "-106751d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatDuration(tt.d, tt.precision))
+		})
+	}
+}
+
+func TestParseHumanDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{name: "天时分", input: "1d2h30m", expected: 26*time.Hour + 30*time.Minute},
+		{name: "周", input: "2w", expected: 2 * Week},
+		{name: "小数", input: "1.5d", expected: 36 * time.Hour},
+		{name: "只有小数部分", input: ".5h", expected: 30 * time.Minute},
+		{name: "空白", input: " 2h 30m ", expected: 2*time.Hour + 30*time.Minute},
+		{name: "毫秒微秒纳秒", input: "1ms2us3ns", expected: time.Millisecond + 2*time.Microsecond + 3},
+		{name: "希腊字母微秒", input: "1μs", expected: time.Microsecond},
+		{name: "负数", input: "-1d", expected: -Day},
+		{name: "正号", input: "+1m", expected: time.Minute},
+		{name: "零", input: "0", expected: 0},
+		{name: "与ParseDuration一致", input: "1h15m30.918273645s", expected: time.Hour + 15*time.Minute + 30918273645},
+		{name: "空字符串", input: "", expectError: true},
+		{name: "缺少单位", input: "10", expectError: true},
+		{name: "未知单位", input: "1y", expectError: true},
+		{name: "缺少数字", input: "d", expectError: true},
+		{name: "多个小数点", input: "1.2.3s", expectError: true},
+		{name: "溢出", input: "20000w", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseHumanDuration(tt.input)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidDuration)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{time.Nanosecond, 3*Day + 4*time.Hour + 5*time.Second + 6*time.Millisecond, -Week} {
+		result, err := ParseHumanDuration(FormatDuration(d, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, d, result)
+	}
+}
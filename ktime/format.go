@@ -0,0 +1,105 @@
+package ktime
+
+import "time"
+
+// Formatter 固定时区和格式的时间戳格式化器,只在创建时解析一次时区
+//
+// 注意事项:
+//   - 线程安全,可以在多个协程中共享
+//   - 时间戳为秒级,单位不确定时先使用 DetectUnit 判断并转换
+type Formatter struct {
+	format   string
+	location *time.Location
+}
+
+// NewFormatter 创建时间戳格式化器
+//
+// 参数:
+//   - format: 时间格式化模板,如 "2006-01-02 15:04:05"
+//   - zone: 可选的时区,格式同 LoadZone,默认使用 UTC+8
+//
+// 返回值:
+//   - *Formatter: 格式化器
+//   - error: 时区格式无效时返回包装了 ErrInvalidZoneFormat 的错误,使用 errors.Is 判断
+//
+// 示例:
+//
+//	f, err := NewFormatter("2006-01-02 15:04:05", "Asia/Shanghai")
+//	for _, row := range rows {
+//	    w.Write([]string{row.Name, f.Format(row.CreatedAt)})
+//	}
+func NewFormatter(format string, zone ...string) (*Formatter, error) {
+	location := defaultLocation
+	if len(zone) > 0 {
+		loc, err := LoadZone(zone[0])
+		if err != nil {
+			return nil, err
+		}
+		location = loc
+	}
+	return &Formatter{format: format, location: location}, nil
+}
+
+// MustNewFormatter 同 NewFormatter,创建失败时panic
+func MustNewFormatter(format string, zone ...string) *Formatter {
+	f, err := NewFormatter(format, zone...)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Location 返回格式化使用的时区
+func (f *Formatter) Location() *time.Location {
+	return f.location
+}
+
+// Format 格式化秒级时间戳,结果同 ConvertToZoneTimeStr
+func (f *Formatter) Format(timestamp int64) string {
+	return time.Unix(timestamp, 0).In(f.location).Format(f.format)
+}
+
+// FormatTime 将时间转换到格式化器的时区后格式化
+func (f *Formatter) FormatTime(t time.Time) string {
+	return t.In(f.location).Format(f.format)
+}
+
+// AppendFormat 将格式化后的时间戳追加到dst,用于拼接输出时避免为每个时间戳分配字符串
+func (f *Formatter) AppendFormat(dst []byte, timestamp int64) []byte {
+	return time.Unix(timestamp, 0).In(f.location).AppendFormat(dst, f.format)
+}
+
+// FormatAll 按顺序格式化所有时间戳
+func (f *Formatter) FormatAll(timestamps []int64) []string {
+	result := make([]string, len(timestamps))
+	for i, ts := range timestamps {
+		result[i] = f.Format(ts)
+	}
+	return result
+}
+
+// ConvertToZoneTimeStrs 批量将时间戳转换为指定时区和格式的时间字符串,时区只解析一次
+//
+// 参数:
+//   - timestamps: Unix时间戳(秒)
+//   - format: 时间格式化模板,如 "2006-01-02 15:04:05"
+//   - zone: 可选的时区,同 ConvertToZoneTimeStr
+//
+// 返回值:
+//   - []string: 与timestamps一一对应的时间字符串
+//   - error: 时区格式无效时返回包装了 ErrInvalidZoneFormat 的错误,使用 errors.Is 判断
+//
+// 注意事项:
+//   - 多次调用时使用相同的时区和格式时,使用 NewFormatter 创建一次后复用
+//
+// 示例:
+//
+//	strs, err := ConvertToZoneTimeStrs([]int64{1684154445, 1684240845}, "2006-01-02", "+8")
+//	// 返回: ["2023-05-15", "2023-05-16"], nil
+func ConvertToZoneTimeStrs(timestamps []int64, format string, zone ...string) ([]string, error) {
+	f, err := NewFormatter(format, zone...)
+	if err != nil {
+		return nil, err
+	}
+	return f.FormatAll(timestamps), nil
+}
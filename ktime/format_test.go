@@ -0,0 +1,63 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatter(t *testing.T) {
+	timestamps := []int64{1684154445, 1684240845, 0}
+
+	t.Run("与ConvertToZoneTimeStr一致", func(t *testing.T) {
+		for _, zone := range [][]string{nil, {"+8"}, {"-5"}, {"UTC"}, {"UTC+05:30"}} {
+			f, err := NewFormatter("2006-01-02 15:04:05 -07:00", zone...)
+			assert.NoError(t, err)
+			for _, ts := range timestamps {
+				want := MustConvertToZoneTimeStr(ts, "2006-01-02 15:04:05 -07:00", zone...)
+				assert.Equal(t, want, f.Format(ts))
+				assert.Equal(t, want, string(f.AppendFormat(nil, ts)))
+			}
+		}
+	})
+
+	t.Run("格式化时间", func(t *testing.T) {
+		f := MustNewFormatter("2006-01-02 15:04", "+8")
+		assert.Equal(t, "2024-05-15 08:00", f.FormatTime(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, "UTC+8", f.Location().String())
+	})
+
+	t.Run("追加", func(t *testing.T) {
+		f := MustNewFormatter("2006-01-02", "+8")
+		buf := []byte("date=")
+		assert.Equal(t, "date=2023-05-15", string(f.AppendFormat(buf, 1684154445)))
+	})
+
+	t.Run("无效的时区", func(t *testing.T) {
+		_, err := NewFormatter("2006-01-02", "invalid_zone")
+		assert.ErrorIs(t, err, ErrInvalidZoneFormat)
+		assert.Panics(t, func() { MustNewFormatter("2006-01-02", "invalid_zone") })
+	})
+}
+
+func TestConvertToZoneTimeStrs(t *testing.T) {
+	strs, err := ConvertToZoneTimeStrs([]int64{1684154445, 1684240845}, "2006-01-02 15:04:05", "+8")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2023-05-15 20:40:45", "2023-05-16 20:40:45"}, strs)
+
+	strs, err = ConvertToZoneTimeStrs(nil, "2006-01-02")
+	assert.NoError(t, err)
+	assert.Empty(t, strs)
+
+	_, err = ConvertToZoneTimeStrs([]int64{1684154445}, "2006-01-02", "invalid_zone")
+	assert.ErrorIs(t, err, ErrInvalidZoneFormat)
+}
+
+func BenchmarkFormatter(b *testing.B) {
+	f := MustNewFormatter("2006-01-02 15:04:05", "Asia/Shanghai")
+	buf := make([]byte, 0, 32)
+	for i := 0; i < b.N; i++ {
+		buf = f.AppendFormat(buf[:0], int64(1684154445+i))
+	}
+}
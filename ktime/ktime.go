@@ -1,7 +1,6 @@
 package ktime
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
@@ -20,7 +19,7 @@ const (
 // 参数:
 //   - timestamp: Unix时间戳（秒）
 //   - format: 时间格式化模板，如 "2006-01-02 15:04:05"
-//   - zone: 可选的时区参数，支持标准时区名称（如"UTC"）或小时偏移（如"+8"、"-5"）
+//   - zone: 可选的时区参数，支持标准时区名称（如"UTC"）、小时偏移（如"+8"、"-5"）或带分钟的偏移（如"UTC+05:30"），参见 LoadZone
 //
 // 返回值:
 //   - string: 格式化后的时间字符串
@@ -28,7 +27,9 @@ const (
 //
 // 注意事项:
 //   - 如果不提供时区参数，默认使用 UTC+8
-//   - 时区参数可以是标准时区名称或小时偏移格式
+//   - 时区参数可以是标准时区名称或偏移格式，解析结果会被缓存
+//   - 偏移最大为 ±23:59，超出时（如"+24"）返回 ErrInvalidZoneFormat 错误
+//   - 只接受秒级时间戳，单位不确定时先使用 DetectUnit 判断并转换
 //
 // 示例:
 //
@@ -38,29 +39,15 @@ func ConvertToZoneTimeStr(timestamp int64, format string, zone ...string) (strin
 	// 将时间戳转换为 time.Time 对象
 	t := time.Unix(timestamp, 0)
 
-	// 解析时区
-	var location *time.Location
-	if len(zone) == 0 {
-		// 默认使用 UTC+8
-		location = time.FixedZone(DefaultZone, DefaultZoneOffset)
-	} else {
-		zoneStr := zone[0]
-		// 尝试解析标准时区
-		loc, err := time.LoadLocation(zoneStr)
+	// 解析时区，默认使用 UTC+8
+	location := defaultLocation
+	if len(zone) > 0 {
+		loc, err := LoadZone(zone[0])
 		if err != nil {
-			// 如果不是标准时区，尝试解析为小时偏移
-			// 例如 "+8" 或 "-5"
-			var offset int
-			_, err := fmt.Sscanf(zoneStr, "%d", &offset)
-			if err == nil {
-				location = time.FixedZone(fmt.Sprintf("UTC%+d", offset), offset*60*60)
-			} else {
-				// 解析失败，返回错误
-				return "", ErrInvalidZoneFormat
-			}
-		} else {
-			location = loc
+			// 返回未包装的错误，兼容使用 err == ErrInvalidZoneFormat 判断的调用方
+			return "", ErrInvalidZoneFormat
 		}
+		location = loc
 	}
 
 	// 将时间转换为指定时区
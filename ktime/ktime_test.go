@@ -69,6 +69,13 @@ func TestConvertToZoneTimeStr(t *testing.T) {
 			zone:        []string{"invalid_zone"},
 			expectError: true,
 		},
+		{
+			name:        "超出范围的时区偏移",
+			timestamp:   timestamp,
+			format:      "2006-01-02 15:04:05",
+			zone:        []string{"+24"},
+			expectError: true,
+		},
 		{
 			name:      "零时间戳",
 			timestamp: 0,
@@ -90,7 +97,7 @@ func TestConvertToZoneTimeStr(t *testing.T) {
 			result, err := ConvertToZoneTimeStr(tt.timestamp, tt.format, tt.zone...)
 
 			if tt.expectError {
-				assert.Error(t, err)
+				assert.Equal(t, ErrInvalidZoneFormat, err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expected, result)
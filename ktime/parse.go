@@ -0,0 +1,196 @@
+package ktime
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnrecognizedFormat = errors.New("unrecognized time format")
+)
+
+// DefaultLayouts ParseFlexible 默认依次尝试的格式
+var DefaultLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"20060102150405",
+	"20060102",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.UnixDate,
+	time.ANSIC,
+	"02 Jan 2006",
+	"Jan 2, 2006",
+}
+
+// ParserOptions 时间解析器的配置项
+type ParserOptions struct {
+	Layouts  []string       // 依次尝试的格式
+	Location *time.Location // 不带时区的格式使用的时区
+}
+
+type ParserOption func(*ParserOptions)
+
+func NewParserOptions() *ParserOptions {
+	return &ParserOptions{
+		Layouts:  DefaultLayouts,
+		Location: time.Local,
+	}
+}
+
+// WithParserLayouts 设置依次尝试的格式,替换默认的 DefaultLayouts
+func WithParserLayouts(layouts ...string) ParserOption {
+	return func(o *ParserOptions) {
+		o.Layouts = layouts
+	}
+}
+
+// WithParserLocation 设置不带时区的格式使用的时区,默认为time.Local
+func WithParserLocation(loc *time.Location) ParserOption {
+	return func(o *ParserOptions) {
+		o.Location = loc
+	}
+}
+
+// Parser 依次尝试多种格式解析时间字符串
+type Parser struct {
+	mu       sync.RWMutex
+	layouts  []string
+	location *time.Location
+}
+
+// NewParser 创建时间解析器
+//
+// 参数说明:
+//   - opts: 可选配置项,参见 ParserOptions
+//
+// 示例:
+//
+//	p := NewParser(WithParserLocation(time.UTC))
+//	p.Register("02/01/2006 15:04")
+//	t, err := p.Parse("15/05/2024 08:30")
+func NewParser(opts ...ParserOption) *Parser {
+	o := NewParserOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Parser{
+		layouts:  append([]string(nil), o.Layouts...),
+		location: o.Location,
+	}
+}
+
+// Register 注册自定义格式,新注册的格式按顺序排在已有的格式之前
+func (p *Parser) Register(layouts ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.layouts = append(append([]string(nil), layouts...), p.layouts...)
+}
+
+// Parse 解析时间字符串
+//
+// 参数说明:
+//   - s: 时间字符串,首尾的空白会被忽略
+//
+// 返回值说明:
+//   - time.Time: 解析后的时间
+//   - error: 所有格式都无法解析时返回 ErrUnrecognizedFormat
+//
+// 注意事项:
+//   - 纯数字的字符串(可以带负号)按Unix时间戳处理,根据大小判断单位:
+//     绝对值小于1e11为秒,小于1e14为毫秒,小于1e17为微秒,否则为纳秒
+//   - 8位和14位的纯数字优先按 "20060102" 和 "20060102150405" 格式解析
+//   - 其他字符串依次尝试自定义格式和 DefaultLayouts,返回第一个成功的结果
+func (p *Parser) Parse(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, errors.Wrap(ErrUnrecognizedFormat, "empty string")
+	}
+	p.mu.RLock()
+	layouts, loc := p.layouts, p.location
+	p.mu.RUnlock()
+
+	if isDigits(s) {
+		if len(s) == 8 || len(s) == 14 {
+			for _, layout := range []string{"20060102", "20060102150405"} {
+				if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+					return t, nil
+				}
+			}
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return unixAuto(n).In(loc), nil
+		}
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Wrapf(ErrUnrecognizedFormat, "%q", s)
+}
+
+// defaultParser ParseFlexible 使用的解析器
+var defaultParser = NewParser()
+
+// ParseFlexible 依次尝试多种常见格式解析时间字符串,格式和规则参见 Parser.Parse
+//
+// 参数说明:
+//   - s: 时间字符串,支持RFC3339、"2006-01-02 15:04:05"、"2006-01-02"、秒级或毫秒级时间戳等
+//
+// 返回值说明:
+//   - time.Time: 解析后的时间,不带时区的格式使用time.Local
+//   - error: 无法解析时返回 ErrUnrecognizedFormat
+//
+// 注意事项:
+//   - 使用 RegisterLayout 注册自定义格式,需要指定时区或只使用部分格式时使用 NewParser
+//
+// 示例:
+//
+//	t, err := ParseFlexible("2024-05-15 08:30:00")
+//	t, err = ParseFlexible("1715733000")    // 秒级时间戳
+//	t, err = ParseFlexible("1715733000123") // 毫秒级时间戳
+func ParseFlexible(s string) (time.Time, error) {
+	return defaultParser.Parse(s)
+}
+
+// RegisterLayout 为 ParseFlexible 注册自定义格式,自定义格式优先于默认格式
+//
+// 示例:
+//
+//	func init() {
+//	    ktime.RegisterLayout("02/01/2006 15:04:05")
+//	}
+func RegisterLayout(layouts ...string) {
+	defaultParser.Register(layouts...)
+}
+
+// isDigits 判断是否为纯数字,可以带负号
+func isDigits(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFlexible(t *testing.T) {
+	local := func(year int, month time.Month, day, hour, min, sec, nsec int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, nsec, time.Local)
+	}
+	tests := []struct {
+		name        string
+		input       string
+		expected    time.Time
+		expectError bool
+	}{
+		{name: "RFC3339", input: "2024-05-15T08:30:00Z", expected: time.Date(2024, 5, 15, 8, 30, 0, 0, time.UTC)},
+		{name: "RFC3339带时区", input: "2024-05-15T08:30:00+08:00", expected: time.Date(2024, 5, 15, 0, 30, 0, 0, time.UTC)},
+		{name: "RFC3339Nano", input: "2024-05-15T08:30:00.123Z", expected: time.Date(2024, 5, 15, 8, 30, 0, 123e6, time.UTC)},
+		{name: "日期时间", input: "2024-05-15 08:30:00", expected: local(2024, 5, 15, 8, 30, 0, 0)},
+		{name: "日期时间带毫秒", input: "2024-05-15 08:30:00.5", expected: local(2024, 5, 15, 8, 30, 0, 5e8)},
+		{name: "首尾空白", input: "  2024-05-15 08:30:00\n", expected: local(2024, 5, 15, 8, 30, 0, 0)},
+		{name: "斜杠日期", input: "2024/05/15", expected: local(2024, 5, 15, 0, 0, 0, 0)},
+		{name: "只有日期", input: "2024-05-15", expected: local(2024, 5, 15, 0, 0, 0, 0)},
+		{name: "紧凑日期", input: "20240515", expected: local(2024, 5, 15, 0, 0, 0, 0)},
+		{name: "紧凑日期时间", input: "20240515083000", expected: local(2024, 5, 15, 8, 30, 0, 0)},
+		{name: "秒级时间戳", input: "1715733000", expected: time.Unix(1715733000, 0)},
+		{name: "毫秒级时间戳", input: "1715733000123", expected: time.UnixMilli(1715733000123)},
+		{name: "微秒级时间戳", input: "1715733000123456", expected: time.UnixMicro(1715733000123456)},
+		{name: "纳秒级时间戳", input: "1715733000123456789", expected: time.Unix(0, 1715733000123456789)},
+		{name: "负时间戳", input: "-86400", expected: time.Unix(-86400, 0)},
+		{name: "RFC1123", input: "Wed, 15 May 2024 08:30:00 GMT", expected: time.Date(2024, 5, 15, 8, 30, 0, 0, time.UTC)},
+		{name: "空字符串", input: "", expectError: true},
+		{name: "无法识别", input: "yesterday", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseFlexible(tt.input)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrUnrecognizedFormat)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(result), "expected %v, got %v", tt.expected, result)
+		})
+	}
+}
+
+func TestParserRegister(t *testing.T) {
+	p := NewParser(WithParserLayouts("2006-01-02"), WithParserLocation(time.UTC))
+
+	_, err := p.Parse("15/05/2024 08:30")
+	assert.ErrorIs(t, err, ErrUnrecognizedFormat)
+
+	p.Register("02/01/2006 15:04")
+	result, err := p.Parse("15/05/2024 08:30")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 15, 8, 30, 0, 0, time.UTC), result)
+
+	// 新注册的格式优先
+	p.Register("02/01/2006", "01/02/2006")
+	result, err = p.Parse("05/06/2024")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC), result)
+
+	// 时间戳使用解析器的时区
+	result, err = p.Parse("0")
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, result.Location())
+}
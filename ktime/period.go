@@ -0,0 +1,78 @@
+package ktime
+
+import "time"
+
+// 以下函数都在t所在的时区计算,需要其他时区的边界时先使用 t.In(loc) 转换
+// 使用time.Date构造边界,而不是Truncate,Truncate按UTC的绝对时间取整,在非UTC时区和夏令时切换的日期会得到错误的结果
+
+// StartOfDay 返回t所在日的开始时间,即 00:00:00
+//
+// 示例:
+//
+//	loc, _ := time.LoadLocation("Asia/Shanghai")
+//	StartOfDay(time.Now().In(loc)) // 北京时间当天的 00:00:00
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfDay 返回t所在日的结束时间,即 23:59:59.999999999
+func EndOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfWeek 返回t所在周的开始时间
+//
+// 参数:
+//   - t: 时间
+//   - weekStart: 可选的每周第一天,默认为周一
+//
+// 示例:
+//
+//	StartOfWeek(t)              // 周一 00:00:00
+//	StartOfWeek(t, time.Sunday) // 周日 00:00:00
+func StartOfWeek(t time.Time, weekStart ...time.Weekday) time.Time {
+	start := time.Monday
+	if len(weekStart) > 0 {
+		start = weekStart[0]
+	}
+	offset := (int(t.Weekday()) - int(start) + 7) % 7
+	y, m, d := t.Date()
+	return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfWeek 返回t所在周的结束时间,weekStart同 StartOfWeek
+func EndOfWeek(t time.Time, weekStart ...time.Weekday) time.Time {
+	y, m, d := StartOfWeek(t, weekStart...).Date()
+	return time.Date(y, m, d+7, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfMonth 返回t所在月的开始时间,即1日 00:00:00
+func StartOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfMonth 返回t所在月的结束时间,即最后一天的 23:59:59.999999999
+func EndOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m+1, 1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// StartOfQuarter 返回t所在季度的开始时间,即1月、4月、7月或10月的1日 00:00:00
+func StartOfQuarter(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, quarterStartMonth(m), 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfQuarter 返回t所在季度的结束时间
+func EndOfQuarter(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, quarterStartMonth(m)+3, 1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// quarterStartMonth 返回m所在季度的第一个月
+func quarterStartMonth(m time.Month) time.Month {
+	return (m-1)/3*3 + 1
+}
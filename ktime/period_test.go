@@ -0,0 +1,53 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriod(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	// 2024-05-15 是周三,UTC时间为 2024-05-14 17:30:00
+	now := time.Date(2024, 5, 15, 1, 30, 45, 123, loc)
+	tests := []struct {
+		name     string
+		result   time.Time
+		expected time.Time
+	}{
+		{"StartOfDay", StartOfDay(now), time.Date(2024, 5, 15, 0, 0, 0, 0, loc)},
+		{"EndOfDay", EndOfDay(now), time.Date(2024, 5, 15, 23, 59, 59, 999999999, loc)},
+		{"StartOfWeek", StartOfWeek(now), time.Date(2024, 5, 13, 0, 0, 0, 0, loc)},
+		{"StartOfWeek周日开始", StartOfWeek(now, time.Sunday), time.Date(2024, 5, 12, 0, 0, 0, 0, loc)},
+		{"StartOfWeek当天开始", StartOfWeek(now, time.Wednesday), time.Date(2024, 5, 15, 0, 0, 0, 0, loc)},
+		{"StartOfWeek跨月", StartOfWeek(time.Date(2024, 6, 1, 0, 0, 0, 0, loc)), time.Date(2024, 5, 27, 0, 0, 0, 0, loc)},
+		{"EndOfWeek", EndOfWeek(now), time.Date(2024, 5, 19, 23, 59, 59, 999999999, loc)},
+		{"EndOfWeek周日开始", EndOfWeek(now, time.Sunday), time.Date(2024, 5, 18, 23, 59, 59, 999999999, loc)},
+		{"StartOfMonth", StartOfMonth(now), time.Date(2024, 5, 1, 0, 0, 0, 0, loc)},
+		{"EndOfMonth", EndOfMonth(now), time.Date(2024, 5, 31, 23, 59, 59, 999999999, loc)},
+		{"EndOfMonth闰年二月", EndOfMonth(time.Date(2024, 2, 10, 0, 0, 0, 0, loc)), time.Date(2024, 2, 29, 23, 59, 59, 999999999, loc)},
+		{"StartOfQuarter", StartOfQuarter(now), time.Date(2024, 4, 1, 0, 0, 0, 0, loc)},
+		{"EndOfQuarter", EndOfQuarter(now), time.Date(2024, 6, 30, 23, 59, 59, 999999999, loc)},
+		{"EndOfQuarter跨年", EndOfQuarter(time.Date(2024, 11, 1, 0, 0, 0, 0, loc)), time.Date(2024, 12, 31, 23, 59, 59, 999999999, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.result)
+		})
+	}
+}
+
+func TestPeriodDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	// 2024-03-10 切换为夏令时,当天只有23小时
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, loc)
+	start, end := StartOfDay(now), EndOfDay(now)
+	assert.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, loc), start)
+	assert.Equal(t, 23*time.Hour, end.Sub(start)+time.Nanosecond)
+	assert.Equal(t, "EST", start.Format("MST"))
+	assert.Equal(t, "EDT", end.Format("MST"))
+}
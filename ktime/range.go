@@ -0,0 +1,149 @@
+package ktime
+
+import (
+	"iter"
+	"time"
+)
+
+// Step 时间范围的步长,按日历计算的月、天和按绝对时间计算的Duration可以组合使用
+type Step struct {
+	Months   int           // 月数,如1月31日加1个月为2月的最后一天
+	Days     int           // 天数,按日历计算,夏令时切换的日期也保持相同的时刻
+	Duration time.Duration // 绝对时间间隔
+}
+
+var (
+	StepHour  = Step{Duration: time.Hour}
+	StepDay   = Step{Days: 1}
+	StepWeek  = Step{Days: 7}
+	StepMonth = Step{Months: 1}
+)
+
+// Every 返回按绝对时间间隔d前进的步长
+func Every(d time.Duration) Step {
+	return Step{Duration: d}
+}
+
+// add 返回from前进n步后的时间,每次都从from计算,避免月末日期逐步漂移
+func (s Step) add(from time.Time, n int) time.Time {
+	t := from
+	if s.Months != 0 {
+		t = addMonths(t, s.Months*n)
+	}
+	if s.Days != 0 {
+		t = t.AddDate(0, 0, s.Days*n)
+	}
+	return t.Add(s.Duration * time.Duration(n))
+}
+
+// addMonths 增加月数,日期超过目标月的天数时取目标月的最后一天
+func addMonths(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	hour, min, sec := t.Clock()
+	first := time.Date(y, m+time.Month(months), 1, hour, min, sec, t.Nanosecond(), t.Location())
+	if last := EndOfMonth(first).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// RangeDates 返回从from到to(包括to)按step前进的所有时间
+//
+// 参数:
+//   - from: 开始时间
+//   - to: 结束时间,正好落在步长上时包括to
+//   - step: 步长,如 StepDay、StepHour、StepMonth 或 Every(15*time.Minute)
+//
+// 返回值:
+//   - []time.Time: 所有时间,from晚于to或者step不能使时间前进时返回nil
+//
+// 注意事项:
+//   - 范围较大时使用 RangeDatesSeq 避免一次分配所有时间
+//
+// 示例:
+//
+//	for _, day := range RangeDates(start, end, StepDay) {
+//	    backfill(day)
+//	}
+func RangeDates(from, to time.Time, step Step) []time.Time {
+	var result []time.Time
+	for t := range RangeDatesSeq(from, to, step) {
+		result = append(result, t)
+	}
+	return result
+}
+
+// RangeDatesSeq 同 RangeDates,返回迭代器
+//
+// 示例:
+//
+//	for month := range RangeDatesSeq(start, end, StepMonth) {
+//	    report(month)
+//	}
+func RangeDatesSeq(from, to time.Time, step Step) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if from.After(to) || !step.add(from, 1).After(from) {
+			return
+		}
+		for i := 0; ; i++ {
+			t := step.add(from, i)
+			if t.After(to) || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// TimeRange 左闭右开的时间范围 [Start, End)
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration 返回时间范围的长度
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Contains 判断t是否在时间范围内
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// Split 将时间范围 [from, to) 等分为n个连续的窗口
+//
+// 参数:
+//   - from: 开始时间
+//   - to: 结束时间,不包括
+//   - n: 窗口数量
+//
+// 返回值:
+//   - []TimeRange: 首尾相接的n个窗口,第一个窗口从from开始,最后一个窗口到to结束,
+//     n小于等于0或者from不早于to时返回nil
+//
+// 注意事项:
+//   - 无法整除时各窗口的长度最多相差1纳秒
+//
+// 示例:
+//
+//	for _, w := range Split(start, end, workers) {
+//	    go backfill(w.Start, w.End)
+//	}
+func Split(from, to time.Time, n int) []TimeRange {
+	if n <= 0 || !from.Before(to) {
+		return nil
+	}
+	total := to.Sub(from)
+	base, rem := total/time.Duration(n), total%time.Duration(n)
+	result := make([]TimeRange, n)
+	start := from
+	for i := range result {
+		end := from.Add(base*time.Duration(i+1) + rem*time.Duration(i+1)/time.Duration(n))
+		if i == n-1 {
+			end = to
+		}
+		result[i] = TimeRange{Start: start, End: end}
+		start = end
+	}
+	return result
+}
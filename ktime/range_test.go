@@ -0,0 +1,90 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeDates(t *testing.T) {
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		from, to time.Time
+		step     Step
+		expected []time.Time
+	}{
+		{"按天包括结束时间", date(5, 1, 0), date(5, 3, 0), StepDay, []time.Time{date(5, 1, 0), date(5, 2, 0), date(5, 3, 0)}},
+		{"按天不包括未对齐的结束时间", date(5, 1, 0), date(5, 2, 23), StepDay, []time.Time{date(5, 1, 0), date(5, 2, 0)}},
+		{"按小时", date(5, 1, 22), date(5, 2, 1), StepHour, []time.Time{date(5, 1, 22), date(5, 1, 23), date(5, 2, 0), date(5, 2, 1)}},
+		{"按月取月末", date(1, 31, 0), date(4, 30, 0), StepMonth, []time.Time{date(1, 31, 0), date(2, 29, 0), date(3, 31, 0), date(4, 30, 0)}},
+		{"自定义间隔", date(5, 1, 0), date(5, 1, 1), Every(30 * time.Minute), []time.Time{date(5, 1, 0), date(5, 1, 0).Add(30 * time.Minute), date(5, 1, 1)}},
+		{"开始等于结束", date(5, 1, 0), date(5, 1, 0), StepDay, []time.Time{date(5, 1, 0)}},
+		{"开始晚于结束", date(5, 2, 0), date(5, 1, 0), StepDay, nil},
+		{"零步长", date(5, 1, 0), date(5, 2, 0), Step{}, nil},
+		{"负步长", date(5, 1, 0), date(5, 2, 0), Step{Days: -1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RangeDates(tt.from, tt.to, tt.step))
+		})
+	}
+}
+
+func TestRangeDatesSeqBreak(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var count int
+	for range RangeDatesSeq(from, from.AddDate(10, 0, 0), StepDay) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+}
+
+func TestRangeDatesDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	from := time.Date(2024, 3, 9, 0, 0, 0, 0, loc)
+	days := RangeDates(from, time.Date(2024, 3, 11, 0, 0, 0, 0, loc), StepDay)
+	if assert.Len(t, days, 3) {
+		for _, day := range days {
+			assert.Equal(t, 0, day.Hour())
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+
+	windows := Split(from, to, 4)
+	if assert.Len(t, windows, 4) {
+		assert.Equal(t, from, windows[0].Start)
+		assert.Equal(t, to, windows[3].End)
+		for i, w := range windows {
+			assert.Equal(t, 150*time.Minute, w.Duration())
+			if i > 0 {
+				assert.Equal(t, windows[i-1].End, w.Start)
+			}
+		}
+		assert.True(t, windows[0].Contains(from))
+		assert.False(t, windows[0].Contains(windows[1].Start))
+	}
+
+	// 无法整除时长度最多相差1纳秒
+	windows = Split(from, from.Add(10), 3)
+	if assert.Len(t, windows, 3) {
+		assert.Equal(t, []time.Duration{3, 3, 4}, []time.Duration{windows[0].Duration(), windows[1].Duration(), windows[2].Duration()})
+	}
+
+	assert.Nil(t, Split(from, to, 0))
+	assert.Nil(t, Split(to, from, 2))
+	assert.Nil(t, Split(from, from, 2))
+}
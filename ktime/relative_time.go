@@ -21,7 +21,12 @@ import "time"
 //	t2 := ktime.Now() // 返回 1年1个月 + 2ms
 //
 // 即使两次调用发生在同一系统时间点（例如系统时钟未更新），单调时钟仍会确保 t2 > t1。
-var initTime = time.Now().AddDate(-1, -1, -1)
+//
+// AddDate 返回的时间不包含单调时钟，所以使用 Add 回退，否则 Now 实际使用的是系统时钟
+var initTime = func() time.Time {
+	now := time.Now()
+	return now.Add(now.AddDate(-1, -1, -1).Sub(now))
+}()
 
 // Now 返回相对于系统启动时间的时间
 func Now() time.Duration {
@@ -22,3 +22,8 @@ func TestRelativeTime(t *testing.T) {
 	time.Sleep(time.Millisecond)
 	assert.True(t, Since(now) > 0)
 }
+
+func TestInitTimeMonotonic(t *testing.T) {
+	// 包含单调时钟的时间在String中带有 "m=" 读数
+	assert.Contains(t, initTime.String(), "m=")
+}
@@ -0,0 +1,173 @@
+package ktime
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StopwatchOptions 秒表的配置项
+type StopwatchOptions struct {
+	Clock Clock // 计时使用的时钟
+}
+
+type StopwatchOption func(*StopwatchOptions)
+
+func NewStopwatchOptions() *StopwatchOptions {
+	return &StopwatchOptions{
+		Clock: RealClock,
+	}
+}
+
+// WithStopwatchClock 设置计时使用的时钟,默认为 RealClock
+func WithStopwatchClock(clock Clock) StopwatchOption {
+	return func(o *StopwatchOptions) {
+		o.Clock = clock
+	}
+}
+
+// Lap 秒表记录的一个阶段
+type Lap struct {
+	Label    string        `json:"label"`    // 阶段名称
+	Duration time.Duration `json:"duration"` // 阶段的耗时,即距离上一个阶段结束经过的时间
+	Total    time.Duration `json:"total"`    // 阶段结束时的总耗时
+}
+
+// Stopwatch 秒表,记录总耗时和各阶段的耗时
+//
+// 注意事项:
+//   - 线程安全
+//   - 暂停期间的时间不计入总耗时和阶段耗时
+//   - 相比 kmonitor.ConsumeTimeStatistics 只返回字符串,可以通过 Laps 获取结构化的结果
+type Stopwatch struct {
+	mu      sync.Mutex
+	clock   Clock
+	running bool
+	start   time.Time     // 本次运行开始的时间
+	elapsed time.Duration // 之前各次运行的累计耗时
+	laps    []Lap
+	lastLap time.Duration // 上一个阶段结束时的总耗时
+}
+
+// NewStopwatch 创建秒表,创建后需要调用 Start 开始计时
+//
+// 参数:
+//   - opts: 可选配置项,参见 StopwatchOptions
+//
+// 示例:
+//
+//	sw := NewStopwatch(WithStopwatchClock(clock))
+//	sw.Start()
+func NewStopwatch(opts ...StopwatchOption) *Stopwatch {
+	o := NewStopwatchOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Stopwatch{clock: o.Clock}
+}
+
+// StartStopwatch 创建并立即开始计时的秒表
+//
+// 示例:
+//
+//	sw := StartStopwatch()
+//	load()
+//	sw.Lap("load")
+//	process()
+//	sw.Lap("process")
+//	log.Println(sw) // load=1.2s process=300ms total=1.5s
+func StartStopwatch(opts ...StopwatchOption) *Stopwatch {
+	s := NewStopwatch(opts...)
+	s.Start()
+	return s
+}
+
+// Start 开始或继续计时,已经在计时时不做任何操作
+func (s *Stopwatch) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return
+	}
+	s.running = true
+	s.start = s.clock.Now()
+}
+
+// Stop 暂停计时,返回总耗时,之后可以调用 Start 继续计时
+func (s *Stopwatch) Stop() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		s.elapsed += s.clock.Since(s.start)
+		s.running = false
+	}
+	return s.elapsed
+}
+
+// Lap 结束当前阶段并记录,下一个阶段从此时开始
+//
+// 参数:
+//   - label: 阶段名称
+//
+// 返回值:
+//   - Lap: 记录的阶段
+func (s *Stopwatch) Lap(label string) Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := s.elapsedLocked()
+	lap := Lap{Label: label, Duration: total - s.lastLap, Total: total}
+	s.laps = append(s.laps, lap)
+	s.lastLap = total
+	return lap
+}
+
+// Reset 停止计时并清空总耗时和所有阶段
+func (s *Stopwatch) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+	s.elapsed = 0
+	s.laps = nil
+	s.lastLap = 0
+}
+
+// Elapsed 返回总耗时,计时中时包括本次运行已经经过的时间
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.elapsedLocked()
+}
+
+// Running 返回是否在计时
+func (s *Stopwatch) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// Laps 按记录的顺序返回所有阶段
+func (s *Stopwatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Lap(nil), s.laps...)
+}
+
+// String 返回各阶段和总耗时,如 "load=1.2s process=300ms total=1.5s"
+func (s *Stopwatch) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sb strings.Builder
+	for _, lap := range s.laps {
+		fmt.Fprintf(&sb, "%s=%s ", lap.Label, lap.Duration)
+	}
+	fmt.Fprintf(&sb, "total=%s", s.elapsedLocked())
+	return sb.String()
+}
+
+func (s *Stopwatch) elapsedLocked() time.Duration {
+	if s.running {
+		return s.elapsed + s.clock.Since(s.start)
+	}
+	return s.elapsed
+}
@@ -0,0 +1,63 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopwatch(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+
+	t.Run("阶段耗时", func(t *testing.T) {
+		sw := StartStopwatch(WithStopwatchClock(clock))
+		clock.Advance(time.Second)
+		assert.Equal(t, Lap{Label: "load", Duration: time.Second, Total: time.Second}, sw.Lap("load"))
+		clock.Advance(300 * time.Millisecond)
+		sw.Lap("process")
+		clock.Advance(200 * time.Millisecond)
+
+		assert.Equal(t, []Lap{
+			{Label: "load", Duration: time.Second, Total: time.Second},
+			{Label: "process", Duration: 300 * time.Millisecond, Total: 1300 * time.Millisecond},
+		}, sw.Laps())
+		assert.Equal(t, 1500*time.Millisecond, sw.Elapsed())
+		assert.Equal(t, "load=1s process=300ms total=1.5s", sw.String())
+	})
+
+	t.Run("暂停期间不计时", func(t *testing.T) {
+		sw := NewStopwatch(WithStopwatchClock(clock))
+		assert.False(t, sw.Running())
+		clock.Advance(time.Minute)
+		assert.Equal(t, time.Duration(0), sw.Elapsed())
+
+		sw.Start()
+		clock.Advance(time.Second)
+		assert.Equal(t, time.Second, sw.Stop())
+		assert.False(t, sw.Running())
+		clock.Advance(time.Hour)
+		assert.Equal(t, time.Second, sw.Elapsed())
+
+		sw.Start()
+		sw.Start()
+		clock.Advance(time.Second)
+		assert.True(t, sw.Running())
+		assert.Equal(t, 2*time.Second, sw.Lap("step").Duration)
+	})
+
+	t.Run("重置", func(t *testing.T) {
+		sw := StartStopwatch(WithStopwatchClock(clock))
+		clock.Advance(time.Second)
+		sw.Lap("step")
+		sw.Reset()
+		assert.False(t, sw.Running())
+		assert.Equal(t, time.Duration(0), sw.Elapsed())
+		assert.Empty(t, sw.Laps())
+		assert.Equal(t, "total=0s", sw.String())
+
+		sw.Start()
+		clock.Advance(time.Second)
+		assert.Equal(t, Lap{Label: "again", Duration: time.Second, Total: time.Second}, sw.Lap("again"))
+	})
+}
@@ -0,0 +1,88 @@
+package ktime
+
+import (
+	"context"
+	"time"
+)
+
+// TickOptions TickAligned 的配置项
+type TickOptions struct {
+	Location *time.Location // 对齐边界使用的时区
+	Clock    Clock          // 计时使用的时钟
+}
+
+type TickOption func(*TickOptions)
+
+func NewTickOptions() *TickOptions {
+	return &TickOptions{
+		Location: time.Local,
+		Clock:    RealClock,
+	}
+}
+
+// WithTickLocation 设置对齐边界使用的时区,默认为time.Local
+func WithTickLocation(loc *time.Location) TickOption {
+	return func(o *TickOptions) {
+		o.Location = loc
+	}
+}
+
+// WithTickClock 设置计时使用的时钟,默认为 RealClock
+func WithTickClock(clock Clock) TickOption {
+	return func(o *TickOptions) {
+		o.Clock = clock
+	}
+}
+
+// TickAligned 返回在整点边界触发的通道,如每分钟的 :00 秒、每小时的整点
+//
+// 参数:
+//   - ctx: 上下文,ctx结束时停止并关闭通道
+//   - interval: 触发间隔,必须大于0,否则会panic
+//   - opts: 可选配置项,参见 TickOptions
+//
+// 返回值:
+//   - <-chan time.Time: 每到一个边界发送该边界的时间(而不是实际触发的时间),使用配置的时区
+//
+// 注意事项:
+//   - 边界按所在时区的wall clock从当天0点开始计算,interval需要能整除一天,如 time.Minute、15*time.Minute、time.Hour
+//   - 同time.Ticker,接收方来不及处理时丢弃边界,不会积压
+//   - 多个实例使用相同的interval和时区时在相同的时刻触发,适合刷新指标、生成报表等需要对齐的任务
+//   - 夏令时切换的日期使用触发前的时区偏移计算边界
+//
+// 示例:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	for t := range TickAligned(ctx, time.Hour) {
+//	    generateReport(t.Add(-time.Hour), t) // 上一个整点小时的报表
+//	}
+func TickAligned(ctx context.Context, interval time.Duration, opts ...TickOption) <-chan time.Time {
+	if interval <= 0 {
+		panic("non-positive interval for TickAligned")
+	}
+	o := NewTickOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ch := make(chan time.Time, 1)
+	go func() {
+		defer close(ch)
+		for {
+			next := nextAligned(o.Clock.Now().In(o.Location), interval)
+			timer := o.Clock.NewTimer(next.Sub(o.Clock.Now()))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C():
+			}
+			select {
+			case ch <- next:
+			default:
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,51 @@
+package ktime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTickAligned(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+
+	t.Run("按时区的整点触发", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 5, 15, 10, 20, 30, 0, kolkata))
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := TickAligned(ctx, time.Hour, WithTickLocation(kolkata), WithTickClock(clock))
+
+		clock.BlockUntil(1)
+		clock.Advance(39*time.Minute + 29*time.Second)
+		assert.Empty(t, ch)
+		clock.Advance(time.Second)
+		assert.Equal(t, time.Date(2024, 5, 15, 11, 0, 0, 0, kolkata), <-ch)
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Hour)
+		assert.Equal(t, time.Date(2024, 5, 15, 12, 0, 0, 0, kolkata), <-ch)
+
+		cancel()
+		_, ok := <-ch
+		assert.False(t, ok, "ctx结束后关闭通道")
+	})
+
+	t.Run("来不及处理时丢弃", func(t *testing.T) {
+		clock := NewFakeClock(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ch := TickAligned(ctx, time.Minute, WithTickLocation(time.UTC), WithTickClock(clock))
+		for i := 0; i < 3; i++ {
+			clock.BlockUntil(1)
+			clock.Advance(time.Minute)
+		}
+		clock.BlockUntil(1)
+		assert.Equal(t, time.Date(2024, 5, 15, 0, 1, 0, 0, time.UTC), <-ch)
+		assert.Empty(t, ch)
+	})
+
+	t.Run("interval必须大于0", func(t *testing.T) {
+		assert.Panics(t, func() { TickAligned(context.Background(), 0) })
+	})
+}
@@ -0,0 +1,7 @@
+//go:build ktime_tzdata
+
+package ktime
+
+// 使用 -tags ktime_tzdata 编译时嵌入时区数据库,系统中没有时区数据库时 LoadZone 和
+// ConvertToZoneTimeStr 仍然可以解析 "Asia/Shanghai" 等时区名称
+import _ "time/tzdata"
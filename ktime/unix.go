@@ -0,0 +1,134 @@
+package ktime
+
+import (
+	"math"
+	"time"
+)
+
+// 以下转换函数都将零值时间和0互相转换,避免零值时间转换为负数的时间戳,
+// 或者0转换为1970-01-01后被当作有效的时间
+
+// UnixMilliToTime 将毫秒时间戳转换为时间,0转换为零值时间
+func UnixMilliToTime(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// UnixMicroToTime 将微秒时间戳转换为时间,0转换为零值时间
+func UnixMicroToTime(us int64) time.Time {
+	if us == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(us)
+}
+
+// UnixNanoToTime 将纳秒时间戳转换为时间,0转换为零值时间
+func UnixNanoToTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// TimeToUnixMilli 将时间转换为毫秒时间戳,零值时间转换为0
+func TimeToUnixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// TimeToUnixMicro 将时间转换为微秒时间戳,零值时间转换为0
+func TimeToUnixMicro(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMicro()
+}
+
+// TimeToUnixNano 将时间转换为纳秒时间戳,零值时间转换为0
+//
+// 注意事项:
+//   - 纳秒时间戳只能表示1678年到2262年之间的时间,超出范围时返回int64的最小值或最大值,
+//     而不是time.Time.UnixNano未定义的结果
+func TimeToUnixNano(t time.Time) int64 {
+	switch {
+	case t.IsZero():
+		return 0
+	case t.Before(minUnixNanoTime):
+		return math.MinInt64
+	case t.After(maxUnixNanoTime):
+		return math.MaxInt64
+	}
+	return t.UnixNano()
+}
+
+var (
+	minUnixNanoTime = time.Unix(0, math.MinInt64)
+	maxUnixNanoTime = time.Unix(0, math.MaxInt64)
+)
+
+// DetectUnit 根据数值的大小猜测时间戳的单位
+//
+// 参数:
+//   - n: 时间戳,可以为负数
+//
+// 返回值:
+//   - time.Duration: 时间戳的单位,为time.Second、time.Millisecond、time.Microsecond或time.Nanosecond
+//
+// 注意事项:
+//   - 按绝对值判断:小于1e11为秒,小于1e14为毫秒,小于1e17为微秒,否则为纳秒
+//   - 对于1973年到5138年之间的时间判断准确,秒级时间戳在1973年之前可能被误认为是毫秒等
+//
+// 示例:
+//
+//	DetectUnit(1715731200)          // time.Second
+//	DetectUnit(1715731200000)       // time.Millisecond
+//	DetectUnit(1715731200000000000) // time.Nanosecond
+func DetectUnit(n int64) time.Duration {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < 0: // math.MinInt64
+		return time.Nanosecond
+	case abs < 1e11:
+		return time.Second
+	case abs < 1e14:
+		return time.Millisecond
+	case abs < 1e17:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
+
+// UnixAutoToTime 使用 DetectUnit 判断时间戳的单位并转换为时间,0转换为零值时间
+//
+// 示例:
+//
+//	UnixAutoToTime(1715731200)    // 2024-05-15 00:00:00 UTC
+//	UnixAutoToTime(1715731200000) // 2024-05-15 00:00:00 UTC
+func UnixAutoToTime(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return unixAuto(n)
+}
+
+// unixAuto 根据大小判断时间戳的单位并转换为时间,0转换为1970-01-01
+func unixAuto(n int64) time.Time {
+	switch DetectUnit(n) {
+	case time.Second:
+		return time.Unix(n, 0)
+	case time.Millisecond:
+		return time.UnixMilli(n)
+	case time.Microsecond:
+		return time.UnixMicro(n)
+	default:
+		return time.Unix(0, n)
+	}
+}
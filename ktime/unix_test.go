@@ -0,0 +1,66 @@
+package ktime
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixConversions(t *testing.T) {
+	ts := time.Date(2024, 5, 15, 0, 0, 0, 123456789, time.UTC)
+
+	t.Run("毫秒", func(t *testing.T) {
+		assert.Equal(t, int64(1715731200123), TimeToUnixMilli(ts))
+		assert.True(t, ts.Truncate(time.Millisecond).Equal(UnixMilliToTime(1715731200123)))
+	})
+
+	t.Run("微秒", func(t *testing.T) {
+		assert.Equal(t, int64(1715731200123456), TimeToUnixMicro(ts))
+		assert.True(t, ts.Truncate(time.Microsecond).Equal(UnixMicroToTime(1715731200123456)))
+	})
+
+	t.Run("纳秒", func(t *testing.T) {
+		assert.Equal(t, int64(1715731200123456789), TimeToUnixNano(ts))
+		assert.True(t, ts.Equal(UnixNanoToTime(1715731200123456789)))
+		assert.Equal(t, int64(math.MaxInt64), TimeToUnixNano(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, int64(math.MinInt64), TimeToUnixNano(time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("零值", func(t *testing.T) {
+		assert.Equal(t, int64(0), TimeToUnixMilli(time.Time{}))
+		assert.Equal(t, int64(0), TimeToUnixMicro(time.Time{}))
+		assert.Equal(t, int64(0), TimeToUnixNano(time.Time{}))
+		assert.True(t, UnixMilliToTime(0).IsZero())
+		assert.True(t, UnixMicroToTime(0).IsZero())
+		assert.True(t, UnixNanoToTime(0).IsZero())
+		assert.True(t, UnixAutoToTime(0).IsZero())
+	})
+}
+
+func TestDetectUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int64
+		want time.Duration
+	}{
+		{"秒", 1715731200, time.Second},
+		{"毫秒", 1715731200123, time.Millisecond},
+		{"微秒", 1715731200123456, time.Microsecond},
+		{"纳秒", 1715731200123456789, time.Nanosecond},
+		{"负数秒", -86400, time.Second},
+		{"负数毫秒", -86400000000000, time.Millisecond},
+		{"最小值", math.MinInt64, time.Nanosecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectUnit(tt.n))
+		})
+	}
+
+	want := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+	for _, n := range []int64{1715731200, 1715731200000, 1715731200000000, 1715731200000000000} {
+		assert.True(t, want.Equal(UnixAutoToTime(n)), n)
+	}
+}
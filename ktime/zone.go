@@ -0,0 +1,104 @@
+package ktime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// 在没有时区数据库的环境中(如scratch、distroless镜像)time.LoadLocation会失败,
+// 使用 -tags ktime_tzdata 编译时会嵌入时区数据库(约450KB),参见 tzdata.go
+
+// defaultLocation 不指定时区时使用的 UTC+8
+var defaultLocation = time.FixedZone(DefaultZone, DefaultZoneOffset)
+
+// zoneCache 缓存解析成功的时区,避免重复读取时区数据库
+var zoneCache sync.Map // map[string]*time.Location
+
+// LoadZone 解析时区字符串,解析成功的结果会被缓存
+//
+// 参数:
+//   - zone: 时区,支持标准时区名称如 "UTC"、"Asia/Shanghai",小时偏移如 "+8"、"-5",
+//     带分钟的偏移如 "+08:00"、"-0330",以及带UTC或GMT前缀的偏移如 "UTC+8"、"GMT-05:30",前缀不区分大小写
+//
+// 返回值:
+//   - *time.Location: 时区,偏移格式返回固定偏移的时区,名称如 "UTC+8"、"UTC+05:30"
+//   - error: 无法解析时返回包装了 ErrInvalidZoneFormat 的错误,使用 errors.Is 判断
+//
+// 注意事项:
+//   - "GMT+8" 表示东八区,与 IANA 的 "Etc/GMT+8"(西八区)相反
+//   - 偏移最大为 ±23:59
+//
+// 示例:
+//
+//	loc, err := LoadZone("UTC+05:30")
+//	t := time.Now().In(loc)
+func LoadZone(zone string) (*time.Location, error) {
+	if loc, ok := zoneCache.Load(zone); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, ok := parseZoneOffset(zone)
+	if !ok {
+		var err error
+		if loc, err = time.LoadLocation(zone); err != nil {
+			return nil, errors.Wrapf(ErrInvalidZoneFormat, "%q", zone)
+		}
+	}
+	zoneCache.Store(zone, loc)
+	return loc, nil
+}
+
+// MustLoadZone 同 LoadZone,解析失败时panic
+func MustLoadZone(zone string) *time.Location {
+	loc, err := LoadZone(zone)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// parseZoneOffset 解析偏移格式的时区,不是偏移格式时返回false
+func parseZoneOffset(zone string) (*time.Location, bool) {
+	s := zone
+	if len(s) >= 3 && (strings.EqualFold(s[:3], "UTC") || strings.EqualFold(s[:3], "GMT")) {
+		s = s[3:]
+		if s == "" {
+			return time.UTC, true
+		}
+	}
+	sign := 1
+	switch {
+	case s == "":
+		return nil, false
+	case s[0] == '+':
+		s = s[1:]
+	case s[0] == '-':
+		sign, s = -1, s[1:]
+	}
+
+	hourStr, minStr, hasColon := strings.Cut(s, ":")
+	if !hasColon && len(s) == 4 {
+		hourStr, minStr = s[:2], s[2:]
+	}
+	if len(hourStr) == 0 || len(hourStr) > 2 || (hasColon || minStr != "") && len(minStr) != 2 || !isDigits(hourStr+minStr) {
+		return nil, false
+	}
+	hours, _ := strconv.Atoi(hourStr)
+	minutes := 0
+	if minStr != "" {
+		minutes, _ = strconv.Atoi(minStr)
+	}
+	if hours > 23 || minutes > 59 {
+		return nil, false
+	}
+
+	name := fmt.Sprintf("UTC%+d", sign*hours)
+	if minutes != 0 {
+		name = fmt.Sprintf("UTC%c%02d:%02d", "-+"[(sign+1)/2], hours, minutes)
+	}
+	return time.FixedZone(name, sign*(hours*60*60+minutes*60)), true
+}
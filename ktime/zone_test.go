@@ -0,0 +1,68 @@
+package ktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadZone(t *testing.T) {
+	ts := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		zone   string
+		name   string
+		offset int
+	}{
+		{"+8", "UTC+8", 8 * 3600},
+		{"-5", "UTC-5", -5 * 3600},
+		{"8", "UTC+8", 8 * 3600},
+		{"+08:00", "UTC+8", 8 * 3600},
+		{"+0530", "UTC+05:30", 5*3600 + 30*60},
+		{"+5:45", "UTC+05:45", 5*3600 + 45*60},
+		{"UTC+8", "UTC+8", 8 * 3600},
+		{"UTC+08:00", "UTC+8", 8 * 3600},
+		{"utc-03:30", "UTC-03:30", -(3*3600 + 30*60)},
+		{"GMT+8", "UTC+8", 8 * 3600},
+		{"GMT-0930", "UTC-09:30", -(9*3600 + 30*60)},
+		{"GMT", "UTC", 0},
+		{"UTC", "UTC", 0},
+		{"", "UTC", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			loc, err := LoadZone(tt.zone)
+			assert.NoError(t, err)
+			name, offset := ts.In(loc).Zone()
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.offset, offset)
+		})
+	}
+
+	t.Run("标准时区名称", func(t *testing.T) {
+		loc, err := LoadZone("America/New_York")
+		assert.NoError(t, err)
+		_, offset := ts.In(loc).Zone()
+		assert.Equal(t, -4*3600, offset)
+	})
+
+	t.Run("缓存", func(t *testing.T) {
+		loc1, _ := LoadZone("Asia/Shanghai")
+		loc2, _ := LoadZone("Asia/Shanghai")
+		assert.Same(t, loc1, loc2)
+	})
+
+	t.Run("无效的时区", func(t *testing.T) {
+		for _, zone := range []string{"+", "UTC+", "+24", "+08:60", "+8:", "+845", "+8abc", "invalid_zone", "GMT+08:0"} {
+			_, err := LoadZone(zone)
+			assert.ErrorIs(t, err, ErrInvalidZoneFormat, zone)
+		}
+		assert.Panics(t, func() { MustLoadZone("invalid_zone") })
+	})
+}
+
+func TestConvertToZoneTimeStrOffsetMinutes(t *testing.T) {
+	str, err := ConvertToZoneTimeStr(1684154445, "2006-01-02 15:04:05 -07:00", "UTC+05:30")
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-05-15 18:10:45 +05:30", str)
+}
@@ -3,7 +3,10 @@ package kunique
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 const (
@@ -18,20 +21,65 @@ const (
 	timestampShift = sequenceBits + nodeIDBits         // 时间戳左移位数
 )
 
+var (
+	ErrTimestampOverflow = errors.New("kunique: timestamp overflow")
+)
+
 var defaultUniqueNode *UniqueNode
 var defaultUniqueNodeOnce sync.Once
 
+// UniqueNodeOptions 唯一ID生成节点的配置项
+type UniqueNodeOptions struct {
+	Sequencer Sequencer        // 持久化已保留的时间戳,保证ID严格递增,默认为 MemorySequencer
+	Lease     time.Duration    // 每次向 Sequencer 保留的时间,越大调用 Sequencer 越少,重启后跳过的时间也越多
+	Exhausted *ExhaustionAlert // 序列号频繁用尽时的告警,默认不告警
+}
+
+type UniqueNodeOption func(*UniqueNodeOptions)
+
+func NewUniqueNodeOptions() *UniqueNodeOptions {
+	return &UniqueNodeOptions{
+		Sequencer: NewMemorySequencer(),
+		Lease:     time.Second,
+	}
+}
+
+// WithSequencer 设置持久化已保留时间戳的 Sequencer
+func WithSequencer(s Sequencer) UniqueNodeOption {
+	return func(o *UniqueNodeOptions) {
+		o.Sequencer = s
+	}
+}
+
+// WithSequencerLease 设置每次向 Sequencer 保留的时间
+func WithSequencerLease(d time.Duration) UniqueNodeOption {
+	return func(o *UniqueNodeOptions) {
+		o.Lease = d
+	}
+}
+
 type UniqueNode struct {
 	mu        sync.Mutex
+	opts      *UniqueNodeOptions
 	nodeID    int64 // 机器ID
 	sequence  int64 // 序列号
 	timestamp int64 // 时间戳 ，毫秒
+	reserved  int64 // 已经向 Sequencer 保留的时间戳，毫秒
+	loaded    bool  // 是否已经从 Sequencer 读取保留的时间戳
+	lastWall  int64 // 上一次读取的系统时间，毫秒，用于检测时钟回拨
+
+	generated       atomic.Int64 // 已生成的ID数量
+	exhaustionWaits atomic.Int64 // 序列号用尽的次数
+	rollbacks       atomic.Int64 // 检测到时钟回拨的次数
+	exhaustedStart  int64        // 当前告警统计周期的开始时间，毫秒
+	exhaustedCount  int64        // 当前告警统计周期内序列号用尽的次数
 }
 
 // NewUniqueNode 创建一个新的唯一ID生成节点
 //
 // 参数说明:
 //   - nodeID: 节点ID，范围必须在0到1023之间
+//   - opts: 可选配置项，参见 UniqueNodeOptions
 //
 // 返回值说明:
 //   - *UniqueNode: 返回初始化后的唯一ID生成节点
@@ -40,15 +88,21 @@ type UniqueNode struct {
 //   - 如果nodeID超出范围，会触发panic
 //   - 每个节点ID对应一个唯一的生成器实例
 //   - 建议在系统启动时初始化并保持单例
+//   - 默认只保证进程内ID严格递增，需要跨进程重启保证时使用 WithSequencer 设置持久化的 Sequencer
 //
 // 示例:
 //
 //	node := NewUniqueNode(1) // 创建节点ID为1的生成器
-func NewUniqueNode(nodeID int64) *UniqueNode {
+//	node = NewUniqueNode(1, WithSequencer(NewRedisSequencer(eval, "kunique:node")))
+func NewUniqueNode(nodeID int64, opts ...UniqueNodeOption) *UniqueNode {
 	if nodeID < 0 || nodeID > nodeIDMax {
 		panic("nodeID must be between 0 and 1023")
 	}
-	return &UniqueNode{nodeID: nodeID}
+	o := NewUniqueNodeOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &UniqueNode{opts: o, nodeID: nodeID}
 }
 
 // GenerateUniqueID 生成一个全局唯一的ID
@@ -74,10 +128,15 @@ func GenerateUniqueID(nodeID ...int64) int64 {
 	if len(nodeID) > 0 {
 		nodeIDFlag = nodeID[0]
 	}
+	return defaultNode(nodeIDFlag).Generate()
+}
+
+// defaultNode 返回全局节点，第一次调用时使用nodeID初始化
+func defaultNode(nodeID int64) *UniqueNode {
 	defaultUniqueNodeOnce.Do(func() {
-		defaultUniqueNode = NewUniqueNode(nodeIDFlag)
+		defaultUniqueNode = NewUniqueNode(nodeID)
 	})
-	return defaultUniqueNode.Generate()
+	return defaultUniqueNode
 }
 
 // Generate 生成一个全局唯一的ID
@@ -90,7 +149,7 @@ func GenerateUniqueID(nodeID ...int64) int64 {
 //
 // 注意事项:
 //   - 该方法是线程安全的，使用互斥锁保证并发安全
-//   - 如果时间戳超出最大值(41位)，会返回0
+//   - 如果时间戳超出最大值(41位)或 Sequencer 出错，会返回0，需要错误信息时使用 NextID
 //   - 同一毫秒内生成的ID会递增序列号
 //   - 当序列号超出最大值(16位)时，会等待到下一毫秒再生成
 //   - ID结构: 41位时间戳 | 6位节点ID | 16位序列号
@@ -100,29 +159,83 @@ func GenerateUniqueID(nodeID ...int64) int64 {
 //	node := NewUniqueNode(1)
 //	id := node.Generate() // 生成唯一ID
 func (s *UniqueNode) Generate() int64 {
+	id, err := s.NextID()
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// NextID 生成一个严格递增的唯一ID
+//
+// 返回值说明:
+//   - int64: 返回生成的64位唯一ID
+//   - error: 时间戳超出最大值时返回 ErrTimestampOverflow，或返回 Sequencer 的错误
+//
+// 注意事项:
+//   - 时钟回拨时继续使用上一次的时间戳，不会等待时钟追上
+//   - 使用超过已保留的时间戳前会先向 Sequencer 保留，出错时不会生成ID
+func (s *UniqueNode) NextID() (int64, error) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	now := time.Now().UnixMilli() // 转毫秒
-	if s.timestamp == now {
+	id, alert, err := s.next()
+	s.mu.Unlock()
+	if alert {
+		// 在锁外回调，避免回调中生成ID导致死锁
+		s.opts.Exhausted.Handler(s.Stats())
+	}
+	return id, err
+}
+
+// next 生成ID，调用方需要持有锁
+// 返回的alert表示是否需要调用序列号用尽的告警回调
+func (s *UniqueNode) next() (id int64, alert bool, err error) {
+	if !s.loaded {
+		reserved, err := s.opts.Sequencer.Load(s.nodeID)
+		if err != nil {
+			return 0, false, err
+		}
+		if reserved > s.timestamp {
+			// 保留过的时间戳可能已经被使用，从下一毫秒的序列号0开始，不计入序列号用尽
+			s.timestamp, s.sequence = reserved+1, -1
+		}
+		s.reserved = max(s.reserved, reserved)
+		s.loaded = true
+	}
+	now, sequence := time.Now().UnixMilli(), int64(0) // 转毫秒
+	if now < s.lastWall {
+		s.rollbacks.Add(1)
+	}
+	s.lastWall = now
+	if now <= s.timestamp {
 		// 当同一时间戳（精度：毫秒）下多次生成id会增加序列号
-		s.sequence = (s.sequence + 1) & sequenceMask
-		if s.sequence == 0 {
-			// 如果当前序列超出12bit长度，则需要等待下一毫秒
+		// 时钟回拨时沿用上一次的时间戳
+		now = s.timestamp
+		sequence = s.sequence + 1
+		if sequence > sequenceMask {
+			// 如果当前序列超出16bit长度，则需要等待下一毫秒
 			// 下一毫秒将使用sequence:0
-			for now <= s.timestamp {
-				now = time.Now().UnixMilli()
+			sequence = 0
+			s.exhaustionWaits.Add(1)
+			alert = s.exhausted(now)
+			now = s.timestamp + 1
+			for time.Now().UnixMilli() == s.timestamp {
 			}
 		}
-	} else {
-		// 不同时间戳（精度：毫秒）下直接使用序列号：0
-		s.sequence = 0
 	}
 	t := now - epoch
 	if t > timestampMax {
-		return 0
+		return 0, alert, ErrTimestampOverflow
+	}
+	if now > s.reserved {
+		reserved := now + s.opts.Lease.Milliseconds()
+		if err := s.opts.Sequencer.Store(s.nodeID, reserved); err != nil {
+			return 0, alert, err
+		}
+		s.reserved = reserved
 	}
-	s.timestamp = now
+	s.timestamp, s.sequence = now, sequence
+	s.generated.Add(1)
 	r := t<<timestampShift | (s.nodeID << nodeIDShift) | (s.sequence)
 
-	return r
+	return r, alert, nil
 }
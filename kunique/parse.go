@@ -0,0 +1,89 @@
+package kunique
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidID = errors.New("kunique: invalid id")
+)
+
+// IDInfo 唯一ID分解后的各个部分
+type IDInfo struct {
+	ID       int64     // 原始ID
+	Time     time.Time // 生成ID时的时间,精度为毫秒
+	NodeID   int64     // 生成ID的节点ID
+	Sequence int64     // 同一毫秒内的序列号
+}
+
+// Parse 将唯一ID分解为时间、节点ID和序列号
+//
+// 参数说明:
+//   - id: 由 UniqueNode 或 GenerateUniqueID 生成的ID
+//
+// 返回值说明:
+//   - IDInfo: 分解后的各个部分
+//   - error: id小于0时返回 ErrInvalidID
+//
+// 注意事项:
+//   - 只校验ID的范围,无法判断ID是否真的由本包生成
+//
+// 示例:
+//
+//	info, err := Parse(id)
+//	fmt.Println(info.Time, info.NodeID, info.Sequence)
+func Parse(id int64) (IDInfo, error) {
+	if id < 0 {
+		return IDInfo{}, errors.Wrapf(ErrInvalidID, "%d", id)
+	}
+	return IDInfo{
+		ID:       id,
+		Time:     time.UnixMilli((id >> timestampShift) + epoch),
+		NodeID:   (id >> nodeIDShift) & nodeIDMax,
+		Sequence: id & sequenceMask,
+	}, nil
+}
+
+// TimeToID 返回指定时间生成的最小ID,即节点ID和序列号都为0的ID
+//
+// 参数说明:
+//   - t: 时间,精度为毫秒,早于起始时间时返回0,超出时间戳范围时返回最大的时间戳对应的ID
+//
+// 示例:
+//
+//	// 查询某个时间之后创建的记录
+//	db.Where("id >= ?", TimeToID(t))
+func TimeToID(t time.Time) int64 {
+	ms := t.UnixMilli() - epoch
+	if ms < 0 {
+		return 0
+	}
+	if ms > timestampMax {
+		ms = timestampMax
+	}
+	return ms << timestampShift
+}
+
+// TimeRangeToIDRange 将时间范围[start, end)转换为ID范围[minID, maxID)
+//
+// 参数说明:
+//   - start: 开始时间,包含
+//   - end: 结束时间,不包含
+//
+// 返回值说明:
+//   - minID: 在start及之后生成的ID都大于等于minID
+//   - maxID: 在end之前生成的ID都小于maxID
+//
+// 注意事项:
+//   - 时间精度为毫秒
+//   - 适用于按时间窗口过滤以唯一ID为主键的数据,可以利用主键索引
+//
+// 示例:
+//
+//	minID, maxID := TimeRangeToIDRange(today, today.AddDate(0, 0, 1))
+//	db.Where("id >= ? AND id < ?", minID, maxID)
+func TimeRangeToIDRange(start, end time.Time) (minID, maxID int64) {
+	return TimeToID(start), TimeToID(end)
+}
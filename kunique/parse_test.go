@@ -0,0 +1,53 @@
+package kunique
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	node := NewUniqueNode(7)
+	before := time.Now().Truncate(time.Millisecond)
+	id1 := node.Generate()
+	id2 := node.Generate()
+	after := time.Now()
+
+	info, err := Parse(id1)
+	assert.NoError(t, err)
+	assert.Equal(t, id1, info.ID)
+	assert.Equal(t, int64(7), info.NodeID)
+	assert.False(t, info.Time.Before(before))
+	assert.False(t, info.Time.After(after))
+
+	info2, err := Parse(id2)
+	assert.NoError(t, err)
+	if info2.Time.Equal(info.Time) {
+		assert.Equal(t, info.Sequence+1, info2.Sequence)
+	}
+
+	_, err = Parse(-1)
+	assert.ErrorIs(t, err, ErrInvalidID)
+}
+
+func TestTimeRangeToIDRange(t *testing.T) {
+	start := time.Now()
+	id := NewUniqueNode(nodeIDMax).Generate()
+	end := time.Now().Add(time.Millisecond)
+
+	minID, maxID := TimeRangeToIDRange(start, end)
+	assert.GreaterOrEqual(t, id, minID)
+	assert.Less(t, id, maxID)
+
+	minID, maxID = TimeRangeToIDRange(end, end.Add(time.Hour))
+	assert.Less(t, id, minID)
+	assert.Less(t, minID, maxID)
+
+	assert.Equal(t, int64(0), TimeToID(time.UnixMilli(epoch-1)))
+	info, err := Parse(TimeToID(start))
+	assert.NoError(t, err)
+	assert.Equal(t, start.UnixMilli(), info.Time.UnixMilli())
+	assert.Equal(t, int64(0), info.NodeID)
+	assert.Equal(t, int64(0), info.Sequence)
+}
@@ -0,0 +1,120 @@
+package kunique
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Sequencer 持久化每个节点已经保留的最大时间戳(毫秒),用于保证ID在进程重启和时钟回拨后仍然严格递增
+//
+// UniqueNode 在使用超过已保留时间戳的时间生成ID之前,会先调用 Store 保留一段时间(参见 WithSequencerLease),
+// 重启后通过 Load 读取保留的时间戳,并从下一毫秒开始生成ID
+type Sequencer interface {
+	// Load 返回节点已经保留的最大时间戳,没有记录时返回0
+	Load(nodeID int64) (int64, error)
+	// Store 保留节点的时间戳,实现需要保证保留的时间戳不会变小
+	Store(nodeID int64, timestamp int64) error
+}
+
+var (
+	_ Sequencer = (*MemorySequencer)(nil)
+	_ Sequencer = (*RedisSequencer)(nil)
+)
+
+// MemorySequencer 基于内存的 Sequencer,UniqueNode 的默认实现
+// 只能保证进程内的ID严格递增,进程重启后记录会丢失
+type MemorySequencer struct {
+	mu         sync.Mutex
+	timestamps map[int64]int64
+}
+
+// NewMemorySequencer 创建一个基于内存的 Sequencer
+func NewMemorySequencer() *MemorySequencer {
+	return &MemorySequencer{timestamps: make(map[int64]int64)}
+}
+
+// Load 返回节点已经保留的最大时间戳
+func (s *MemorySequencer) Load(nodeID int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.timestamps[nodeID], nil
+}
+
+// Store 保留节点的时间戳,小于已保留的时间戳时无效果
+func (s *MemorySequencer) Store(nodeID int64, timestamp int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if timestamp > s.timestamps[nodeID] {
+		s.timestamps[nodeID] = timestamp
+	}
+	return nil
+}
+
+// RedisEvalFunc 执行redis的EVAL命令,用于适配不同的redis客户端
+type RedisEvalFunc func(script string, keys []string, args ...any) (any, error)
+
+const (
+	redisLoadScript  = `return tonumber(redis.call('GET', KEYS[1]) or '0')`
+	redisStoreScript = `if tonumber(ARGV[1]) > tonumber(redis.call('GET', KEYS[1]) or '0') then redis.call('SET', KEYS[1], ARGV[1]) end return 0`
+)
+
+// RedisSequencer 基于redis的 Sequencer,每个节点的时间戳保存在 "<prefix>:<nodeID>" 中
+// 进程重启后仍然可以保证ID严格递增
+type RedisSequencer struct {
+	eval   RedisEvalFunc
+	prefix string
+}
+
+// NewRedisSequencer 创建一个基于redis的 Sequencer
+//
+// 参数说明:
+//   - eval: 执行EVAL命令的函数,不依赖具体的redis客户端
+//   - prefix: key的前缀
+//
+// 注意事项:
+//   - UniqueNode 持有锁时调用 Sequencer,eval需要设置超时时间
+//   - 通过lua脚本保证保留的时间戳不会变小
+//
+// 示例:
+//
+//	// 使用 github.com/redis/go-redis
+//	seq := NewRedisSequencer(func(script string, keys []string, args ...any) (any, error) {
+//	    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	    defer cancel()
+//	    return rdb.Eval(ctx, script, keys, args...).Result()
+//	}, "kunique:node")
+//	node := NewUniqueNode(1, WithSequencer(seq))
+func NewRedisSequencer(eval RedisEvalFunc, prefix string) *RedisSequencer {
+	return &RedisSequencer{eval: eval, prefix: prefix}
+}
+
+// Load 返回节点已经保留的最大时间戳
+func (s *RedisSequencer) Load(nodeID int64) (int64, error) {
+	reply, err := s.eval(redisLoadScript, []string{s.key(nodeID)})
+	if err != nil {
+		return 0, errors.Wrap(err, "kunique: load timestamp from redis")
+	}
+	switch v := reply.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, errors.Errorf("kunique: unexpected redis reply %T", reply)
+	}
+}
+
+// Store 保留节点的时间戳,小于已保留的时间戳时无效果
+func (s *RedisSequencer) Store(nodeID int64, timestamp int64) error {
+	_, err := s.eval(redisStoreScript, []string{s.key(nodeID)}, timestamp)
+	return errors.Wrap(err, "kunique: store timestamp to redis")
+}
+
+func (s *RedisSequencer) key(nodeID int64) string {
+	return fmt.Sprintf("%s:%d", s.prefix, nodeID)
+}
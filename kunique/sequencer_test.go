@@ -0,0 +1,92 @@
+package kunique
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedis 模拟redis执行 RedisSequencer 使用的脚本
+type fakeRedis map[string]string
+
+func (r fakeRedis) eval(script string, keys []string, args ...any) (any, error) {
+	cur, _ := strconv.ParseInt(r[keys[0]], 10, 64)
+	switch script {
+	case redisLoadScript:
+		return cur, nil
+	case redisStoreScript:
+		if v := args[0].(int64); v > cur {
+			r[keys[0]] = strconv.FormatInt(v, 10)
+		}
+		return int64(0), nil
+	}
+	return nil, errors.New("unknown script")
+}
+
+type errSequencer struct{ err error }
+
+func (s errSequencer) Load(int64) (int64, error) { return 0, nil }
+func (s errSequencer) Store(int64, int64) error  { return s.err }
+
+func TestSequencerRestart(t *testing.T) {
+	redis := fakeRedis{}
+	seq := NewRedisSequencer(redis.eval, "kunique:node")
+
+	node := NewUniqueNode(1, WithSequencer(seq), WithSequencerLease(time.Hour))
+	var last int64
+	for i := 0; i < 100; i++ {
+		id, err := node.NextID()
+		assert.NoError(t, err)
+		assert.Greater(t, id, last)
+		last = id
+	}
+	assert.Contains(t, redis, "kunique:node:1")
+
+	reserved, err := seq.Load(1)
+	assert.NoError(t, err)
+
+	// 模拟重启,新的节点从保留的时间戳之后开始生成
+	restarted := NewUniqueNode(1, WithSequencer(seq))
+	id, err := restarted.NextID()
+	assert.NoError(t, err)
+	assert.Greater(t, id, last)
+	info, _ := Parse(id)
+	assert.Equal(t, reserved+1, info.Time.UnixMilli())
+}
+
+func TestSequencerClockBehind(t *testing.T) {
+	// 保留的时间戳在未来,相当于时钟回拨
+	seq := NewMemorySequencer()
+	future := time.Now().Add(time.Minute).UnixMilli()
+	assert.NoError(t, seq.Store(2, future))
+	assert.NoError(t, seq.Store(2, future-1), "保留的时间戳不会变小")
+	reserved, _ := seq.Load(2)
+	assert.Equal(t, future, reserved)
+
+	node := NewUniqueNode(2, WithSequencer(seq))
+	var last int64
+	for i := 0; i < int(sequenceMask)+10; i++ {
+		id := node.Generate()
+		assert.Greater(t, id, last)
+		last = id
+	}
+	info, _ := Parse(last)
+	assert.Equal(t, future+2, info.Time.UnixMilli(), "序列号用尽时使用下一毫秒,不等待时钟")
+}
+
+func TestSequencerError(t *testing.T) {
+	storeErr := errors.New("store failed")
+	node := NewUniqueNode(3, WithSequencer(errSequencer{err: storeErr}))
+	_, err := node.NextID()
+	assert.ErrorIs(t, err, storeErr)
+	assert.Equal(t, int64(0), node.Generate())
+
+	seq := NewRedisSequencer(func(string, []string, ...any) (any, error) {
+		return nil, storeErr
+	}, "kunique:node")
+	_, err = seq.Load(1)
+	assert.ErrorIs(t, err, storeErr)
+}
@@ -0,0 +1,78 @@
+package kunique
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ExhaustionAlert 序列号频繁用尽时的告警配置
+type ExhaustionAlert struct {
+	Threshold int64           // 一个统计周期内序列号用尽的次数达到该值时告警
+	Interval  time.Duration   // 统计周期
+	Handler   func(NodeStats) // 告警回调,每个统计周期最多调用一次
+}
+
+// WithExhaustionAlert 设置序列号频繁用尽时的告警回调
+//
+// 参数说明:
+//   - threshold: 一个统计周期内序列号用尽的次数达到该值时告警
+//   - interval: 统计周期
+//   - handler: 告警回调,在生成ID的协程中同步调用,不要执行耗时操作
+//
+// 注意事项:
+//   - 序列号用尽说明单个节点每毫秒生成的ID超过65536个,需要增加节点或降低生成速度
+//
+// 示例:
+//
+//	node := NewUniqueNode(1, WithExhaustionAlert(100, time.Second, func(stats NodeStats) {
+//	    log.Printf("node %d sequence exhausted %d times", stats.NodeID, stats.ExhaustionWaits)
+//	}))
+func WithExhaustionAlert(threshold int64, interval time.Duration, handler func(NodeStats)) UniqueNodeOption {
+	return func(o *UniqueNodeOptions) {
+		o.Exhausted = &ExhaustionAlert{Threshold: threshold, Interval: interval, Handler: handler}
+	}
+}
+
+// NodeStats 唯一ID生成节点的统计信息
+type NodeStats struct {
+	NodeID          int64 `json:"node_id"`          // 节点ID
+	Generated       int64 `json:"generated"`        // 已生成的ID数量
+	ExhaustionWaits int64 `json:"exhaustion_waits"` // 序列号用尽,需要使用下一毫秒的次数
+	Rollbacks       int64 `json:"rollbacks"`        // 检测到时钟回拨的次数
+}
+
+// Stats 返回节点的统计信息
+//
+// 注意事项:
+//   - kmonitor 依赖本包,所以本包不直接注册指标,可以将节点注册到 kmonitor 的注册表中
+//
+// 示例:
+//
+//	node := NewUniqueNode(1)
+//	kmonitor.Register("kunique", node, kmonitor.Labels{"node": "1"})
+func (s *UniqueNode) Stats() NodeStats {
+	return NodeStats{
+		NodeID:          s.nodeID,
+		Generated:       s.generated.Load(),
+		ExhaustionWaits: s.exhaustionWaits.Load(),
+		Rollbacks:       s.rollbacks.Load(),
+	}
+}
+
+// MarshalJSON 将统计信息编码为JSON,参见 Stats
+func (s *UniqueNode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Stats())
+}
+
+// exhausted 记录一次序列号用尽,返回是否需要告警,调用方需要持有锁
+func (s *UniqueNode) exhausted(now int64) bool {
+	alert := s.opts.Exhausted
+	if alert == nil || alert.Handler == nil {
+		return false
+	}
+	if now-s.exhaustedStart >= alert.Interval.Milliseconds() {
+		s.exhaustedStart, s.exhaustedCount = now, 0
+	}
+	s.exhaustedCount++
+	return s.exhaustedCount == alert.Threshold
+}
@@ -0,0 +1,59 @@
+package kunique
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeStats(t *testing.T) {
+	// 保留的时间戳在未来,序列号用尽时不需要等待时钟
+	seq := NewMemorySequencer()
+	assert.NoError(t, seq.Store(5, time.Now().Add(time.Minute).UnixMilli()))
+
+	var alerts []NodeStats
+	node := NewUniqueNode(5, WithSequencer(seq), WithExhaustionAlert(2, time.Hour, func(stats NodeStats) {
+		alerts = append(alerts, stats)
+	}))
+
+	n := 3*(int(sequenceMask)+1) + 10
+	for i := 0; i < n; i++ {
+		node.Generate()
+	}
+	stats := node.Stats()
+	assert.Equal(t, int64(n), stats.Generated)
+	// 从保留的时间戳的下一毫秒开始,每65536个ID用尽一次
+	assert.Equal(t, int64(3), stats.ExhaustionWaits)
+	assert.Len(t, alerts, 1, "每个统计周期最多告警一次")
+	assert.Equal(t, int64(5), alerts[0].NodeID)
+	assert.Equal(t, int64(2), alerts[0].ExhaustionWaits)
+
+	// 模拟时钟回拨
+	node.mu.Lock()
+	node.lastWall = time.Now().Add(time.Minute).UnixMilli()
+	node.mu.Unlock()
+	node.Generate()
+	node.Generate()
+	assert.Equal(t, int64(1), node.Stats().Rollbacks)
+
+	data, err := json.Marshal(node)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"node_id":5,"generated":196620,"exhaustion_waits":3,"rollbacks":1}`, string(data))
+}
+
+func TestNodeStatsRestart(t *testing.T) {
+	seq := NewMemorySequencer()
+	node := NewUniqueNode(6, WithSequencer(seq))
+	last := node.Generate()
+	assert.NotZero(t, last)
+
+	// 模拟重启,从保留的时间戳之后开始不计入序列号用尽
+	restarted := NewUniqueNode(6, WithSequencer(seq))
+	id := restarted.Generate()
+	assert.Greater(t, id, last)
+	info, _ := Parse(id)
+	assert.Equal(t, int64(0), info.Sequence)
+	assert.Equal(t, int64(0), restarted.Stats().ExhaustionWaits)
+}
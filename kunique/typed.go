@@ -0,0 +1,120 @@
+package kunique
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidTypedID = errors.New("kunique: invalid typed id")
+	ErrPrefixMismatch = errors.New("kunique: typed id prefix mismatch")
+)
+
+const (
+	typedIDSeparator = "_"
+	base62Alphabet   = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz" // 按ASCII升序排列,保证编码后的字符串顺序与ID顺序一致
+	base62Len        = 11                                                               // 62^11 > 2^63,int64编码后的固定长度
+)
+
+// TypedID 带类型前缀的唯一ID生成器,生成形如 "ord_0AbC9f3kX2z" 的ID
+// 不同类型的ID前缀不同,可以避免混用,例如把订单ID当成用户ID查询
+type TypedID struct {
+	prefix string
+	node   *UniqueNode
+}
+
+// NewTypedID 创建一个带类型前缀的唯一ID生成器
+//
+// 参数说明:
+//   - prefix: 类型前缀,只能包含小写字母和数字,不能为空
+//   - node: 可选参数,生成ID使用的节点,默认使用 GenerateUniqueID 的全局节点
+//
+// 注意事项:
+//   - 如果prefix不合法,会触发panic
+//   - 唯一部分是固定11位的base62编码,字符串顺序与ID的生成顺序一致
+//
+// 示例:
+//
+//	orderID := NewTypedID("ord")
+//	id := orderID.Generate() // ord_0AbC9f3kX2z
+//	raw, err := orderID.Parse(id)
+func NewTypedID(prefix string, node ...*UniqueNode) *TypedID {
+	if prefix == "" || strings.IndexFunc(prefix, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) >= 0 {
+		panic("prefix must be non-empty lowercase letters or digits")
+	}
+	t := &TypedID{prefix: prefix}
+	if len(node) > 0 {
+		t.node = node[0]
+	}
+	return t
+}
+
+// Prefix 返回类型前缀
+func (t *TypedID) Prefix() string {
+	return t.prefix
+}
+
+// Generate 生成一个带类型前缀的唯一ID,生成失败时返回空字符串,参见 UniqueNode.NextID
+func (t *TypedID) Generate() string {
+	node := t.node
+	if node == nil {
+		node = defaultNode(1)
+	}
+	id, err := node.NextID()
+	if err != nil {
+		return ""
+	}
+	return t.Format(id)
+}
+
+// Format 将唯一ID格式化为带类型前缀的ID
+// 参数:
+//   - id: 唯一ID,小于0时返回空字符串
+func (t *TypedID) Format(id int64) string {
+	if id < 0 {
+		return ""
+	}
+	var buf [base62Len]byte
+	n := uint64(id)
+	for i := base62Len - 1; i >= 0; i-- {
+		buf[i] = base62Alphabet[n%62]
+		n /= 62
+	}
+	return t.prefix + typedIDSeparator + string(buf[:])
+}
+
+// Parse 解析带类型前缀的ID,返回原始的唯一ID
+// 返回:
+//   - int64: 原始的唯一ID,可以继续使用 Parse 分解
+//   - error: 前缀不一致时返回 ErrPrefixMismatch,格式不正确时返回 ErrInvalidTypedID
+func (t *TypedID) Parse(s string) (int64, error) {
+	prefix, encoded, ok := strings.Cut(s, typedIDSeparator)
+	if !ok {
+		return 0, errors.Wrapf(ErrInvalidTypedID, "%q", s)
+	}
+	if prefix != t.prefix {
+		return 0, errors.Wrapf(ErrPrefixMismatch, "want %q, got %q", t.prefix, prefix)
+	}
+	if len(encoded) != base62Len {
+		return 0, errors.Wrapf(ErrInvalidTypedID, "%q", s)
+	}
+	var n uint64
+	for i := 0; i < len(encoded); i++ {
+		d := strings.IndexByte(base62Alphabet, encoded[i])
+		if d < 0 {
+			return 0, errors.Wrapf(ErrInvalidTypedID, "%q", s)
+		}
+		hi := n * 62
+		if hi/62 != n || hi+uint64(d) < hi {
+			return 0, errors.Wrapf(ErrInvalidTypedID, "%q", s)
+		}
+		n = hi + uint64(d)
+	}
+	if n > 1<<63-1 {
+		return 0, errors.Wrapf(ErrInvalidTypedID, "%q", s)
+	}
+	return int64(n), nil
+}
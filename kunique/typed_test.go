@@ -0,0 +1,51 @@
+package kunique
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedID(t *testing.T) {
+	orders := NewTypedID("ord", NewUniqueNode(3))
+	users := NewTypedID("usr")
+	assert.Equal(t, "ord", orders.Prefix())
+
+	id1, id2 := orders.Generate(), orders.Generate()
+	assert.True(t, strings.HasPrefix(id1, "ord_"))
+	assert.Len(t, id1, len("ord_")+base62Len)
+	assert.Less(t, id1, id2, "字符串顺序与生成顺序一致")
+
+	raw, err := orders.Parse(id1)
+	assert.NoError(t, err)
+	assert.Equal(t, id1, orders.Format(raw))
+	info, err := Parse(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), info.NodeID)
+
+	_, err = users.Parse(id1)
+	assert.ErrorIs(t, err, ErrPrefixMismatch)
+	assert.True(t, strings.HasPrefix(users.Generate(), "usr_"))
+
+	for _, v := range []int64{0, 1, 61, 62, math.MaxInt64} {
+		raw, err := orders.Parse(orders.Format(v))
+		assert.NoError(t, err)
+		assert.Equal(t, v, raw)
+	}
+
+	for _, s := range []string{"", "ord", "ord_", "ord_123", "ord_0000000000-", "ord_zzzzzzzzzzz", "ord_AzL8n0Y58m8"} {
+		_, err := orders.Parse(s)
+		assert.ErrorIs(t, err, ErrInvalidTypedID, s)
+	}
+
+	assert.Empty(t, orders.Format(-1), "小于0的ID")
+	failed := NewTypedID("ord", NewUniqueNode(4, WithSequencer(errSequencer{err: errors.New("store failed")})))
+	assert.Empty(t, failed.Generate(), "生成失败时返回空字符串")
+
+	assert.Panics(t, func() { NewTypedID("") })
+	assert.Panics(t, func() { NewTypedID("Ord") })
+	assert.Panics(t, func() { NewTypedID("o_d") })
+}